@@ -23,43 +23,50 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/admin/services": {
+        "/api/admin/booking-exceptions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of all services",
+                "description": "Get the dates with exceptional working hours or closures between two dates",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Services"
+                    "Admin - Bookings"
                 ],
-                "summary": "Get all services (Admin only)",
+                "summary": "Get booking exceptions (Admin only)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to today",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "maximum": 100,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to 90 days after from",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of services",
+                        "description": "Exceptions",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetAllServicesResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -97,7 +104,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new service for car owners",
+                "description": "Replace the working hours of a date. Without opens_at and closes_at the date is closed; several\nperiods can be added for the same date. Existing bookings are kept.",
                 "consumes": [
                     "application/json"
                 ],
@@ -105,26 +112,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Services"
+                    "Admin - Bookings"
                 ],
-                "summary": "Create a new service (Admin only)",
+                "summary": "Add a booking exception (Admin only)",
                 "parameters": [
                     {
-                        "description": "Service details",
+                        "description": "Exception",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateServiceRequest"
+                            "$ref": "#/definitions/handlers.BookingExceptionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Service created successfully",
+                        "description": "Exception created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.BookingException"
                         }
                     },
                     "400": {
@@ -166,35 +172,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/services/{uuid}": {
-            "get": {
+        "/api/admin/booking-exceptions/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get service details by UUID",
+                "description": "Delete an exception, restoring the working hours of its date",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Services"
+                    "Admin - Bookings"
                 ],
-                "summary": "Get a service by UUID (Admin only)",
+                "summary": "Delete a booking exception (Admin only)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Service UUID",
-                        "name": "uuid",
+                        "type": "integer",
+                        "description": "Exception ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service details",
+                        "description": "Exception deleted",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ServiceData"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -216,7 +234,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Service not found",
+                        "description": "Exception not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -234,59 +252,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/admin/booking-hours": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update service information by UUID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the weekly working hours in which appointments can be booked",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Services"
-                ],
-                "summary": "Update a service (Admin only)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Service UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Service update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateServiceRequest"
-                        }
-                    }
+                    "Admin - Bookings"
                 ],
+                "summary": "Get working hours (Admin only)",
                 "responses": {
                     "200": {
-                        "description": "Service updated successfully",
+                        "description": "Working hours",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -305,15 +295,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -325,37 +306,43 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a service by UUID",
+                "description": "Replace the weekly working hours. A weekday can have several periods (e.g. around a lunch break),\nwhich must not overlap. Weekdays without periods are closed. Existing bookings are kept.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Services"
+                    "Admin - Bookings"
                 ],
-                "summary": "Delete a service (Admin only)",
+                "summary": "Replace working hours (Admin only)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Service UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "Working hours",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BookingHoursRequest"
+                            }
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service deleted successfully",
+                        "description": "Working hours updated",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -385,15 +372,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -406,22 +384,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/users": {
+        "/api/admin/bookings": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of all users on the platform",
+                "description": "Get the appointments between two dates in appointment order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Admin - Bookings"
                 ],
-                "summary": "Get all users (Admin only)",
+                "summary": "Get bookings (Admin only)",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD), defaults to today",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD), defaults to 7 days after from",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "confirmed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Booking status",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -436,19 +436,23 @@ const docTemplate = `{
                         "description": "Items per page",
                         "name": "limit",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by name or email",
-                        "name": "search",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of users",
+                        "description": "Bookings",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetAllUsersResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "401": {
@@ -481,14 +485,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/users/{uuid}": {
-            "put": {
+        "/api/admin/equipment": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update user information by UUID",
                 "consumes": [
                     "application/json"
                 ],
@@ -496,30 +499,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Admin - Equipment"
                 ],
-                "summary": "Update a user (Admin only)",
+                "summary": "Create an equipment item (Admin only)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User update details",
+                        "description": "Equipment",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.AdminUpdateUserRequest"
+                            "$ref": "#/definitions/handlers.EquipmentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User updated successfully",
+                    "201": {
+                        "description": "Equipment created successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -534,26 +530,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "404": {
-                        "description": "User not found",
+                        "description": "Category not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -561,8 +539,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Email already taken",
+                    "409": {
+                        "description": "Name already exists",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -580,38 +558,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/admin/equipment-categories": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a user by UUID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin"
+                    "Admin - Equipment"
                 ],
-                "summary": "Delete a user (Admin only)",
+                "summary": "Create an equipment category (Admin only)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "Category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EquipmentCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User deleted successfully",
+                    "201": {
+                        "description": "Category created successfully",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -623,8 +605,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Name already exists",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -632,26 +614,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -662,9 +626,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/forgot-password": {
-            "post": {
-                "description": "Send password reset email to user",
+        "/api/admin/equipment-categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -672,23 +640,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin - Equipment"
                 ],
-                "summary": "Request password reset",
+                "summary": "Update an equipment category (Admin only)",
                 "parameters": [
                     {
-                        "description": "Email address",
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.ForgotPasswordRequest"
+                            "$ref": "#/definitions/handlers.EquipmentCategoryRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Reset email sent",
+                        "description": "Category updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -696,14 +678,26 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "429": {
-                        "description": "Too many requests",
+                    "409": {
+                        "description": "Name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -712,41 +706,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/auth/login": {
-            "post": {
-                "description": "Authenticate user with email and password",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Delete an equipment category. Only categories without equipment can be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin - Equipment"
                 ],
-                "summary": "Login user",
+                "summary": "Delete an equipment category (Admin only)",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Category deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -754,14 +749,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "409": {
+                        "description": "Category still has equipment",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "429": {
-                        "description": "Too many requests",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -772,30 +770,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/me": {
-            "get": {
+        "/api/admin/equipment/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the authenticated user's profile information",
+                "description": "Update an equipment item. Renaming it changes the name used by API clients and imports.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin - Equipment"
+                ],
+                "summary": "Update an equipment item (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Equipment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EquipmentRequest"
+                        }
+                    }
                 ],
-                "summary": "Get current user profile",
                 "responses": {
                     "200": {
-                        "description": "User profile",
+                        "description": "Equipment updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/handlers.UserProfileResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -804,7 +824,25 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Equipment or category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Name already exists",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -814,43 +852,32 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the authenticated user's profile information",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an equipment item and remove it from all vehicles",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin - Equipment"
                 ],
-                "summary": "Update user profile",
+                "summary": "Delete an equipment item (Admin only)",
                 "parameters": [
                     {
-                        "description": "Profile update details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateProfileRequest"
-                        }
+                        "type": "integer",
+                        "description": "Equipment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateProfileResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
+                        "description": "Equipment deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -858,8 +885,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Equipment not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -867,18 +894,26 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/auth/register": {
-            "post": {
-                "description": "Register a new user account with email and password",
+        "/api/admin/exchange-rates": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set exchange rates for supported currencies (lei, euro, usd) and recompute the normalized price of all vehicles. Currencies not included keep their current rate.",
                 "consumes": [
                     "application/json"
                 ],
@@ -886,35 +921,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin - Exchange Rates"
                 ],
-                "summary": "Register a new user",
+                "summary": "Update exchange rates (Admin only)",
                 "parameters": [
                     {
-                        "description": "Registration details",
+                        "description": "Exchange rates",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.RegisterRequest"
+                            "$ref": "#/definitions/handlers.UpdateExchangeRatesRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Account created successfully",
+                    "200": {
+                        "description": "Updated exchange rates",
                         "schema": {
-                            "$ref": "#/definitions/handlers.RegisterResponse"
+                            "$ref": "#/definitions/handlers.ExchangeRatesResponse"
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "400": {
+                        "description": "Bad request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "429": {
-                        "description": "Too many requests",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -934,42 +981,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/reset-password": {
+        "/api/admin/payments/{uuid}/refund": {
             "post": {
-                "description": "Reset user password using reset token",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Refund a succeeded payment in full. The promotion of the service order keeps running.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin - Services"
                 ],
-                "summary": "Reset password with token",
+                "summary": "Refund a payment (Admin only)",
                 "parameters": [
                     {
-                        "description": "Reset token and new password",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResetPasswordRequest"
-                        }
+                        "type": "string",
+                        "description": "Payment UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password reset successful",
+                        "description": "Payment refunded",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Invalid or expired token",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -977,132 +1022,220 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "422": {
-                        "description": "Validation error",
+                    "403": {
+                        "description": "Forbidden - Admin access required",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                }
-            }
-        },
-        "/api/brands": {
-            "get": {
-                "description": "Retrieve a list of all automobile brands",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "brands"
-                ],
-                "summary": "Get all brands",
-                "responses": {
-                    "200": {
-                        "description": "success with brands array",
+                    },
+                    "404": {
+                        "description": "Payment not found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Payment has not succeeded",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Payment provider error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Payments are not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/brands/{id}/automobiles": {
+        "/api/admin/service-orders": {
             "get": {
-                "description": "Retrieve all automobiles for a specific brand",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get a paginated list of promotion orders, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "Admin - Services"
                 ],
-                "summary": "Get automobiles by brand",
+                "summary": "Get all service orders (Admin only)",
                 "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "active",
+                            "expired",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Brand ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success with automobiles array",
+                        "description": "Service orders",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid brand ID",
+                        "description": "Invalid status",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "brand not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/services": {
-            "get": {
-                "description": "Get a paginated list of all active services for car owners",
+        "/api/admin/service-orders/{uuid}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Activate a pending order, extending the vehicle's promotion window by the ordered duration",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Admin - Services"
                 ],
-                "summary": "Get all active services (Public)",
+                "summary": "Activate a service order (Admin only)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Service order UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of services",
+                        "description": "Service order activated",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetAllServicesResponse"
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Service order is not pending",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -1117,27 +1250,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/vehicles": {
-            "get": {
+        "/api/admin/service-orders/{uuid}/cancel": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all vehicles created by the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Cancel a pending order that has no pending, processing or succeeded payment. A payment succeeding after all for a cancelled order is refunded automatically.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vehicles"
+                    "Admin - Services"
+                ],
+                "summary": "Cancel a service order (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service order UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all vehicles for authenticated user",
                 "responses": {
                     "200": {
-                        "description": "List of user's vehicles",
+                        "description": "Service order cancelled",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1147,232 +1286,6678 @@ const docTemplate = `{
                         "description": "Unauthorized",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Service order is not pending or has a payment in progress",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/admin/services": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new vehicle with images and all details. Only authenticated users can create vehicles. The vehicle will be automatically assigned to the authenticated user.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Get a paginated list of all services",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vehicles"
+                    "Admin - Services"
                 ],
-                "summary": "Create a new vehicle listing (Authenticated users only)",
+                "summary": "Get all services (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of services",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAllServicesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new service for car owners",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Services"
+                ],
+                "summary": "Create a new service (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Service details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Service created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/services/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get service details by UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Services"
+                ],
+                "summary": "Get a service by UUID (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ServiceData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the service, to send as If-Match when updating it"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update service information by UUID. Requires the ETag of the version being updated in If-Match; an update of a service modified since that version is rejected with 412.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Services"
+                ],
+                "summary": "Update a service (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the service version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Service update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateServiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the service"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Service modified since it was read, with current_version and current_etag",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a service by UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Services"
+                ],
+                "summary": "Delete a service (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Service has been ordered or booked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of all users on the platform",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get all users (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or email",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAllUsersResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/users/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user information by UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get a user (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminUserData"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user, to send as If-Match when updating it"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin access required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update user information by UUID. Requires the ETag of the version being updated in If-Match; an update of a user modified since that version, by an admin or by the user editing their profile, is rejected with 412.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update a user (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "User update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdminUpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "User modified since it was read, with current_version and current_etag",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Email already taken",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user by UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a user (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/vehicle-audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get recorded vehicle creations, edits and status changes, newest first, with the acting user, their IP and the old and new value of every changed field. Filter by vehicle, actor or IP to investigate a listing or a user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get the vehicle audit log (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only changes of this vehicle",
+                        "name": "vehicle_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only changes made by this user ID",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes made from this IP address",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only this action (create, update, status)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit log entries",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/forgot-password": {
+            "post": {
+                "description": "Send password reset email to user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Email address",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reset email sent",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/login": {
+            "post": {
+                "description": "Authenticate user with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get current user profile",
+                "responses": {
+                    "200": {
+                        "description": "User profile",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserProfileResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user, to send as If-Match when updating the profile"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the authenticated user's profile information. Requires the ETag of GET /api/auth/me in If-Match; an update of a profile modified since, by the user or by an admin, is rejected with 412.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the user version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateProfileResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "412": {
+                        "description": "Profile modified since it was read, with current_version and current_etag",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/register": {
+            "post": {
+                "description": "Register a new user account with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Registration details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Account created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/reset-password": {
+            "post": {
+                "description": "Reset user password using reset token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset password with token",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset successful",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/brands": {
+            "get": {
+                "description": "Retrieve a list of all automobile brands",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "brands"
+                ],
+                "summary": "Get all brands",
+                "responses": {
+                    "200": {
+                        "description": "success with brands array",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified (If-None-Match matched)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/brands/{id}/automobiles": {
+            "get": {
+                "description": "Retrieve all automobiles for a specific brand",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "brands"
+                ],
+                "summary": "Get automobiles by brand",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success with automobiles array",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid brand ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "brand not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/dealers/{slug}": {
+            "get": {
+                "description": "Get the public profile of a dealer organization and its active listings, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get a dealer storefront (Public)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Convert prices to this currency (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dealer and listings",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid currency",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Dealer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/equipment": {
+            "get": {
+                "description": "Get all equipment grouped by category. The names are used in the equipment field of vehicles and the equipment filter of the vehicle list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "equipment"
+                ],
+                "summary": "Get the equipment catalog (Public)",
+                "responses": {
+                    "200": {
+                        "description": "Equipment categories with their equipment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/exchange-rates": {
+            "get": {
+                "description": "Get the exchange rates used to convert and compare prices. Each rate is the value of one unit of the currency in the base currency.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchange-rates"
+                ],
+                "summary": "Get exchange rates (Public)",
+                "responses": {
+                    "200": {
+                        "description": "Exchange rates",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExchangeRatesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feeds/{format}": {
+            "get": {
+                "description": "Export listings for aggregators and ad platforms as a generic XML vehicle feed, a CSV product catalog or a JSON feed. Without since the feed holds all active listings; with since it holds the listings of any status changed after that time, and listings no longer active have availability \"out of stock\" so consumers can remove them.",
+                "produces": [
+                    "text/xml",
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get the listing feed (Feed token required)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed format (xml, csv, json)",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only listings changed after this time (RFC 3339, e.g. 2026-03-01T00:00:00Z)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed token (alternatively sent in the X-Feed-Token header)",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or since value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid feed token",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a dealer organization. The authenticated user becomes its owner. The public storefront slug is generated from the name and does not change on rename.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Create an organization",
+                "parameters": [
+                    {
+                        "description": "Organization profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Organization created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the organization profile and the role of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get an organization (Members only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Organization",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the organization profile. The storefront slug and the logo are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Update an organization (Owners and managers)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Organization updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the organization and its memberships. Its vehicles are kept and stay with the members who listed them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Delete an organization (Owners only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Organization deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{uuid}/logo": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload or replace the organization logo (jpg, jpeg, png, webp; max 10MB)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Upload the organization logo (Owners and managers)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Logo image",
+                        "name": "logo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logo uploaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{uuid}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the members of the organization with their roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get organization members (Members only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Members",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a registered user to the organization by email. Owners can add any role; managers can add agents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Add an organization member (Owners and managers)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Member added successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or user not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Already a member",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{uuid}/members/{user_uuid}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the role of an organization member. The last owner cannot be demoted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Change a member's role (Owners only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user UUID",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or member not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a member from the organization. Owners can remove anyone, managers can remove agents, and every member can leave. The last owner cannot be removed. The member's vehicles stay listed for the organization.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Remove an organization member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user UUID",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member removed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization or member not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/organizations/{uuid}/vehicles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all vehicles listed for the organization, of any status, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get organization vehicles (Members only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payments/webhooks/{provider}": {
+            "post": {
+                "description": "Receive a signed payment status notification. Redelivered events are applied once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payment provider webhook",
+                "parameters": [
+                    {
+                        "enum": [
+                            "mock"
+                        ],
+                        "type": "string",
+                        "description": "Payment provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event received",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown provider or payment",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Payments are not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/services": {
+            "get": {
+                "description": "Get a paginated list of all active services for car owners",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get all active services (Public)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of services",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAllServicesResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified (If-None-Match matched)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/services/{uuid}/slots": {
+            "get": {
+                "description": "Get the start times on a date at which the service can still be booked. Slots last the service duration\nand follow the working hours (or the exceptions of the date) in the workshop's time zone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Get available appointment slots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Available slots",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or service cannot be booked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the appointments of the authenticated user, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Get the user's bookings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bookings",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book a service at one of its available slots. A confirmation email is sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Book an appointment",
+                "parameters": [
+                    {
+                        "description": "Booking details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Booking created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Slot is not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/bookings/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an appointment of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Get a booking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Booking",
+                        "schema": {
+                            "$ref": "#/definitions/models.Booking"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a confirmed appointment to another available slot. An email with the new time is sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Reschedule a booking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New start time",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RescheduleBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Booking rescheduled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Slot is not available or booking is cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a confirmed appointment, freeing its slot. A cancellation email is sent.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Cancel a booking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Booking cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Booking not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Booking is already cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/comparisons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the authenticated user's saved comparison list, aligned by attribute like the public compare endpoint",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get the saved comparison list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency to compare prices in (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved vehicle comparison (data: VehicleComparison)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a vehicle by slug to the authenticated user's comparison list (maximum 4 vehicles). Adding a vehicle twice has no effect.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Add a vehicle to the saved comparison list",
+                "parameters": [
+                    {
+                        "description": "Vehicle to compare",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddComparisonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated comparison (data: VehicleComparison)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Comparison list is full",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove all vehicles from the authenticated user's comparison list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Clear the saved comparison list",
+                "responses": {
+                    "200": {
+                        "description": "Comparison list cleared",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/comparisons/{slug}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a vehicle by slug from the authenticated user's comparison list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Remove a vehicle from the saved comparison list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated comparison (data: VehicleComparison)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not in comparison list",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the organizations the authenticated user belongs to, with the user's role in each",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get my organizations",
+                "responses": {
+                    "200": {
+                        "description": "Organizations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/payments/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a payment of the user, with its status changes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get a payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment",
+                        "schema": {
+                            "$ref": "#/definitions/models.Payment"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/payments/{uuid}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Charge a pending payment with a payment method. The mock provider accepts mock_success,\nmock_failure and mock_delayed (processing until a webhook reports success).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Confirm a payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Payment UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment method",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ConfirmPaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment confirmed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Payment is not pending",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Payments are not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/service-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the promotion orders placed by the authenticated user, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Get the user's service orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service orders",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/service-orders/{uuid}/payments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a payment for a pending service order of the user. Requests are idempotent per Idempotency-Key:\nrepeating one returns the payment it created. Once the payment succeeds the order is activated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Pay for a service order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service order UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key for this payment attempt",
+                        "name": "Idempotency-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment previously created with this Idempotency-Key",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "201": {
+                        "description": "Payment created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Missing Idempotency-Key",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Service order not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Service order cannot be paid",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key used for another service order",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "502": {
+                        "description": "Payment provider error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Payments are not available",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open an upload for a vehicle image of the given size (at most 10MB, jpg, jpeg, png or webp). Send the bytes with PATCH /api/user/uploads/{uuid}, in one or more chunks,\nthen reference the upload UUID in upload_ids when creating or updating a vehicle. Uploads that are not claimed by a vehicle expire (24 hours by default).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Uploads"
+                ],
+                "summary": "Request an image upload slot",
+                "parameters": [
+                    {
+                        "description": "Image file name and size in bytes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Upload created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "Upload-Offset": {
+                                "type": "string",
+                                "description": "Bytes received so far (0)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too many open uploads",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/uploads/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the status and progress of an upload. The offset is where an interrupted upload resumes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Uploads"
+                ],
+                "summary": "Get an upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "Upload-Offset": {
+                                "type": "string",
+                                "description": "Bytes received so far"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found or expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an upload in progress or discard a completed upload that no vehicle claimed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Uploads"
+                ],
+                "summary": "Delete an upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload deleted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found or expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Append the request body to an upload, starting at the offset in the Upload-Offset header, which must equal the bytes received so far.\nAfter an interruption, get the upload to find the offset to resume from. Once all bytes are received the image is checked and the upload is completed.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Uploads"
+                ],
+                "summary": "Upload a chunk of an image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset of the chunk, the bytes received so far",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Bytes of the image",
+                        "name": "chunk",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chunk received, with the upload status and progress",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "Upload-Offset": {
+                                "type": "string",
+                                "description": "Bytes received so far"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing Upload-Offset or file is not an image",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found or expired",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Offset does not match the bytes received, or upload already completed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Chunk exceeds the size of the upload",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all vehicles created by the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get all vehicles for authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "List of user's vehicles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new vehicle with images and all details. Only authenticated users can create vehicles. The vehicle will be automatically assigned to the authenticated user.\nImages are sent in the form, or uploaded beforehand with POST /api/user/uploads and referenced in upload_ids, which also allows a JSON body.",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Create a new vehicle listing (Authenticated users only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle title (min 5, max 255 characters)",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle category",
+                        "name": "category",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Price (must be greater than 0)",
+                        "name": "price",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Price negotiable (default: false)",
+                        "name": "negotiable",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person type (persoana_fizica, firma)",
+                        "name": "person_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog brand ID (takes precedence over brand)",
+                        "name": "brand_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brand name or slug from the catalog (required unless brand_id is set or it can be decoded from the VIN)",
+                        "name": "brand",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog automobile ID (must belong to the brand)",
+                        "name": "automobile_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model",
+                        "name": "model",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle identification number (17 characters)",
+                        "name": "vin",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Engine capacity in cm3",
+                        "name": "engine_capacity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Power in HP",
+                        "name": "power_hp",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuel type (benzina, motorina, electric, hibrid, gpl, hybrid_benzina, hybrid_motorina)",
+                        "name": "fuel_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Body type (sedan, suv, break, coupe, cabrio, hatchback, pickup, van, monovolum)",
+                        "name": "body_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Kilometers",
+                        "name": "kilometers",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Color",
+                        "name": "color",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year (1970-2030, required unless it can be decoded from the VIN)",
+                        "name": "year",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of keys",
+                        "name": "number_of_keys",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Condition (utilizat, nou)",
+                        "name": "condition",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transmission (manuala, automata)",
+                        "name": "transmission",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Steering position (stanga, dreapta)",
+                        "name": "steering",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Vehicle registered (default: false)",
+                        "name": "registered",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact name",
+                        "name": "contact_name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email address (valid email format)",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone number",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a dealer organization the user is a member of, to list the vehicle for it",
+                        "name": "organization_uuid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Equipment names from GET /api/equipment (repeated or comma separated, e.g. ac,navigation)",
+                        "name": "equipment",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Index of the image to use as featured (0-based, default: 0)",
+                        "name": "featured_image_index",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Vehicle images (max 8, jpeg/png/jpg)",
+                        "name": "images",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "UUIDs of completed uploads from POST /api/user/uploads, added after the images (max 8 images in total). The vehicle can also be created with a JSON body of the same fields and upload_ids.",
+                        "name": "upload_ids",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Vehicle created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Authentication required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/drafts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the draft listings of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Get the drafts of the authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "List of drafts",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save an incomplete vehicle listing. All fields are optional, but the values given must be valid. Images can be sent now or uploaded later. Drafts are not listed until published and are deleted after a period without changes.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Create a draft listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle category",
+                        "name": "category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Price",
+                        "name": "price",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Price negotiable",
+                        "name": "negotiable",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person type (persoana_fizica, firma)",
+                        "name": "person_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog brand ID",
+                        "name": "brand_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brand name or slug from the catalog",
+                        "name": "brand",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog automobile ID",
+                        "name": "automobile_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model",
+                        "name": "model",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle identification number (17 characters)",
+                        "name": "vin",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Engine capacity in cm3",
+                        "name": "engine_capacity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Power in HP",
+                        "name": "power_hp",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuel type",
+                        "name": "fuel_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Body type",
+                        "name": "body_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Kilometers",
+                        "name": "kilometers",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Color",
+                        "name": "color",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of keys",
+                        "name": "number_of_keys",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Condition (utilizat, nou)",
+                        "name": "condition",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transmission (manuala, automata)",
+                        "name": "transmission",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Steering (stanga, dreapta)",
+                        "name": "steering",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Registered",
+                        "name": "registered",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact name",
+                        "name": "contact_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a dealer organization the user is a member of, to list the vehicle for it",
+                        "name": "organization_uuid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Equipment names from GET /api/equipment (repeated or comma separated)",
+                        "name": "equipment",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Vehicle images (max 8, jpeg/png/jpg)",
+                        "name": "images",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Draft created successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Authentication required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/drafts/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a draft listing of the authenticated user with its images and equipment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Get a draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Draft UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draft details",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Draft not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save more fields of a draft listing. Only the fields given are changed and checked. Images are added with POST /api/user/vehicles/drafts/{uuid}/images.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Update a draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Draft UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle category",
+                        "name": "category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Price",
+                        "name": "price",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Price negotiable",
+                        "name": "negotiable",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person type (persoana_fizica, firma)",
+                        "name": "person_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog brand ID",
+                        "name": "brand_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brand name or slug from the catalog",
+                        "name": "brand",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog automobile ID",
+                        "name": "automobile_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model",
+                        "name": "model",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle identification number (17 characters)",
+                        "name": "vin",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Engine capacity in cm3",
+                        "name": "engine_capacity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Power in HP",
+                        "name": "power_hp",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuel type",
+                        "name": "fuel_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Body type",
+                        "name": "body_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Kilometers",
+                        "name": "kilometers",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Color",
+                        "name": "color",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of keys",
+                        "name": "number_of_keys",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Condition (utilizat, nou)",
+                        "name": "condition",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transmission (manuala, automata)",
+                        "name": "transmission",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Steering (stanga, dreapta)",
+                        "name": "steering",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Registered",
+                        "name": "registered",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact name",
+                        "name": "contact_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a dealer organization the user is a member of",
+                        "name": "organization_uuid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Equipment names replacing the current equipment (send an empty value to remove all)",
+                        "name": "equipment",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draft updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Draft not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a draft listing and its images",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Delete a draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Draft UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draft deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Draft not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/drafts/{uuid}/images": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload more images to a draft listing, up to 8 images in total. The first image of a draft without images becomes its featured image.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Add images to a draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Draft UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Vehicle images (jpeg/png/jpg)",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Images uploaded successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "No images or too many images",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Draft not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/drafts/{uuid}/images/{image_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an image of a draft listing. When it was the featured image, the next image becomes featured.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Remove an image from a draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Draft UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Draft or image not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/drafts/{uuid}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Validate a draft like a new listing (all fields required by POST /api/user/vehicles) and make it live. Validation errors are returned as for vehicle creation and the draft is kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle drafts"
+                ],
+                "summary": "Publish a draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Draft UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draft published successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "No longer a member of the draft's organization",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Draft not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Draft modified by another request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import up to 500 listings from a CSV or XML feed. Columns (or XML elements) are named like the create vehicle form fields, e.g. external_id, title, brand, model, price, currency, fuel_type, body_type, year; common aliases such as make, mileage or gearbox are accepted. Every listing needs a dealer-supplied external_id: re-importing a listing with the same external_id updates it instead of creating a duplicate. Images are given in the images column as http(s) URLs or as file names from the uploaded ZIP archive, separated by \"|\"; when images are given for an existing listing they replace its images. At most 100 image URLs are downloaded per import, and rows not reached within the one minute time limit are reported failed, to be imported again. Equipment names (see GET /api/equipment) go in the equipment column, separated by \"|\". person_type defaults to firma. Each row is validated like a single vehicle creation and the response reports the outcome of every row.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Bulk import vehicle listings (Authenticated users only)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XML feed",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feed format (csv, xml), detected from the file extension by default",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "ZIP archive with the images referenced by the feed",
+                        "name": "images_zip",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate the feed without saving anything (default: false)",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a dealer organization the user is a member of, to list the vehicles for it",
+                        "name": "organization_uuid",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import report",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid feed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Authentication required",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a vehicle with all its details and images using UUID. Only accessible by vehicle owner or admin. The ETag header holds the version of the vehicle, to send as If-Match when updating it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get vehicle by UUID (Owner/Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle details",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the vehicle"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Not owner or admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a vehicle listing using UUID. Requires the ETag of the version being updated in If-Match; an update of a vehicle modified since that version is rejected with 412.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Update vehicle by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle category",
+                        "name": "category",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Price",
+                        "name": "price",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Price negotiable",
+                        "name": "negotiable",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Person type (persoana_fizica, firma)",
+                        "name": "person_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog brand ID (takes precedence over brand)",
+                        "name": "brand_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Brand name or slug from the catalog",
+                        "name": "brand",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Catalog automobile ID (must belong to the brand)",
+                        "name": "automobile_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model",
+                        "name": "model",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Vehicle identification number (17 characters)",
+                        "name": "vin",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Engine capacity (cm3)",
+                        "name": "engine_capacity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Power (HP)",
+                        "name": "power_hp",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Fuel type",
+                        "name": "fuel_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Body type",
+                        "name": "body_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Kilometers",
+                        "name": "kilometers",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Color",
+                        "name": "color",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of keys",
+                        "name": "number_of_keys",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Condition (utilizat, nou)",
+                        "name": "condition",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transmission (manuala, automata)",
+                        "name": "transmission",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Steering (stanga, dreapta)",
+                        "name": "steering",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Registered",
+                        "name": "registered",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contact name",
+                        "name": "contact_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone",
+                        "name": "phone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID of a dealer organization the owner is a member of, to move the vehicle to it",
+                        "name": "organization_uuid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Equipment names replacing the current equipment (repeated or comma separated; send an empty value to remove all)",
+                        "name": "equipment",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vehicle version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the vehicle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Not owner or admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Vehicle modified since it was read, with current_version and current_etag",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a vehicle listing with a JSON Merge Patch (RFC 7396): only the members sent are changed, and null clears description, vin, engine_capacity, power_hp, kilometers, color, number_of_keys, phone, organization_uuid (moving the vehicle out of its organization) and equipment. Other members cannot be null. The members are the fields of PUT /api/user/vehicles/{uuid}, with booleans and numbers as JSON values and equipment as an array of names. upload_ids adds completed uploads from POST /api/user/uploads to the vehicle images (max 8 images in total). Requires the ETag of the version being updated in If-Match, like PUT.",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Partially update vehicle by UUID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch, e.g. {\\",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vehicle version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the vehicle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid merge patch or validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Not owner or admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Vehicle modified since it was read, with current_version and current_etag",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/{uuid}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the recorded creation, edits and status changes of a vehicle, newest first, with the old and new value of every changed field. Whether a change was made by the owner, an organization member or an admin is shown, but not who made it or from where.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get the change history of a vehicle (Owner/Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle history",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Not owner or admin",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/{uuid}/promotions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Order an active promotion service (top listing or homepage highlight) for an active vehicle the user can edit.\nThe order is pending until it is activated, which extends the vehicle's promotion window by the service duration.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Order a promotion for a vehicle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion service",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderPromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Service order created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle or service not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/vehicles/{uuid}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Activate or deactivate a listing. Only admins can ban a listing or change the status of a banned one. Requires the ETag of the vehicle version in If-Match. The change is recorded in the vehicle history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Change the status of a vehicle (Owner/Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status (active, inactive, banned)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateVehicleStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the vehicle version being updated",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle status updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Not owner or admin, or the vehicle is banned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "412": {
+                        "description": "Vehicle modified since it was read, with current_version and current_etag",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the viewing and test drive requests for the user's vehicles and the vehicles of the organizations they manage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Get received viewing requests",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "countered",
+                            "accepted",
+                            "declined",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Viewing requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests/sent": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the viewing and test drive requests the user made",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Get sent viewing requests",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "countered",
+                            "accepted",
+                            "declined",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Viewing requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a viewing request with all proposed times, for its buyer or seller",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Get a viewing request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Viewing request UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Viewing request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ViewingRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Viewing request not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests/{uuid}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept one of the times proposed by the other party. Both parties receive a confirmation with an\niCalendar attachment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Accept a viewing request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Viewing request UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Accepted time",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AcceptViewingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Viewing request accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Time was not proposed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Viewing request not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Not awaiting your response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests/{uuid}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the accepted appointment as an .ics file for calendar applications",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Download an accepted viewing as iCalendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Viewing request UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Viewing request not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Viewing request is not accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests/{uuid}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an open or accepted request made by the user; the seller is notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Cancel a viewing request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Viewing request UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CloseViewingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Viewing request cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Only the buyer can cancel",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Viewing request not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Viewing request is closed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests/{uuid}/counter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Propose other times instead of the ones offered by the other party, who is notified by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Counter-propose a viewing request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Viewing request UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Proposed times",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CounterViewingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Counter-proposal sent",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Viewing request not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Not awaiting your response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/viewing-requests/{uuid}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Decline the times proposed by the other party, closing the request",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Decline a viewing request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Viewing request UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CloseViewingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Viewing request declined",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Viewing request not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Not awaiting your response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vehicles": {
+            "get": {
+                "description": "Public endpoint to retrieve all active vehicles with optional search and filtering. No authentication required. Perfect for browsing and searching the vehicle marketplace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get all vehicles with search and filters (Public)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vehicle title (min 5, max 255 characters)",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
+                        "description": "Search by title, brand, model, or description",
+                        "name": "search",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Vehicle category",
-                        "name": "category",
-                        "in": "formData",
-                        "required": true
+                        "description": "Filter by catalog brand ID or slug (e.g. 12 or alfa-romeo)",
+                        "name": "brand",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Vehicle description",
-                        "name": "description",
-                        "in": "formData"
+                        "description": "Filter by model name",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by fuel type (benzina, motorina, electric, hibrid, gpl, hybrid_benzina, hybrid_motorina)",
+                        "name": "fuel_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by body type (sedan, suv, break, coupe, cabrio, hatchback, pickup, van, monovolum)",
+                        "name": "body_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by transmission (manuala, automata)",
+                        "name": "transmission",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by condition (utilizat, nou)",
+                        "name": "condition",
+                        "in": "query"
                     },
                     {
                         "type": "number",
-                        "description": "Price (must be greater than 0)",
-                        "name": "price",
-                        "in": "formData",
-                        "required": true
+                        "description": "Minimum price (in the currency param, default lei)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price (in the currency param, default lei)",
+                        "name": "max_price",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Currency (e.g., lei)",
+                        "description": "Currency for price filters and converted prices (lei, euro, usd)",
                         "name": "currency",
-                        "in": "formData",
-                        "required": true
+                        "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Price negotiable (default: false)",
-                        "name": "negotiable",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "Sort order (newest, price_asc, price_desc; default: newest)",
+                        "name": "sort",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Person type (persoana_fizica, firma)",
-                        "name": "person_type",
-                        "in": "formData",
-                        "required": true
+                        "type": "integer",
+                        "description": "Minimum year",
+                        "name": "min_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum year",
+                        "name": "max_year",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Brand",
-                        "name": "brand",
-                        "in": "formData",
-                        "required": true
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Model",
-                        "name": "model",
-                        "in": "formData",
-                        "required": true
+                        "description": "Comma separated equipment names; only vehicles having all of them (e.g. ac,navigation)",
+                        "name": "equipment",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Engine capacity in cm3",
-                        "name": "engine_capacity",
-                        "in": "formData"
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Power in HP",
-                        "name": "power_hp",
-                        "in": "formData"
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of vehicles with pagination",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vehicles/compare": {
+            "get": {
+                "description": "Compare 2 to 4 vehicles by slug. Attributes are aligned in the order of the slugs, lookup values use their display names and every attribute is flagged when the vehicles differ. Prices are shown in a common currency.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Compare vehicles side by side (Public)",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Fuel type (benzina, motorina, electric, hibrid, gpl, hybrid_benzina, hybrid_motorina)",
-                        "name": "fuel_type",
-                        "in": "formData",
+                        "description": "Comma-separated vehicle slugs (2 to 4)",
+                        "name": "slugs",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Body type (sedan, suv, break, coupe, cabrio, hatchback, pickup, van, monovolum)",
-                        "name": "body_type",
-                        "in": "formData",
-                        "required": true
+                        "description": "Currency to compare prices in (lei, euro, usd; default: the shared currency of the vehicles, otherwise lei)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vehicle comparison (data: VehicleComparison)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vehicles/feed.atom": {
+            "get": {
+                "description": "Atom 1.0 feed of the most recent active listings. Accepts the same search and filter parameters as GET /api/vehicles, so any search can be followed in a feed reader. Entries link to the listing page and carry the featured image, price and key specs. Responses are cacheable and support conditional requests with If-None-Match.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Atom feed of recent vehicles (Public)",
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Kilometers",
-                        "name": "kilometers",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "Search by title, brand, model, or description",
+                        "name": "search",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Color",
-                        "name": "color",
-                        "in": "formData"
+                        "description": "Filter by catalog brand ID or slug (e.g. 12 or alfa-romeo)",
+                        "name": "brand",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Year (1970-2030)",
-                        "name": "year",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter by model name",
+                        "name": "model",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of keys",
-                        "name": "number_of_keys",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "Filter by fuel type",
+                        "name": "fuel_type",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Condition (utilizat, nou)",
-                        "name": "condition",
-                        "in": "formData",
-                        "required": true
+                        "description": "Filter by body type",
+                        "name": "body_type",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Transmission (manuala, automata)",
+                        "description": "Filter by transmission (manuala, automata)",
                         "name": "transmission",
-                        "in": "formData",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Steering position (stanga, dreapta)",
-                        "name": "steering",
-                        "in": "formData",
-                        "required": true
+                        "description": "Filter by condition (utilizat, nou)",
+                        "name": "condition",
+                        "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Vehicle registered (default: false)",
-                        "name": "registered",
-                        "in": "formData"
+                        "type": "number",
+                        "description": "Minimum price (in the currency param, default lei)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price (in the currency param, default lei)",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency for price filters and converted prices (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "City",
-                        "name": "city",
-                        "in": "formData",
-                        "required": true
+                        "description": "Sort order (newest, price_asc, price_desc; default: newest)",
+                        "name": "sort",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Contact name",
-                        "name": "contact_name",
-                        "in": "formData",
-                        "required": true
+                        "type": "integer",
+                        "description": "Minimum year",
+                        "name": "min_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum year",
+                        "name": "max_year",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Email address (valid email format)",
-                        "name": "email",
-                        "in": "formData",
-                        "required": true
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Phone number",
-                        "name": "phone",
-                        "in": "formData"
+                        "description": "Comma separated equipment names; only vehicles having all of them",
+                        "name": "equipment",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Index of the image to use as featured (0-based, default: 0)",
-                        "name": "featured_image_index",
-                        "in": "formData"
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "Vehicle images (max 8, jpeg/png/jpg)",
-                        "name": "images",
-                        "in": "formData"
+                        "type": "integer",
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Vehicle created successfully",
+                    "200": {
+                        "description": "Atom feed",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Validation error",
+                    "304": {
+                        "description": "Not modified",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - Authentication required",
+                    "400": {
+                        "description": "Invalid query parameters",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1388,257 +7973,314 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/vehicles/{uuid}": {
+        "/api/vehicles/feed.rss": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a vehicle with all its details and images using UUID. Only accessible by vehicle owner or admin.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "RSS 2.0 feed of the most recent active listings. Accepts the same search and filter parameters as GET /api/vehicles, so any search can be followed in a feed reader. Items link to the listing page and carry the featured image, price and key specs. Responses are cacheable and support conditional requests with If-None-Match.",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
                     "vehicles"
                 ],
-                "summary": "Get vehicle by UUID (Owner/Admin only)",
+                "summary": "RSS feed of recent vehicles (Public)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vehicle UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Vehicle details",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - Not owner or admin",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Vehicle not found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "description": "Search by title, brand, model, or description",
+                        "name": "search",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update a vehicle listing using UUID",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "vehicles"
-                ],
-                "summary": "Update vehicle by UUID",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Vehicle UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by catalog brand ID or slug (e.g. 12 or alfa-romeo)",
+                        "name": "brand",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Vehicle title",
-                        "name": "title",
-                        "in": "formData"
+                        "description": "Filter by model name",
+                        "name": "model",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Vehicle category",
-                        "name": "category",
-                        "in": "formData"
+                        "description": "Filter by fuel type",
+                        "name": "fuel_type",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Vehicle description",
-                        "name": "description",
-                        "in": "formData"
+                        "description": "Filter by body type",
+                        "name": "body_type",
+                        "in": "query"
                     },
                     {
-                        "type": "number",
-                        "description": "Price",
-                        "name": "price",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "Filter by transmission (manuala, automata)",
+                        "name": "transmission",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Currency (e.g., lei)",
-                        "name": "currency",
-                        "in": "formData"
+                        "description": "Filter by condition (utilizat, nou)",
+                        "name": "condition",
+                        "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Price negotiable",
-                        "name": "negotiable",
-                        "in": "formData"
+                        "type": "number",
+                        "description": "Minimum price (in the currency param, default lei)",
+                        "name": "min_price",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Person type (persoana_fizica, firma)",
-                        "name": "person_type",
-                        "in": "formData"
+                        "type": "number",
+                        "description": "Maximum price (in the currency param, default lei)",
+                        "name": "max_price",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Brand",
-                        "name": "brand",
-                        "in": "formData"
+                        "description": "Currency for price filters and converted prices (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Model",
-                        "name": "model",
-                        "in": "formData"
+                        "description": "Sort order (newest, price_asc, price_desc; default: newest)",
+                        "name": "sort",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Engine capacity (cm3)",
-                        "name": "engine_capacity",
-                        "in": "formData"
+                        "description": "Minimum year",
+                        "name": "min_year",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Power (HP)",
-                        "name": "power_hp",
-                        "in": "formData"
+                        "description": "Maximum year",
+                        "name": "max_year",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Fuel type",
-                        "name": "fuel_type",
-                        "in": "formData"
+                        "description": "Filter by city",
+                        "name": "city",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Body type",
-                        "name": "body_type",
-                        "in": "formData"
+                        "description": "Comma separated equipment names; only vehicles having all of them",
+                        "name": "equipment",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Kilometers",
-                        "name": "kilometers",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Color",
-                        "name": "color",
-                        "in": "formData"
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Year",
-                        "name": "year",
-                        "in": "formData"
+                        "description": "Items per page (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "RSS feed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vehicles/recommended": {
+            "get": {
+                "description": "Public endpoint to retrieve recommended vehicles ranked by listing score (completeness, images, price versus market, recency and engagement; vehicles flagged as recommended are boosted). Perfect for homepage or featured sections. No authentication required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get recommended vehicles (Public)",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Number of keys",
-                        "name": "number_of_keys",
-                        "in": "formData"
+                        "description": "Number of vehicles to return (default: 10, max: 50)",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Condition (utilizat, nou)",
-                        "name": "condition",
-                        "in": "formData"
+                        "type": "boolean",
+                        "description": "Spread results across brands (default: false)",
+                        "name": "diverse",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Transmission (manuala, automata)",
-                        "name": "transmission",
-                        "in": "formData"
+                        "type": "integer",
+                        "description": "Maximum vehicles per brand when diverse is set (default: 2)",
+                        "name": "max_per_brand",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Steering (stanga, dreapta)",
-                        "name": "steering",
-                        "in": "formData"
+                        "description": "Also show prices converted to this currency (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of recommended vehicles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Registered",
-                        "name": "registered",
-                        "in": "formData"
+                    "304": {
+                        "description": "Not modified (If-None-Match matched)",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "City",
-                        "name": "city",
-                        "in": "formData"
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vehicles/{slug}": {
+            "get": {
+                "description": "Public endpoint to retrieve detailed information about a specific vehicle using its SEO-friendly slug. Returns all vehicle details, images, and specifications. No authentication required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get vehicle by slug (Public)",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Contact name",
-                        "name": "contact_name",
-                        "in": "formData"
+                        "description": "Vehicle slug (SEO-friendly URL identifier)",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Email",
-                        "name": "email",
-                        "in": "formData"
+                        "description": "Also show the price converted to this currency (lei, euro, usd)",
+                        "name": "currency",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Phone",
-                        "name": "phone",
-                        "in": "formData"
+                        "description": "Set to seo to also return the page metadata (JSON-LD, Open Graph, Twitter card, canonical URL) as seo",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Vehicle updated successfully",
+                        "description": "Vehicle details with complete information",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
+                    "304": {
+                        "description": "Not modified (If-None-Match matched)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "400": {
-                        "description": "Validation error",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Not owner or admin",
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vehicles/{slug}/seo": {
+            "get": {
+                "description": "Page metadata of a listing derived from the vehicle: the canonical URL, title and description, schema.org Car JSON-LD with an Offer, and Open Graph and Twitter card meta tags. Also returned by GET /api/vehicles/{slug} with include=seo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicles"
+                ],
+                "summary": "Get the page metadata of a vehicle (Public)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle slug (SEO-friendly URL identifier)",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page metadata",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1661,9 +8303,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/vehicles": {
+        "/api/vehicles/{slug}/similar": {
             "get": {
-                "description": "Public endpoint to retrieve all active vehicles with optional search and filtering. No authentication required. Perfect for browsing and searching the vehicle marketplace.",
+                "description": "Public endpoint returning other active listings similar to the vehicle with the given slug, scored by brand/model match, body type, fuel type, year, price and kilometer proximity and the same city. Ties are ordered by newest listing, then ID.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1673,199 +8315,220 @@ const docTemplate = `{
                 "tags": [
                     "vehicles"
                 ],
-                "summary": "Get all vehicles with search and filters (Public)",
+                "summary": "Get vehicles similar to a vehicle (Public)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by title, brand, model, or description",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by brand name",
-                        "name": "brand",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by model name",
-                        "name": "model",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by fuel type (benzina, motorina, electric, hibrid, gpl, hybrid_benzina, hybrid_motorina)",
-                        "name": "fuel_type",
-                        "in": "query"
+                        "description": "Vehicle slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by body type (sedan, suv, break, coupe, cabrio, hatchback, pickup, van, monovolum)",
-                        "name": "body_type",
+                        "type": "integer",
+                        "description": "Number of vehicles to return (default: 6, max: 20)",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by transmission (manuala, automata)",
-                        "name": "transmission",
+                        "type": "boolean",
+                        "description": "Leave out other listings of the same seller (default: false)",
+                        "name": "exclude_seller",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by condition (utilizat, nou)",
-                        "name": "condition",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Minimum price",
-                        "name": "min_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Maximum price",
-                        "name": "max_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Minimum year",
-                        "name": "min_year",
+                        "description": "Also show prices converted to this currency (lei, euro, usd)",
+                        "name": "currency",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of similar vehicles",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Maximum year",
-                        "name": "max_year",
-                        "in": "query"
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter by city",
-                        "name": "city",
-                        "in": "query"
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/vehicles/{slug}/viewing-requests": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query"
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ask the seller of an active listing for a viewing or test drive at one of up to 5 proposed times.\nThe seller is notified by email; contact details are not shared.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Viewing requests"
+                ],
+                "summary": "Request a viewing or test drive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vehicle slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Items per page (default: 20, max: 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Proposed times",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateViewingRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of vehicles with pagination",
+                    "201": {
+                        "description": "Viewing request created",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Bad request",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Vehicle not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/vehicles/recommended": {
+        "/sitemap.xml": {
             "get": {
-                "description": "Public endpoint to retrieve a curated list of recommended vehicles. Returns recent, high-quality vehicles with images. Perfect for homepage or featured sections. No authentication required.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Sitemap index listing the sitemap files of active vehicle pages, brand pages and model pages. Each file holds at most 50,000 URLs.",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "vehicles"
-                ],
-                "summary": "Get recommended vehicles (Public)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Number of vehicles to return (default: 10, max: 50)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "sitemaps"
                 ],
+                "summary": "Get the sitemap index (Public)",
                 "responses": {
                     "200": {
-                        "description": "List of recommended vehicles",
+                        "description": "Sitemap index",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/api/vehicles/{slug}": {
+        "/sitemaps/{file}": {
             "get": {
-                "description": "Public endpoint to retrieve detailed information about a specific vehicle using its SEO-friendly slug. Returns all vehicle details, images, and specifications. No authentication required.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Sitemap file of a section as listed in the sitemap index: vehicles-N.xml with the active listings and their last update, brands-N.xml and models-N.xml with the catalog brand and model pages.",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "vehicles"
+                    "sitemaps"
                 ],
-                "summary": "Get vehicle by slug (Public)",
+                "summary": "Get a sitemap file (Public)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Vehicle slug (SEO-friendly URL identifier)",
-                        "name": "slug",
+                        "description": "Sitemap file name (e.g. vehicles-1.xml)",
+                        "name": "file",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Vehicle details with complete information",
+                        "description": "Sitemap",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Vehicle not found",
+                        "description": "Sitemap not found",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
@@ -1873,6 +8536,59 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "handlers.AcceptViewingRequest": {
+            "description": "Accept viewing request payload",
+            "type": "object",
+            "required": [
+                "starts_at"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "See you at the dealership"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-11-02T10:30:00+02:00"
+                }
+            }
+        },
+        "handlers.AddComparisonRequest": {
+            "description": "Add a vehicle to the comparison list",
+            "type": "object",
+            "required": [
+                "slug"
+            ],
+            "properties": {
+                "slug": {
+                    "type": "string",
+                    "example": "bmw-320d-2019-xdrive-impecabil"
+                }
+            }
+        },
+        "handlers.AddOrganizationMemberRequest": {
+            "description": "Existing user to add to the organization",
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "agent@dealer.ro"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "manager",
+                        "agent"
+                    ],
+                    "example": "agent"
+                }
+            }
+        },
         "handlers.AdminUpdateUserRequest": {
             "description": "Admin update user request payload",
             "type": "object",
@@ -1941,45 +8657,294 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string",
-                    "example": "2024-01-15T10:30:00Z"
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "uuid": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.BookingExceptionRequest": {
+            "description": "Booking exception payload; without opens_at and closes_at the day is closed",
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "closes_at": {
+                    "type": "string",
+                    "example": "13:00"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-12-24"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Christmas Eve"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "09:00"
+                }
+            }
+        },
+        "handlers.BookingHoursRequest": {
+            "description": "Weekly working hours period",
+            "type": "object",
+            "required": [
+                "capacity",
+                "closes_at",
+                "opens_at",
+                "weekday"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "closes_at": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "example": 1
+                }
+            }
+        },
+        "handlers.CloseViewingRequest": {
+            "description": "Decline or cancel payload",
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "The car has been sold"
+                }
+            }
+        },
+        "handlers.ConfirmPaymentRequest": {
+            "description": "Confirm payment request payload",
+            "type": "object",
+            "required": [
+                "payment_method"
+            ],
+            "properties": {
+                "payment_method": {
+                    "type": "string",
+                    "example": "mock_success"
+                }
+            }
+        },
+        "handlers.CounterViewingRequest": {
+            "description": "Counter-proposal payload",
+            "type": "object",
+            "required": [
+                "slots"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "I am only available in the evening"
+                },
+                "slots": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2026-11-03T17:00:00+02:00"
+                    ]
+                }
+            }
+        },
+        "handlers.CreateBookingRequest": {
+            "description": "Create booking request payload",
+            "type": "object",
+            "required": [
+                "service_uuid",
+                "starts_at"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Please check the brakes too"
+                },
+                "service_uuid": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-11-02T10:30:00+02:00"
+                }
+            }
+        },
+        "handlers.CreateServiceRequest": {
+            "description": "Create service request payload",
+            "type": "object",
+            "required": [
+                "price",
+                "title"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "currency": {
+                    "type": "string",
+                    "enum": [
+                        "lei",
+                        "euro",
+                        "usd"
+                    ],
+                    "example": "lei"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Full synthetic oil change service"
+                },
+                "duration_minutes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "price": {
+                    "type": "number",
+                    "example": 150
+                },
+                "promotion": {
+                    "type": "string",
+                    "enum": [
+                        "top",
+                        "highlight"
+                    ],
+                    "example": "top"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Oil Change"
+                }
+            }
+        },
+        "handlers.CreateUploadRequest": {
+            "description": "Upload slot payload",
+            "type": "object",
+            "required": [
+                "filename",
+                "size"
+            ],
+            "properties": {
+                "filename": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "front.jpg"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 2483712
+                }
+            }
+        },
+        "handlers.CreateViewingRequest": {
+            "description": "Viewing or test drive request payload",
+            "type": "object",
+            "required": [
+                "slots"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "viewing",
+                        "test_drive"
+                    ],
+                    "example": "test_drive"
                 },
-                "uuid": {
+                "message": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "Could I also see the service history?"
+                },
+                "slots": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2026-11-02T10:30:00+02:00"
+                    ]
                 }
             }
         },
-        "handlers.CreateServiceRequest": {
-            "description": "Create service request payload",
+        "handlers.EquipmentCategoryRequest": {
+            "description": "Equipment category",
             "type": "object",
             "required": [
-                "price",
-                "title"
+                "display_name",
+                "name"
             ],
             "properties": {
-                "active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "currency": {
+                "display_name": {
                     "type": "string",
-                    "example": "lei"
+                    "maxLength": 100,
+                    "example": "Confort"
                 },
-                "description": {
+                "name": {
                     "type": "string",
-                    "example": "Full synthetic oil change service"
+                    "maxLength": 50,
+                    "example": "comfort"
                 },
-                "duration_minutes": {
+                "sort_order": {
                     "type": "integer",
-                    "example": 30
+                    "example": 1
+                }
+            }
+        },
+        "handlers.EquipmentRequest": {
+            "description": "Equipment item",
+            "type": "object",
+            "required": [
+                "category_id",
+                "display_name",
+                "name"
+            ],
+            "properties": {
+                "category_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "price": {
-                    "type": "number",
-                    "example": 150
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Scaune încălzite"
                 },
-                "title": {
+                "name": {
                     "type": "string",
-                    "example": "Oil Change"
+                    "maxLength": 50,
+                    "example": "heated_seats"
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "example": 4
                 }
             }
         },
@@ -1998,6 +8963,22 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.ExchangeRatesResponse": {
+            "description": "Exchange rates expressed in the base currency",
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string",
+                    "example": "lei"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExchangeRate"
+                    }
+                }
+            }
+        },
         "handlers.ForgotPasswordRequest": {
             "description": "Forgot password request payload",
             "type": "object",
@@ -2076,6 +9057,75 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.OrderPromotionRequest": {
+            "description": "Order promotion request payload",
+            "type": "object",
+            "required": [
+                "service_uuid"
+            ],
+            "properties": {
+                "service_uuid": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "handlers.OrganizationRequest": {
+            "description": "Organization profile. On update every field is replaced; omitted optional fields are cleared.",
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Str. Fabricii 12"
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Cluj-Napoca"
+                },
+                "county": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Cluj"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Used cars with warranty"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "contact@dealer.ro"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 2,
+                    "example": "Auto Elys Cluj"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "+40740000000"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "400632"
+                },
+                "vat_code": {
+                    "type": "string",
+                    "example": "RO12345678"
+                },
+                "website": {
+                    "type": "string",
+                    "example": "https://dealer.ro"
+                }
+            }
+        },
         "handlers.PaginationMeta": {
             "description": "Pagination metadata",
             "type": "object",
@@ -2159,6 +9209,19 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.RescheduleBookingRequest": {
+            "description": "Reschedule booking request payload",
+            "type": "object",
+            "required": [
+                "starts_at"
+            ],
+            "properties": {
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-11-03T09:00:00+02:00"
+                }
+            }
+        },
         "handlers.ResetPasswordRequest": {
             "description": "Reset password request payload",
             "type": "object",
@@ -2214,6 +9277,10 @@ const docTemplate = `{
                     "type": "number",
                     "example": 150
                 },
+                "promotion": {
+                    "type": "string",
+                    "example": "top"
+                },
                 "title": {
                     "type": "string",
                     "example": "Oil Change"
@@ -2225,6 +9292,47 @@ const docTemplate = `{
                 "uuid": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.UpdateExchangeRatesRequest": {
+            "description": "Value of one unit of each currency in the base currency (lei)",
+            "type": "object",
+            "required": [
+                "rates"
+            ],
+            "properties": {
+                "rates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    },
+                    "example": {
+                        "euro": 4.97,
+                        "usd": 4.58
+                    }
+                }
+            }
+        },
+        "handlers.UpdateOrganizationMemberRequest": {
+            "description": "New role of the member",
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "manager",
+                        "agent"
+                    ],
+                    "example": "manager"
                 }
             }
         },
@@ -2271,6 +9379,11 @@ const docTemplate = `{
                 },
                 "currency": {
                     "type": "string",
+                    "enum": [
+                        "lei",
+                        "euro",
+                        "usd"
+                    ],
                     "example": "lei"
                 },
                 "description": {
@@ -2285,12 +9398,38 @@ const docTemplate = `{
                     "type": "number",
                     "example": 150
                 },
+                "promotion": {
+                    "type": "string",
+                    "enum": [
+                        "top",
+                        "highlight"
+                    ],
+                    "example": "top"
+                },
                 "title": {
                     "type": "string",
                     "example": "Oil Change"
                 }
             }
         },
+        "handlers.UpdateVehicleStatusRequest": {
+            "description": "Vehicle status change",
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "inactive",
+                        "banned"
+                    ],
+                    "example": "inactive"
+                }
+            }
+        },
         "handlers.UserData": {
             "description": "User data",
             "type": "object",
@@ -2325,6 +9464,248 @@ const docTemplate = `{
                     "$ref": "#/definitions/handlers.UserData"
                 }
             }
+        },
+        "models.Booking": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "service_title": {
+                    "type": "string"
+                },
+                "service_uuid": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_email": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "user_name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BookingException": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "closes_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "opens_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentEvent"
+                    }
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_intent_id": {
+                    "type": "string"
+                },
+                "service_order_id": {
+                    "type": "integer"
+                },
+                "service_order_uuid": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PaymentEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "provider_event_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ViewingRequest": {
+            "type": "object",
+            "properties": {
+                "buyer_id": {
+                    "type": "integer"
+                },
+                "buyer_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "response_message": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "integer"
+                },
+                "seller_name": {
+                    "type": "string"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ViewingSlot"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "vehicle_city": {
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "type": "integer"
+                },
+                "vehicle_slug": {
+                    "type": "string"
+                },
+                "vehicle_title": {
+                    "type": "string"
+                },
+                "vehicle_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ViewingSlot": {
+            "type": "object",
+            "properties": {
+                "proposed_by": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
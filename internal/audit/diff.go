@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Change is the old and new value of a changed field. Old is nil for a created record.
+type Change struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// Fields flattens a struct into its field values keyed by JSON name, with pointers dereferenced
+// (nil pointers become nil). Fields tagged json:"-" and the ignored names are left out.
+func Fields(v interface{}, ignore ...string) map[string]interface{} {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	skip := make(map[string]bool, len(ignore))
+	for _, name := range ignore {
+		skip[name] = true
+	}
+
+	fields := make(map[string]interface{})
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+		if name == "-" || skip[name] {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		for fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() == reflect.Ptr {
+			fields[name] = nil
+			continue
+		}
+		fields[name] = fieldValue.Interface()
+	}
+	return fields
+}
+
+// Diff returns the fields whose value differs between two snapshots returned by Fields, sorted by
+// name. A nil before snapshot (a created record) reports every field with a value.
+func Diff(before, after map[string]interface{}) []Change {
+	names := make([]string, 0, len(after))
+	for name := range after {
+		names = append(names, name)
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	changes := make([]Change, 0)
+	for _, name := range names {
+		oldValue, newValue := before[name], after[name]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, Change{Field: name, Old: oldValue, New: newValue})
+	}
+	return changes
+}
@@ -0,0 +1,120 @@
+// Package booking computes the appointment slots available in the workshop's opening windows.
+package booking
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Step is the spacing between the start times offered in a window
+const Step = 15 * time.Minute
+
+// Window is a period in which appointments can take place, with how many can run at the same time
+type Window struct {
+	Start    time.Time
+	End      time.Time
+	Capacity int
+}
+
+// Interval is the time taken by an existing appointment
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Slot is an appointment start time with the number of appointments that can still take it
+type Slot struct {
+	Start     time.Time `json:"starts_at"`
+	End       time.Time `json:"ends_at"`
+	Available int       `json:"available"`
+}
+
+// ParseClock parses a wall clock time (HH:MM or HH:MM:SS) into minutes after midnight
+func ParseClock(value string) (int, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Hour()*60 + t.Minute(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+}
+
+// FormatClock formats minutes after midnight as HH:MM
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// NewWindow returns the window of a day between two clock times given in minutes after midnight.
+// day only provides the date and the location.
+func NewWindow(day time.Time, opens, closes, capacity int) Window {
+	year, month, date := day.Date()
+	return Window{
+		Start:    time.Date(year, month, date, 0, opens, 0, 0, day.Location()),
+		End:      time.Date(year, month, date, 0, closes, 0, 0, day.Location()),
+		Capacity: capacity,
+	}
+}
+
+// Slots lists the start times after now, every Step from the start of each window, where an appointment
+// of the given duration fits in the window and still has capacity next to the booked appointments
+func Slots(windows []Window, duration time.Duration, booked []Interval, now time.Time) []Slot {
+	slots := []Slot{}
+	for _, window := range windows {
+		for start := window.Start; !start.Add(duration).After(window.End); start = start.Add(Step) {
+			if !start.After(now) {
+				continue
+			}
+			if available := window.Capacity - peak(booked, start, start.Add(duration)); available > 0 {
+				slots = append(slots, Slot{Start: start, End: start.Add(duration), Available: available})
+			}
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	return slots
+}
+
+// Available returns how many more appointments of the given duration can start at start: zero when
+// start is not one of the start times of a window or the window is fully booked
+func Available(windows []Window, duration time.Duration, booked []Interval, start time.Time) int {
+	end := start.Add(duration)
+	for _, window := range windows {
+		if start.Before(window.Start) || end.After(window.End) || start.Sub(window.Start)%Step != 0 {
+			continue
+		}
+		if available := window.Capacity - peak(booked, start, end); available > 0 {
+			return available
+		}
+	}
+	return 0
+}
+
+// peak returns the largest number of booked appointments running at the same time between start and end
+func peak(booked []Interval, start, end time.Time) int {
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+	var edges []edge
+	for _, interval := range booked {
+		if interval.Start.Before(end) && interval.End.After(start) {
+			edges = append(edges, edge{interval.Start, 1}, edge{interval.End, -1})
+		}
+	}
+	// Ends sort before starts at the same instant: back to back appointments do not overlap
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].at.Equal(edges[j].at) {
+			return edges[i].delta < edges[j].delta
+		}
+		return edges[i].at.Before(edges[j].at)
+	})
+
+	running, max := 0, 0
+	for _, e := range edges {
+		running += e.delta
+		if running > max {
+			max = running
+		}
+	}
+	return max
+}
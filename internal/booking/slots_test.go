@@ -0,0 +1,97 @@
+package booking
+
+import (
+	"testing"
+	"time"
+)
+
+var bucharest = mustLocation("Europe/Bucharest")
+
+func mustLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 11, 2, hour, minute, 0, 0, bucharest)
+}
+
+func TestSlots(t *testing.T) {
+	day := at(0, 0)
+	windows := []Window{NewWindow(day, 9*60, 12*60, 2)}
+	booked := []Interval{
+		{at(9, 0), at(10, 0)},
+		{at(9, 30), at(10, 30)},
+	}
+
+	slots := Slots(windows, time.Hour, booked, at(8, 0))
+
+	want := map[string]int{"09:45": 0, "10:00": 1, "10:15": 1, "10:30": 2, "10:45": 2, "11:00": 2, "11:15": 0}
+	got := make(map[string]int)
+	for _, slot := range slots {
+		got[slot.Start.Format("15:04")] = slot.Available
+		if !slot.End.Equal(slot.Start.Add(time.Hour)) {
+			t.Errorf("slot %s ends at %s", slot.Start, slot.End)
+		}
+	}
+	if len(got) != 5 {
+		t.Errorf("got %d slots, want 5: %v", len(got), got)
+	}
+	for start, available := range want {
+		if got[start] != available {
+			t.Errorf("slot %s available = %d, want %d", start, got[start], available)
+		}
+	}
+}
+
+func TestSlotsSkipPast(t *testing.T) {
+	windows := []Window{NewWindow(at(0, 0), 9*60, 10*60, 1)}
+
+	slots := Slots(windows, 30*time.Minute, nil, at(9, 15))
+	if len(slots) != 1 || !slots[0].Start.Equal(at(9, 30)) {
+		t.Errorf("slots = %v, want only 09:30", slots)
+	}
+}
+
+func TestAvailable(t *testing.T) {
+	windows := []Window{
+		NewWindow(at(0, 0), 9*60, 12*60, 1),
+		NewWindow(at(0, 0), 13*60, 17*60, 1),
+	}
+	booked := []Interval{{at(10, 0), at(11, 0)}}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  int
+	}{
+		{"free", at(9, 0), 1},
+		{"back to back", at(11, 0), 1},
+		{"overlapping", at(10, 30), 0},
+		{"crosses lunch break", at(11, 30), 0},
+		{"misaligned", at(13, 10), 0},
+		{"closed", at(18, 0), 0},
+	}
+	for _, tt := range tests {
+		if got := Available(windows, time.Hour, booked, tt.start); got != tt.want {
+			t.Errorf("%s: Available = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	for value, want := range map[string]int{"09:00": 540, "17:30": 1050, "08:15:00": 495} {
+		got, err := ParseClock(value)
+		if err != nil || got != want {
+			t.Errorf("ParseClock(%q) = %d, %v, want %d", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "9", "25:00", "09:60", "09:00am"} {
+		if _, err := ParseClock(value); err == nil {
+			t.Errorf("ParseClock(%q) succeeded", value)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is a key-value store with per-entry expiry. Memory is the in-process implementation; a shared
+// cache can implement the same interface, storing values it knows how to encode.
+type Cache interface {
+	// Get returns the value of key, or false when it is missing or expired
+	Get(key string) (interface{}, bool)
+	// Set stores value under key for ttl
+	Set(key string, value interface{}, ttl time.Duration)
+	// Delete removes the keys
+	Delete(keys ...string)
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(prefix string)
+}
+
+// Memory is an in-process Cache. Expired entries are dropped when read and swept on writes.
+type Memory struct {
+	mu        sync.RWMutex
+	items     map[string]entry
+	nextSweep time.Time
+}
+
+type entry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// sweepInterval is the minimum time between sweeps of expired entries
+const sweepInterval = time.Minute
+
+// NewMemory creates an empty in-process cache
+func NewMemory() *Memory {
+	return &Memory{items: make(map[string]entry)}
+}
+
+func (m *Memory) Get(key string) (interface{}, bool) {
+	m.mu.RLock()
+	item, ok := m.items[key]
+	m.mu.RUnlock()
+	if !ok || time.Now().After(item.expiresAt) {
+		return nil, false
+	}
+	return item.value, true
+}
+
+func (m *Memory) Set(key string, value interface{}, ttl time.Duration) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now.After(m.nextSweep) {
+		for k, item := range m.items {
+			if now.After(item.expiresAt) {
+				delete(m.items, k)
+			}
+		}
+		m.nextSweep = now.Add(sweepInterval)
+	}
+	m.items[key] = entry{value: value, expiresAt: now.Add(ttl)}
+}
+
+func (m *Memory) Delete(keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		delete(m.items, key)
+	}
+}
+
+func (m *Memory) DeletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.items {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.items, key)
+		}
+	}
+}
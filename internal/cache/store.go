@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Store reads through a Cache: a miss runs the loader once however many callers miss the same key
+// concurrently, and the result is cached for the given TTL. A nil Store disables caching.
+type Store struct {
+	backend    Cache
+	generation atomic.Uint64 // incremented by every invalidation, so loads started before it are not cached
+
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// errLoadPanicked is returned to the callers waiting for a load that panicked
+var errLoadPanicked = errors.New("cache: load panicked")
+
+// flight is a load in progress shared by the callers missing the same key
+type flight struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// NewStore creates a store caching in backend
+func NewStore(backend Cache) *Store {
+	return &Store{
+		backend: backend,
+		flights: make(map[string]*flight),
+	}
+}
+
+// Fetch returns the cached value of key, or loads, caches and returns it. Errors are not cached.
+// Cached values are shared between callers, which must not modify them.
+func Fetch[T any](s *Store, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	if s == nil {
+		return load()
+	}
+	if value, ok := s.backend.Get(key); ok {
+		if typed, ok := value.(T); ok {
+			return typed, nil
+		}
+	}
+
+	value, err := s.do(key, func() (interface{}, error) {
+		generation := s.generation.Load()
+		value, err := load()
+		if err == nil && s.generation.Load() == generation {
+			s.backend.Set(key, value, ttl)
+		}
+		return value, err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// do runs load for key unless a load of the same key and generation is in progress, whose result it waits for
+func (s *Store) do(key string, load func() (interface{}, error)) (interface{}, error) {
+	flightKey := strconv.FormatUint(s.generation.Load(), 10) + ":" + key
+
+	s.mu.Lock()
+	if f, ok := s.flights[flightKey]; ok {
+		s.mu.Unlock()
+		<-f.done
+		return f.value, f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	s.flights[flightKey] = f
+	s.mu.Unlock()
+
+	completed := false
+	defer func() {
+		if !completed {
+			f.err = errLoadPanicked
+		}
+		s.mu.Lock()
+		delete(s.flights, flightKey)
+		s.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.value, f.err = load()
+	completed = true
+	return f.value, f.err
+}
+
+// Invalidate removes the keys from the cache
+func (s *Store) Invalidate(keys ...string) {
+	if s == nil {
+		return
+	}
+	s.generation.Add(1)
+	s.backend.Delete(keys...)
+}
+
+// InvalidatePrefix removes the keys starting with prefix from the cache
+func (s *Store) InvalidatePrefix(prefix string) {
+	if s == nil {
+		return
+	}
+	s.generation.Add(1)
+	s.backend.DeletePrefix(prefix)
+}
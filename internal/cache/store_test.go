@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchCaches(t *testing.T) {
+	store := NewStore(NewMemory())
+	loads := 0
+	load := func() ([]string, error) {
+		loads++
+		return []string{"Audi", "BMW"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		brands, err := Fetch(store, "brands", time.Minute, load)
+		if err != nil || len(brands) != 2 {
+			t.Fatalf("Fetch = %v, %v", brands, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("loaded %d times, want 1", loads)
+	}
+
+	store.InvalidatePrefix("bra")
+	if _, err := Fetch(store, "brands", time.Minute, load); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 {
+		t.Errorf("loaded %d times after invalidation, want 2", loads)
+	}
+}
+
+func TestFetchDoesNotCacheErrors(t *testing.T) {
+	store := NewStore(NewMemory())
+	failure := errors.New("database unavailable")
+	loads := 0
+	load := func() (int, error) {
+		loads++
+		return 0, failure
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := Fetch(store, "count", time.Minute, load); !errors.Is(err, failure) {
+			t.Fatalf("err = %v, want %v", err, failure)
+		}
+	}
+	if loads != 2 {
+		t.Errorf("loaded %d times, want 2", loads)
+	}
+}
+
+func TestFetchCoalescesConcurrentMisses(t *testing.T) {
+	store := NewStore(NewMemory())
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := Fetch(store, "answer", time.Minute, load); err != nil || value != 42 {
+				t.Errorf("Fetch = %v, %v", value, err)
+			}
+		}()
+	}
+	// Let the callers reach the flight before the load completes
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Errorf("loaded %d times, want 1", n)
+	}
+}
+
+func TestFetchDiscardsLoadsStartedBeforeInvalidation(t *testing.T) {
+	store := NewStore(NewMemory())
+	_, _ = Fetch(store, "recommended", time.Minute, func() (string, error) {
+		store.Invalidate("recommended")
+		return "stale", nil
+	})
+
+	value, _ := Fetch(store, "recommended", time.Minute, func() (string, error) {
+		return "fresh", nil
+	})
+	if value != "fresh" {
+		t.Errorf("value = %q, want the load started after the invalidation", value)
+	}
+}
+
+func TestMemoryExpiry(t *testing.T) {
+	memory := NewMemory()
+	memory.Set("short", 1, time.Millisecond)
+	memory.Set("long", 2, time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := memory.Get("short"); ok {
+		t.Error("expired entry returned")
+	}
+	if value, ok := memory.Get("long"); !ok || value != 2 {
+		t.Errorf("Get(long) = %v, %v", value, ok)
+	}
+}
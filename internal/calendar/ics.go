@@ -0,0 +1,85 @@
+// Package calendar writes iCalendar (RFC 5545) files for appointments.
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// ContentType is the MIME type of iCalendar files
+const ContentType = "text/calendar; charset=utf-8"
+
+const timestampLayout = "20060102T150405Z"
+
+// Event is a calendar appointment
+type Event struct {
+	UID         string // globally unique, stable across updates of the same appointment
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Created     time.Time // DTSTAMP; now when zero
+}
+
+// ICS returns an iCalendar file with the given events. Times are written in UTC.
+func ICS(events ...Event) []byte {
+	var buf bytes.Buffer
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:-//AutoElys//Appointments//EN")
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	for _, event := range events {
+		stamp := event.Created
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+escape(event.UID))
+		writeLine(&buf, "DTSTAMP:"+stamp.UTC().Format(timestampLayout))
+		writeLine(&buf, "DTSTART:"+event.Start.UTC().Format(timestampLayout))
+		writeLine(&buf, "DTEND:"+event.End.UTC().Format(timestampLayout))
+		writeLine(&buf, "SUMMARY:"+escape(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escape(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&buf, "LOCATION:"+escape(event.Location))
+		}
+		if event.URL != "" {
+			writeLine(&buf, "URL:"+event.URL)
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escape escapes text property values
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// writeLine writes a content line, folded at 75 octets without splitting UTF-8 characters
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8Start(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading space counts towards continuation lines
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func utf8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICS(t *testing.T) {
+	start := time.Date(2026, 11, 2, 10, 30, 0, 0, time.FixedZone("EET", 2*60*60))
+	ics := string(ICS(Event{
+		UID:         "viewing-1@autoelys",
+		Start:       start,
+		End:         start.Add(30 * time.Minute),
+		Summary:     "Viewing: BMW 320d, 2019",
+		Description: "Bring the service book\nand both keys",
+		Location:    "Cluj-Napoca",
+		Created:     time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+	}))
+
+	for _, line := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:viewing-1@autoelys\r\n",
+		"DTSTAMP:20261001T080000Z\r\n",
+		"DTSTART:20261102T083000Z\r\n",
+		"DTEND:20261102T090000Z\r\n",
+		"SUMMARY:Viewing: BMW 320d\\, 2019\r\n",
+		"DESCRIPTION:Bring the service book\\nand both keys\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, line) {
+			t.Errorf("missing %q in:\n%s", line, ics)
+		}
+	}
+}
+
+func TestFolding(t *testing.T) {
+	ics := string(ICS(Event{UID: "1", Summary: strings.Repeat("ăâî", 40)}))
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+strings.Repeat("ăâî", 40)+"\r\n") {
+		t.Errorf("folded summary does not unfold to the original:\n%s", ics)
+	}
+}
@@ -0,0 +1,85 @@
+package catalog
+
+import (
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+)
+
+// BackfillResult summarizes a catalog backfill run
+type BackfillResult struct {
+	Scanned          int      `json:"scanned"`
+	BrandsLinked     int      `json:"brands_linked"`
+	AutomobileLinked int      `json:"automobiles_linked"`
+	Unmatched        []string `json:"unmatched,omitempty"`
+}
+
+// Backfill links existing vehicles to the brand and automobile catalog using fuzzy matching.
+// Matched vehicles also get their brand name normalized to the catalog spelling.
+// With dryRun set, nothing is written and only the result is reported.
+func Backfill(vehicleRepo *repository.VehicleRepository, brandRepo *repository.BrandRepository, automobileRepo *repository.AutomobileRepository, dryRun bool) (*BackfillResult, error) {
+	brands, err := brandRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	matcher := NewMatcher(brands)
+
+	rows, err := vehicleRepo.GetUnlinkedCatalogRows()
+	if err != nil {
+		return nil, err
+	}
+
+	automobilesByBrand := make(map[uint64][]models.Automobile)
+	result := &BackfillResult{}
+
+	for _, row := range rows {
+		result.Scanned++
+
+		var brand *models.Brand
+		if row.BrandID != nil {
+			for i := range brands {
+				if brands[i].ID == *row.BrandID {
+					brand = &brands[i]
+					break
+				}
+			}
+		} else if matched, ok := matcher.MatchBrand(row.Brand); ok {
+			brand = matched
+		}
+
+		if brand == nil {
+			result.Unmatched = append(result.Unmatched, row.Brand+" "+row.Model)
+			continue
+		}
+		if row.BrandID == nil {
+			result.BrandsLinked++
+		}
+
+		automobileID := row.AutomobileID
+		if automobileID == nil {
+			automobiles, cached := automobilesByBrand[brand.ID]
+			if !cached {
+				automobiles, err = automobileRepo.GetByBrandID(brand.ID)
+				if err != nil {
+					return nil, err
+				}
+				automobilesByBrand[brand.ID] = automobiles
+			}
+
+			if automobile, ok := MatchAutomobile(automobiles, brand.Name, row.Model, row.Year); ok {
+				automobileID = &automobile.ID
+				result.AutomobileLinked++
+			}
+		}
+
+		if dryRun {
+			continue
+		}
+
+		brandID := brand.ID
+		if err := vehicleRepo.SetCatalogLinks(row.ID, &brandID, automobileID, brand.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
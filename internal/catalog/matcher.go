@@ -0,0 +1,228 @@
+package catalog
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/utils"
+)
+
+// brandAliases maps common spellings and abbreviations (as slugs) to the catalog brand slug
+var brandAliases = map[string]string{
+	"vw":              "volkswagen",
+	"volkswagen-vw":   "volkswagen",
+	"mercedes":        "mercedes-benz",
+	"mercedesbenz":    "mercedes-benz",
+	"merc":            "mercedes-benz",
+	"mb":              "mercedes-benz",
+	"benz":            "mercedes-benz",
+	"amg":             "mercedes-amg",
+	"chevy":           "chevrolet",
+	"alfa":            "alfa-romeo",
+	"alfaromeo":       "alfa-romeo",
+	"landrover":       "land-rover",
+	"range-rover":     "land-rover",
+	"rolls":           "rolls-royce",
+	"rollsroyce":      "rolls-royce",
+	"aston":           "aston-martin",
+	"ds":              "ds-automobiles",
+	"ssang-yong":      "ssangyong",
+	"ram":             "ram-trucks",
+	"lucid":           "lucid-motors",
+	"tata":            "tata-motors",
+	"mini-cooper":     "mini",
+	"gordon-murray":   "gordon-murray-automotive",
+	"maruti":          "maruti-suzuki",
+	"citroen-ds":      "ds-automobiles",
+	"vauxhall-opel":   "opel",
+	"hyundai-motor":   "hyundai",
+	"kia-motors":      "kia",
+	"toyota-motor":    "toyota",
+	"bmw-alpina":      "bmw",
+	"skoda-auto":      "skoda",
+	"seat-cupra":      "cupra",
+	"dacia-renault":   "dacia",
+	"mitsubishi-fuso": "mitsubishi",
+}
+
+var (
+	modelYearsPattern  = regexp.MustCompile(`(\d{4})\s*-\s*(\d{4}|[Pp]resent)`)
+	modelSuffixPattern = regexp.MustCompile(`(?i)\s*photos,.*$`)
+)
+
+// BrandSlug converts a brand name to the slug used to look it up in the catalog, resolving known aliases
+func BrandSlug(name string) string {
+	slug := utils.GenerateSlug(name)
+	if canonical, ok := brandAliases[slug]; ok {
+		return canonical
+	}
+	return slug
+}
+
+// Matcher resolves free-text brand names against the brand catalog
+type Matcher struct {
+	brands []models.Brand
+	bySlug map[string]int
+}
+
+// NewMatcher indexes the given brands by slug
+func NewMatcher(brands []models.Brand) *Matcher {
+	m := &Matcher{
+		brands: brands,
+		bySlug: make(map[string]int, len(brands)),
+	}
+	for i, brand := range brands {
+		m.bySlug[utils.GenerateSlug(brand.Name)] = i
+	}
+	return m
+}
+
+// MatchBrand finds the catalog brand for a free-text name. It tries the exact slug and known
+// aliases first and falls back to a small edit distance for typos. Ambiguous matches are rejected.
+func (m *Matcher) MatchBrand(name string) (*models.Brand, bool) {
+	slug := BrandSlug(name)
+	if slug == "" {
+		return nil, false
+	}
+	if i, ok := m.bySlug[slug]; ok {
+		return &m.brands[i], true
+	}
+
+	compact := strings.ReplaceAll(slug, "-", "")
+	maxDistance := 0
+	switch {
+	case len(compact) >= 7:
+		maxDistance = 2
+	case len(compact) >= 4:
+		maxDistance = 1
+	}
+
+	best := -1
+	bestDistance := maxDistance + 1
+	ambiguous := false
+	for candidateSlug, i := range m.bySlug {
+		candidate := strings.ReplaceAll(candidateSlug, "-", "")
+		if candidate == compact {
+			return &m.brands[i], true
+		}
+		distance := levenshtein(compact, candidate)
+		if distance < bestDistance {
+			best, bestDistance, ambiguous = i, distance, false
+		} else if distance == bestDistance {
+			ambiguous = true
+		}
+	}
+
+	if best < 0 || ambiguous {
+		return nil, false
+	}
+	return &m.brands[best], true
+}
+
+// CleanModelName strips the brand prefix, production years and trailing marketing text from a catalog automobile name
+func CleanModelName(name, brandName string) string {
+	cleaned := html.UnescapeString(name)
+	cleaned = modelSuffixPattern.ReplaceAllString(cleaned, "")
+	cleaned = modelYearsPattern.ReplaceAllString(cleaned, "")
+	cleaned = strings.Join(strings.Fields(cleaned), " ")
+	if len(cleaned) >= len(brandName) && strings.EqualFold(cleaned[:len(brandName)], brandName) {
+		cleaned = strings.TrimSpace(cleaned[len(brandName):])
+	}
+	return cleaned
+}
+
+// modelYears extracts the production year range from a catalog automobile name (0 when unknown)
+func modelYears(name string) (int, int) {
+	match := modelYearsPattern.FindStringSubmatch(name)
+	if match == nil {
+		return 0, 0
+	}
+	from, _ := strconv.Atoi(match[1])
+	to, err := strconv.Atoi(match[2])
+	if err != nil {
+		to = 9999 // "Present"
+	}
+	return from, to
+}
+
+// MatchAutomobile picks the catalog automobile of a brand that best matches a free-text model name.
+// Every word of the model must appear in the catalog name; when several generations match, the one
+// whose production years include the vehicle year wins, then the one with the shortest name.
+func MatchAutomobile(automobiles []models.Automobile, brandName, model string, year int) (*models.Automobile, bool) {
+	modelSlug := utils.GenerateSlug(model)
+	if modelSlug == "" {
+		return nil, false
+	}
+	modelWords := strings.Split(modelSlug, "-")
+
+	best := -1
+	bestScore := 0
+	bestLength := 0
+	for i, automobile := range automobiles {
+		cleanedSlug := utils.GenerateSlug(CleanModelName(automobile.Name, brandName))
+		if cleanedSlug == "" {
+			continue
+		}
+
+		score := 0
+		if cleanedSlug == modelSlug {
+			score = 3
+		} else if containsAllWords(strings.Split(cleanedSlug, "-"), modelWords) {
+			score = 1
+		} else {
+			continue
+		}
+
+		if year > 0 {
+			if from, to := modelYears(automobile.Name); from > 0 && year >= from && year <= to {
+				score++
+			}
+		}
+
+		if score > bestScore || (score == bestScore && len(cleanedSlug) < bestLength) {
+			best, bestScore, bestLength = i, score, len(cleanedSlug)
+		}
+	}
+
+	if best < 0 {
+		return nil, false
+	}
+	return &automobiles[best], true
+}
+
+func containsAllWords(haystack, needles []string) bool {
+	words := make(map[string]bool, len(haystack))
+	for _, word := range haystack {
+		words[word] = true
+	}
+	for _, needle := range needles {
+		if !words[needle] {
+			return false
+		}
+	}
+	return true
+}
+
+// levenshtein returns the edit distance between two ASCII strings
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,85 @@
+package currency
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"autoelys_backend/internal/models"
+)
+
+// Rates maps a currency code to the value of one unit of it in models.BaseCurrency
+type Rates map[string]float64
+
+// Convert converts an amount between two currencies, rounded to two decimals.
+// It returns false when either currency has no known rate.
+func (r Rates) Convert(amount float64, from, to string) (float64, bool) {
+	from = models.NormalizeCurrency(from)
+	to = models.NormalizeCurrency(to)
+	if from == to {
+		return amount, true
+	}
+
+	fromRate, ok := r[from]
+	if !ok || fromRate <= 0 {
+		return 0, false
+	}
+	toRate, ok := r[to]
+	if !ok || toRate <= 0 {
+		return 0, false
+	}
+
+	return math.Round(amount*fromRate/toRate*100) / 100, true
+}
+
+// ToBase converts an amount into the base currency
+func (r Rates) ToBase(amount float64, from string) (float64, bool) {
+	return r.Convert(amount, from, models.BaseCurrency)
+}
+
+// File is the format of the local exchange rates file, e.g.
+//
+//	{"base": "lei", "rates": {"euro": 4.97, "usd": 4.58}}
+type File struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// LoadFile reads and validates exchange rates from a local JSON file
+func LoadFile(path string) (Rates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid exchange rates file: %w", err)
+	}
+	if file.Base != "" && models.NormalizeCurrency(file.Base) != models.BaseCurrency {
+		return nil, fmt.Errorf("exchange rates must be expressed in %s, got base %q", models.BaseCurrency, file.Base)
+	}
+
+	return Validate(file.Rates)
+}
+
+// Validate normalizes currency codes, rejects unsupported currencies and non-positive rates,
+// and pins the base currency to 1
+func Validate(rates map[string]float64) (Rates, error) {
+	validated := Rates{models.BaseCurrency: 1}
+	for code, rate := range rates {
+		normalized := models.NormalizeCurrency(code)
+		if !models.IsSupportedCurrency(normalized) {
+			return nil, fmt.Errorf("unsupported currency %q", code)
+		}
+		if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("invalid rate for %s: must be a positive number", normalized)
+		}
+		if normalized == models.BaseCurrency && rate != 1 {
+			return nil, fmt.Errorf("rate for base currency %s must be 1", models.BaseCurrency)
+		}
+		validated[normalized] = rate
+	}
+	return validated, nil
+}
@@ -0,0 +1,204 @@
+package feeds
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+)
+
+// Format is an outbound listing feed format
+type Format string
+
+const (
+	FormatXML  Format = "xml"  // generic XML vehicle feed
+	FormatCSV  Format = "csv"  // product catalog for ad platforms
+	FormatJSON Format = "json" // JSON feed
+)
+
+// Formats lists the supported feed formats
+var Formats = []Format{FormatXML, FormatCSV, FormatJSON}
+
+// IsSupported reports whether format is a supported feed format
+func IsSupported(format Format) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentType returns the HTTP content type of a feed format
+func ContentType(format Format) string {
+	switch format {
+	case FormatXML:
+		return "application/xml; charset=utf-8"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Listing availability in feeds
+const (
+	AvailabilityInStock    = "in stock"
+	AvailabilityOutOfStock = "out of stock"
+)
+
+// loadBatchSize is the number of vehicles read from the repository per query
+const loadBatchSize = 200
+
+// Options configure feed generation
+type Options struct {
+	SiteURL     string    // base URL of the public site, used for listing links
+	MediaURL    string    // base URL the uploaded images are served from
+	GeneratedAt time.Time // generation time written in the XML and JSON feeds
+	Since       time.Time // set for incremental feeds, which also report removed listings
+}
+
+// Amount is a price written with two decimals
+type Amount float64
+
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(a), 'f', 2, 64)), nil
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return a.MarshalText()
+}
+
+// Item is a listing as published in the feeds
+type Item struct {
+	ID             string    `json:"id" xml:"id,attr"`
+	ExternalID     string    `json:"external_id,omitempty" xml:"external_id,omitempty"`
+	Availability   string    `json:"availability" xml:"availability"`
+	Title          string    `json:"title" xml:"title"`
+	Description    string    `json:"description,omitempty" xml:"description,omitempty"`
+	URL            string    `json:"url" xml:"url"`
+	Price          Amount    `json:"price" xml:"price"`
+	Currency       string    `json:"currency" xml:"currency"`
+	Negotiable     bool      `json:"negotiable" xml:"negotiable"`
+	Brand          string    `json:"brand" xml:"brand"`
+	Model          string    `json:"model" xml:"model"`
+	Year           int       `json:"year" xml:"year"`
+	Kilometers     *int      `json:"kilometers,omitempty" xml:"kilometers,omitempty"`
+	FuelType       string    `json:"fuel_type" xml:"fuel_type"`
+	BodyType       string    `json:"body_type" xml:"body_type"`
+	Transmission   string    `json:"transmission" xml:"transmission"`
+	Condition      string    `json:"condition" xml:"condition"`
+	Color          string    `json:"color,omitempty" xml:"color,omitempty"`
+	EngineCapacity *int      `json:"engine_capacity,omitempty" xml:"engine_capacity,omitempty"`
+	PowerHP        *int      `json:"power_hp,omitempty" xml:"power_hp,omitempty"`
+	City           string    `json:"city" xml:"city"`
+	Images         []string  `json:"images" xml:"images>image"`
+	UpdatedAt      time.Time `json:"updated_at" xml:"updated_at"`
+}
+
+// NewItem converts a vehicle to a feed item. Only active listings are in stock; other statuses
+// appear in incremental feeds so consumers can remove them.
+func NewItem(vehicle *models.Vehicle, opts Options) Item {
+	item := Item{
+		ID:             vehicle.UUID,
+		Availability:   AvailabilityInStock,
+		Title:          vehicle.Title,
+		URL:            joinURL(opts.SiteURL, "/vehicles/"+vehicle.Slug),
+		Price:          Amount(vehicle.Price),
+		Currency:       models.ISOCurrency(vehicle.Currency),
+		Negotiable:     vehicle.Negotiable,
+		Brand:          vehicle.Brand,
+		Model:          vehicle.Model,
+		Year:           vehicle.Year,
+		Kilometers:     vehicle.Kilometers,
+		FuelType:       vehicle.FuelType,
+		BodyType:       vehicle.BodyType,
+		Transmission:   vehicle.Transmission,
+		Condition:      vehicle.Condition,
+		EngineCapacity: vehicle.EngineCapacity,
+		PowerHP:        vehicle.PowerHP,
+		City:           vehicle.City,
+		Images:         []string{},
+		UpdatedAt:      vehicle.UpdatedAt.UTC(),
+	}
+	if vehicle.Status != models.VehicleStatusActive {
+		item.Availability = AvailabilityOutOfStock
+	}
+	if vehicle.ExternalID != nil {
+		item.ExternalID = *vehicle.ExternalID
+	}
+	if vehicle.Description != nil {
+		item.Description = *vehicle.Description
+	}
+	if vehicle.Color != nil {
+		item.Color = *vehicle.Color
+	}
+
+	// Featured image first, then the remaining images in upload order
+	if vehicle.FeaturedImage != nil && *vehicle.FeaturedImage != "" {
+		item.Images = append(item.Images, joinURL(opts.MediaURL, *vehicle.FeaturedImage))
+	}
+	for _, image := range vehicle.Images {
+		if vehicle.FeaturedImage != nil && image.ImageURL == *vehicle.FeaturedImage {
+			continue
+		}
+		item.Images = append(item.Images, joinURL(opts.MediaURL, image.ImageURL))
+	}
+
+	return item
+}
+
+func joinURL(base, path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// Write generates a feed of the given vehicles in the given format
+func Write(w io.Writer, format Format, vehicles []models.Vehicle, opts Options) error {
+	items := make([]Item, 0, len(vehicles))
+	for i := range vehicles {
+		items = append(items, NewItem(&vehicles[i], opts))
+	}
+
+	switch format {
+	case FormatXML:
+		return writeXML(w, items, opts)
+	case FormatCSV:
+		return writeCSV(w, items)
+	case FormatJSON:
+		return writeJSON(w, items, opts)
+	default:
+		return fmt.Errorf("unsupported feed format %q", format)
+	}
+}
+
+// Load reads the vehicles to publish, least recently updated first: all active vehicles, or with a non-zero
+// since, the vehicles of any status updated after it
+func Load(vehicleRepo *repository.VehicleRepository, since time.Time) ([]models.Vehicle, error) {
+	params := repository.VehicleSearchParams{
+		Sort:            repository.VehicleSortUpdated,
+		Limit:           loadBatchSize,
+		UpdatedSince:    since,
+		IncludeInactive: !since.IsZero(),
+		WithDescription: true,
+	}
+
+	var vehicles []models.Vehicle
+	for {
+		batch, total, err := vehicleRepo.GetAll(params)
+		if err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, batch...)
+		params.Offset += len(batch)
+		if len(batch) < params.Limit || params.Offset >= total {
+			return vehicles, nil
+		}
+	}
+}
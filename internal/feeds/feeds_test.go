@@ -0,0 +1,170 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"autoelys_backend/internal/models"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden feed files in testdata")
+
+var testOptions = Options{
+	SiteURL:     "https://autoelys.example/",
+	MediaURL:    "https://api.autoelys.example",
+	GeneratedAt: time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC),
+}
+
+func loadFixture(t *testing.T) []models.Vehicle {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "vehicles.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vehicles []models.Vehicle
+	if err := json.Unmarshal(data, &vehicles); err != nil {
+		t.Fatal(err)
+	}
+	return vehicles
+}
+
+// checkGolden compares a generated feed with testdata/name, or rewrites it with -update
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch\n--- got ---\n%s\n--- want ---\n%s", name, got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	vehicles := loadFixture(t)
+
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, format, vehicles, testOptions); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, "feed."+string(format), buf.Bytes())
+		})
+	}
+}
+
+func TestWriteIncremental(t *testing.T) {
+	vehicles := loadFixture(t)[2:]
+	opts := testOptions
+	opts.Since = time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, vehicles, opts); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "feed_incremental.json", buf.Bytes())
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, Format("rss"), loadFixture(t), testOptions); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
+
+func TestNewItem(t *testing.T) {
+	vehicles := loadFixture(t)
+
+	item := NewItem(&vehicles[0], testOptions)
+	if item.URL != "https://autoelys.example/vehicles/skoda-octavia-2-0-tdi-style" {
+		t.Errorf("URL = %q", item.URL)
+	}
+	wantImages := []string{
+		"https://api.autoelys.example/uploads/vehicles/octavia-2.jpg",
+		"https://api.autoelys.example/uploads/vehicles/octavia-1.jpg",
+		"https://api.autoelys.example/uploads/vehicles/octavia-3.jpg",
+	}
+	if len(item.Images) != len(wantImages) {
+		t.Fatalf("Images = %v, want %v", item.Images, wantImages)
+	}
+	for i := range wantImages {
+		if item.Images[i] != wantImages[i] {
+			t.Errorf("Images[%d] = %q, want %q", i, item.Images[i], wantImages[i])
+		}
+	}
+	if item.Currency != "EUR" || item.Availability != AvailabilityInStock {
+		t.Errorf("Currency = %q, Availability = %q", item.Currency, item.Availability)
+	}
+
+	removed := NewItem(&vehicles[2], testOptions)
+	if removed.Availability != AvailabilityOutOfStock {
+		t.Errorf("Availability of an inactive listing = %q, want %q", removed.Availability, AvailabilityOutOfStock)
+	}
+	if removed.Images[0] != "https://cdn.dealer.example/a-087.jpg" {
+		t.Errorf("absolute image URL rewritten to %q", removed.Images[0])
+	}
+}
+
+func TestWriteSyndication(t *testing.T) {
+	vehicles := loadFixture(t)
+	channel := Channel{
+		Title:       "AutoElys - Skoda",
+		Description: "Latest vehicle listings on AutoElys",
+		Author:      "AutoElys",
+		Link:        "https://autoelys.example/vehicles?brand=skoda",
+		SelfURL:     "https://api.autoelys.example/api/vehicles/feed?brand=skoda",
+		Updated:     LastModified(vehicles),
+	}
+
+	writers := map[string]func(io.Writer, Channel, []models.Vehicle, Options) error{
+		"feed.rss":  WriteRSS,
+		"feed.atom": WriteAtom,
+	}
+	for name, write := range writers {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := write(&buf, channel, vehicles, testOptions); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, name, buf.Bytes())
+		})
+	}
+}
+
+func TestWriteAtomEmptyIsStable(t *testing.T) {
+	channel := Channel{Title: "AutoElys", SelfURL: "https://api.autoelys.example/api/vehicles/feed"}
+
+	var first, second bytes.Buffer
+	if err := WriteAtom(&first, channel, nil, testOptions); err != nil {
+		t.Fatal(err)
+	}
+	later := testOptions
+	later.GeneratedAt = later.GeneratedAt.Add(time.Hour)
+	if err := WriteAtom(&second, channel, nil, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Errorf("empty feed changed with the generation time:\n%s\n%s", first.String(), second.String())
+	}
+	if !bytes.Contains(first.Bytes(), []byte("<updated>1970-01-01T00:00:00Z</updated>")) {
+		t.Errorf("empty feed has no fixed updated time:\n%s", first.String())
+	}
+}
@@ -0,0 +1,284 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"html"
+	"io"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"autoelys_backend/internal/models"
+)
+
+// Content types of the syndication feeds
+const (
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+	AtomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// Channel describes a syndication feed of listings
+type Channel struct {
+	Title       string
+	Description string
+	Author      string
+	Link        string    // site page showing the same listings
+	SelfURL     string    // URL the feed is served from, also the Atom feed id
+	Updated     time.Time // latest change of the listed vehicles
+}
+
+// LastModified returns the latest update time of the vehicles
+func LastModified(vehicles []models.Vehicle) time.Time {
+	var latest time.Time
+	for i := range vehicles {
+		if vehicles[i].UpdatedAt.After(latest) {
+			latest = vehicles[i].UpdatedAt
+		}
+	}
+	return latest.UTC()
+}
+
+// entry is a listing prepared for the syndication feeds
+type entry struct {
+	Item
+	published time.Time
+	image     string // featured image, empty when the listing has none
+	summary   string // price and key specs as plain text
+}
+
+func newEntries(vehicles []models.Vehicle, opts Options) []entry {
+	entries := make([]entry, 0, len(vehicles))
+	for i := range vehicles {
+		e := entry{
+			Item:      NewItem(&vehicles[i], opts),
+			published: vehicles[i].CreatedAt.UTC(),
+		}
+		if len(e.Images) > 0 {
+			e.image = e.Images[0]
+		}
+		e.summary = strings.Join(append([]string{priceText(&vehicles[i], e.Item)}, specs(e.Item)...), " · ")
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// priceText formats the listing price, followed by the converted price when one was requested
+func priceText(vehicle *models.Vehicle, item Item) string {
+	price, _ := item.Price.MarshalText()
+	text := string(price) + " " + item.Currency
+	if vehicle.ConvertedPrice != nil {
+		converted, _ := Amount(*vehicle.ConvertedPrice).MarshalText()
+		text += " (" + string(converted) + " " + models.ISOCurrency(vehicle.ConvertedCurrency) + ")"
+	}
+	if item.Negotiable {
+		text += ", negotiable"
+	}
+	return text
+}
+
+// specs lists the key specs of a listing
+func specs(item Item) []string {
+	values := []string{strconv.Itoa(item.Year)}
+	if item.Kilometers != nil {
+		values = append(values, strconv.Itoa(*item.Kilometers)+" km")
+	}
+	values = append(values, item.FuelType, item.Transmission, item.BodyType)
+	if item.EngineCapacity != nil {
+		values = append(values, strconv.Itoa(*item.EngineCapacity)+" cm3")
+	}
+	if item.PowerHP != nil {
+		values = append(values, strconv.Itoa(*item.PowerHP)+" HP")
+	}
+	values = append(values, item.City)
+
+	specs := values[:0]
+	for _, value := range values {
+		if value != "" {
+			specs = append(specs, value)
+		}
+	}
+	return specs
+}
+
+// htmlSummary is the entry summary with the featured image, for feed readers rendering HTML
+func (e entry) htmlSummary() string {
+	var b strings.Builder
+	if e.image != "" {
+		b.WriteString(`<p><a href="` + html.EscapeString(e.URL) + `"><img src="` + html.EscapeString(e.image) +
+			`" alt="` + html.EscapeString(e.Title) + `"></a></p>`)
+	}
+	b.WriteString("<p>" + html.EscapeString(e.summary) + "</p>")
+	return b.String()
+}
+
+// imageType guesses the media type of an image from its extension
+func imageType(url string) string {
+	if t := mime.TypeByExtension(strings.ToLower(path.Ext(url))); strings.HasPrefix(t, "image/") {
+		return t
+	}
+	return "image/jpeg"
+}
+
+// RSS 2.0 document
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	Self          rssAtomLink `xml:"atom:link"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Description string        `xml:"description"`
+	Categories  []string      `xml:"category"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// WriteRSS writes the vehicles as an RSS 2.0 feed
+func WriteRSS(w io.Writer, channel Channel, vehicles []models.Vehicle, opts Options) error {
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       channel.Title,
+			Link:        channel.Link,
+			Description: channel.Description,
+			Self:        rssAtomLink{Href: channel.SelfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       []rssItem{},
+		},
+	}
+	if !channel.Updated.IsZero() {
+		feed.Channel.LastBuildDate = channel.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, e := range newEntries(vehicles, opts) {
+		item := rssItem{
+			Title:       e.Title,
+			Link:        e.URL,
+			GUID:        rssGUID{Value: e.ID},
+			PubDate:     e.published.Format(time.RFC1123Z),
+			Description: e.htmlSummary(),
+			Categories:  []string{e.Brand, e.BodyType},
+		}
+		if e.image != "" {
+			item.Enclosure = &rssEnclosure{URL: e.image, Type: imageType(e.image)}
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	return encodeXML(w, feed)
+}
+
+// Atom 1.0 document
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Author   atomAuthor  `xml:"author"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Summary    atomText       `xml:"summary"`
+	Content    atomText       `xml:"content"`
+}
+
+// WriteAtom writes the vehicles as an Atom 1.0 feed
+func WriteAtom(w io.Writer, channel Channel, vehicles []models.Vehicle, opts Options) error {
+	// An empty feed gets a fixed time rather than the generation time, so its body and ETag stay the same
+	// across requests
+	updated := channel.Updated
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+	feed := atomFeed{
+		Title:    channel.Title,
+		Subtitle: channel.Description,
+		ID:       channel.SelfURL,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Author:   atomAuthor{Name: channel.Author},
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: channel.SelfURL},
+			{Rel: "alternate", Type: "text/html", Href: channel.Link},
+		},
+		Entries: []atomEntry{},
+	}
+
+	for _, e := range newEntries(vehicles, opts) {
+		entry := atomEntry{
+			Title:      e.Title,
+			ID:         "urn:uuid:" + e.ID,
+			Links:      []atomLink{{Rel: "alternate", Type: "text/html", Href: e.URL}},
+			Published:  e.published.Format(time.RFC3339),
+			Updated:    e.UpdatedAt.Format(time.RFC3339),
+			Categories: []atomCategory{{Term: e.Brand}, {Term: e.BodyType}},
+			Summary:    atomText{Type: "text", Body: e.summary},
+			Content:    atomText{Type: "html", Body: e.htmlSummary()},
+		}
+		if e.image != "" {
+			entry.Links = append(entry.Links, atomLink{Rel: "enclosure", Type: imageType(e.image), Href: e.image})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return encodeXML(w, feed)
+}
@@ -0,0 +1,134 @@
+package feeds
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xmlFeed is the root element of the XML vehicle feed
+type xmlFeed struct {
+	XMLName     xml.Name  `xml:"vehicles"`
+	GeneratedAt time.Time `xml:"generated_at,attr"`
+	Since       string    `xml:"since,attr,omitempty"`
+	Count       int       `xml:"count,attr"`
+	Items       []Item    `xml:"vehicle"`
+}
+
+func writeXML(w io.Writer, items []Item, opts Options) error {
+	feed := xmlFeed{
+		GeneratedAt: opts.GeneratedAt.UTC(),
+		Count:       len(items),
+		Items:       items,
+	}
+	if !opts.Since.IsZero() {
+		feed.Since = opts.Since.UTC().Format(time.RFC3339)
+	}
+
+	return encodeXML(w, feed)
+}
+
+// encodeXML writes an indented XML document with its header
+func encodeXML(w io.Writer, document interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// csvColumns are the product catalog columns, named after the common ad platform catalog fields
+var csvColumns = []string{
+	"id", "title", "description", "availability", "condition", "price", "link", "image_link",
+	"additional_image_link", "brand", "model", "year", "mileage_km", "fuel_type", "body_type",
+	"transmission", "color", "city", "updated_at",
+}
+
+func writeCSV(w io.Writer, items []Item) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvColumns); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		var imageLink, additionalImageLinks string
+		if len(item.Images) > 0 {
+			imageLink = item.Images[0]
+			additionalImageLinks = strings.Join(item.Images[1:], ",")
+		}
+		var mileage string
+		if item.Kilometers != nil {
+			mileage = strconv.Itoa(*item.Kilometers)
+		}
+		price, _ := item.Price.MarshalText()
+
+		record := []string{
+			item.ID,
+			item.Title,
+			item.Description,
+			item.Availability,
+			catalogCondition(item.Condition),
+			string(price) + " " + item.Currency,
+			item.URL,
+			imageLink,
+			additionalImageLinks,
+			item.Brand,
+			item.Model,
+			strconv.Itoa(item.Year),
+			mileage,
+			item.FuelType,
+			item.BodyType,
+			item.Transmission,
+			item.Color,
+			item.City,
+			item.UpdatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// catalogCondition maps the vehicle condition to the new/used values of product catalogs
+func catalogCondition(condition string) string {
+	if condition == "nou" {
+		return "new"
+	}
+	return "used"
+}
+
+// jsonFeed is the document of the JSON feed
+type jsonFeed struct {
+	GeneratedAt time.Time  `json:"generated_at"`
+	Since       *time.Time `json:"since,omitempty"`
+	Count       int        `json:"count"`
+	Vehicles    []Item     `json:"vehicles"`
+}
+
+func writeJSON(w io.Writer, items []Item, opts Options) error {
+	feed := jsonFeed{
+		GeneratedAt: opts.GeneratedAt.UTC(),
+		Count:       len(items),
+		Vehicles:    items,
+	}
+	if !opts.Since.IsZero() {
+		since := opts.Since.UTC()
+		feed.Since = &since
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(feed)
+}
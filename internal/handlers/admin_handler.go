@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"autoelys_backend/internal/middleware"
+	"autoelys_backend/internal/models"
 	"autoelys_backend/internal/repository"
 	"errors"
 	"net/http"
@@ -31,10 +32,27 @@ type AdminUserData struct {
 	Email     string  `json:"email" example:"john@example.com"`
 	Phone     *string `json:"phone,omitempty" example:"+40712345678"`
 	Active    bool    `json:"active" example:"true"`
+	Version   uint64  `json:"version" example:"3"`
 	CreatedAt string  `json:"created_at" example:"2024-01-15T10:30:00Z"`
 	UpdatedAt string  `json:"updated_at" example:"2024-01-15T10:30:00Z"`
 }
 
+func userToAdminData(user *models.User) AdminUserData {
+	return AdminUserData{
+		ID:        user.ID,
+		UUID:      user.UUID,
+		RoleID:    user.RoleID,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Phone:     user.Phone,
+		Active:    user.Active,
+		Version:   user.Version,
+		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
 // GetAllUsersResponse represents the paginated users response
 // @Description Paginated users response
 type GetAllUsersResponse struct {
@@ -93,19 +111,8 @@ func (h *AdminHandler) GetAllUsers(c *gin.Context) {
 	}
 
 	var userData []AdminUserData
-	for _, user := range users {
-		userData = append(userData, AdminUserData{
-			ID:        user.ID,
-			UUID:      user.UUID,
-			RoleID:    user.RoleID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Phone:     user.Phone,
-			Active:    user.Active,
-			CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		})
+	for i := range users {
+		userData = append(userData, userToAdminData(&users[i]))
 	}
 
 	totalPages := (total + limit - 1) / limit
@@ -121,6 +128,35 @@ func (h *AdminHandler) GetAllUsers(c *gin.Context) {
 	})
 }
 
+// GetUser godoc
+// @Summary Get a user (Admin only)
+// @Description Get user information by UUID
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "User UUID"
+// @Success 200 {object} AdminUserData "User details"
+// @Header 200 {string} ETag "Version of the user, to send as If-Match when updating it"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/users/{uuid} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	user, err := h.userRepo.FindByUUID(c.Param("uuid"))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user"})
+		return
+	}
+
+	setVersionETag(c, user.Version)
+	c.JSON(http.StatusOK, userToAdminData(user))
+}
+
 // AdminUpdateUserRequest represents the update user payload for admin
 // @Description Admin update user request payload
 type AdminUpdateUserRequest struct {
@@ -134,19 +170,23 @@ type AdminUpdateUserRequest struct {
 
 // UpdateUser godoc
 // @Summary Update a user (Admin only)
-// @Description Update user information by UUID
+// @Description Update user information by UUID. Requires the ETag of the version being updated in If-Match; an update of a user modified since that version, by an admin or by the user editing their profile, is rejected with 412.
 // @Tags Admin
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param uuid path string true "User UUID"
+// @Param If-Match header string true "ETag of the user version being updated"
 // @Param request body AdminUpdateUserRequest true "User update details"
 // @Success 200 {object} map[string]interface{} "User updated successfully"
+// @Header 200 {string} ETag "New version of the user"
 // @Failure 400 {object} map[string]string "Bad request"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 403 {object} map[string]string "Forbidden"
 // @Failure 404 {object} map[string]string "User not found"
+// @Failure 412 {object} map[string]interface{} "User modified since it was read, with current_version and current_etag"
 // @Failure 422 {object} map[string]string "Email already taken"
+// @Failure 428 {object} map[string]interface{} "If-Match header missing"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/admin/users/{uuid} [put]
 func (h *AdminHandler) UpdateUser(c *gin.Context) {
@@ -173,6 +213,11 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	if precondition := checkIfMatch(c, "user", user.Version); precondition != nil {
+		precondition.respond(c, gin.H{"error": precondition.message})
+		return
+	}
+
 	var req AdminUpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
@@ -199,6 +244,14 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 	}
 
 	if err := h.userRepo.AdminUpdateUser(user); err != nil {
+		if precondition := versionConflict("user", err); precondition != nil {
+			precondition.respond(c, gin.H{"error": precondition.message})
+			return
+		}
+		if errors.Is(err, repository.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
 		if errors.Is(err, repository.ErrDuplicateEmail) {
 			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Email already taken"})
 			return
@@ -207,20 +260,10 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, user.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "User updated successfully",
-		"user": AdminUserData{
-			ID:        user.ID,
-			UUID:      user.UUID,
-			RoleID:    user.RoleID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Phone:     user.Phone,
-			Active:    user.Active,
-			CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		},
+		"user":    userToAdminData(user),
 	})
 }
 
@@ -411,6 +411,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} UserProfileResponse "User profile"
+// @Header 200 {string} ETag "Version of the user, to send as If-Match when updating the profile"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 404 {object} map[string]string "User not found"
 // @Router /api/auth/me [get]
@@ -440,21 +441,26 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 		},
 	}
 
+	setVersionETag(c, user.Version)
 	c.JSON(http.StatusOK, response)
 }
 
 // UpdateProfile godoc
 // @Summary Update user profile
-// @Description Update the authenticated user's profile information
+// @Description Update the authenticated user's profile information. Requires the ETag of GET /api/auth/me in If-Match; an update of a profile modified since, by the user or by an admin, is rejected with 412.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param If-Match header string true "ETag of the user version being updated"
 // @Param request body UpdateProfileRequest true "Profile update details"
 // @Success 200 {object} UpdateProfileResponse "Profile updated successfully"
+// @Header 200 {string} ETag "New version of the user"
 // @Failure 400 {object} map[string]string "Bad request"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 412 {object} map[string]interface{} "Profile modified since it was read, with current_version and current_etag"
 // @Failure 422 {object} ErrorResponse "Validation error"
+// @Failure 428 {object} map[string]interface{} "If-Match header missing"
 // @Router /api/auth/me [put]
 func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
@@ -485,6 +491,11 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
+	if precondition := checkIfMatch(c, "user", user.Version); precondition != nil {
+		precondition.respond(c, gin.H{"error": precondition.message})
+		return
+	}
+
 	if req.FirstName != "" {
 		user.FirstName = req.FirstName
 	}
@@ -496,6 +507,14 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	if err := h.userRepo.UpdateProfile(user); err != nil {
+		if precondition := versionConflict("user", err); precondition != nil {
+			precondition.respond(c, gin.H{"error": precondition.message})
+			return
+		}
+		if errors.Is(err, repository.ErrUserNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile"})
 		return
 	}
@@ -510,6 +529,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		},
 	}
 
+	setVersionETag(c, user.Version)
 	c.JSON(http.StatusOK, response)
 }
 
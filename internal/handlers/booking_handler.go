@@ -0,0 +1,754 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/booking"
+	"autoelys_backend/internal/middleware"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/services"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bookingHorizonDays is how many days ahead appointments can be booked
+const bookingHorizonDays = 90
+
+type BookingHandler struct {
+	bookingRepo  *repository.BookingRepository
+	serviceRepo  *repository.ServiceRepository
+	emailService *services.EmailService
+	location     *time.Location
+}
+
+// NewBookingHandler creates the booking handler; working hours are wall clock times in location
+func NewBookingHandler(bookingRepo *repository.BookingRepository, serviceRepo *repository.ServiceRepository, emailService *services.EmailService, location *time.Location) *BookingHandler {
+	return &BookingHandler{
+		bookingRepo:  bookingRepo,
+		serviceRepo:  serviceRepo,
+		emailService: emailService,
+		location:     location,
+	}
+}
+
+// BookingHoursRequest represents one period of the weekly working hours
+// @Description Weekly working hours period
+type BookingHoursRequest struct {
+	Weekday  *uint8 `json:"weekday" binding:"required,max=6" example:"1"`
+	OpensAt  string `json:"opens_at" binding:"required" example:"09:00"`
+	ClosesAt string `json:"closes_at" binding:"required" example:"17:00"`
+	Capacity uint   `json:"capacity" binding:"required,min=1" example:"2"`
+}
+
+// BookingExceptionRequest represents the create booking exception payload
+// @Description Booking exception payload; without opens_at and closes_at the day is closed
+type BookingExceptionRequest struct {
+	Date     string  `json:"date" binding:"required" example:"2026-12-24"`
+	OpensAt  *string `json:"opens_at" example:"09:00"`
+	ClosesAt *string `json:"closes_at" example:"13:00"`
+	Capacity *uint   `json:"capacity" example:"1"`
+	Note     *string `json:"note" example:"Christmas Eve"`
+}
+
+// CreateBookingRequest represents the create booking payload
+// @Description Create booking request payload
+type CreateBookingRequest struct {
+	ServiceUUID string    `json:"service_uuid" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+	StartsAt    time.Time `json:"starts_at" binding:"required" example:"2026-11-02T10:30:00+02:00"`
+	Notes       *string   `json:"notes" example:"Please check the brakes too"`
+}
+
+// RescheduleBookingRequest represents the reschedule booking payload
+// @Description Reschedule booking request payload
+type RescheduleBookingRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required" example:"2026-11-03T09:00:00+02:00"`
+}
+
+// GetServiceSlots godoc
+// @Summary Get available appointment slots
+// @Description Get the start times on a date at which the service can still be booked. Slots last the service duration
+// @Description and follow the working hours (or the exceptions of the date) in the workshop's time zone.
+// @Tags Bookings
+// @Produce json
+// @Param uuid path string true "Service UUID"
+// @Param date query string true "Date (YYYY-MM-DD)"
+// @Success 200 {object} map[string]interface{} "Available slots"
+// @Failure 400 {object} map[string]string "Invalid date or service cannot be booked"
+// @Failure 404 {object} map[string]string "Service not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/services/{uuid}/slots [get]
+func (h *BookingHandler) GetServiceSlots(c *gin.Context) {
+	service, ok := h.bookableService(c, c.Param("uuid"))
+	if !ok {
+		return
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", c.Query("date"), h.location)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+		return
+	}
+
+	slots := []booking.Slot{}
+	now := time.Now().In(h.location)
+	if h.withinHorizon(day, now) {
+		windows, err := h.windows(day)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch working hours"})
+			return
+		}
+		booked, err := h.bookingRepo.GetBooked(day)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bookings"})
+			return
+		}
+		slots = booking.Slots(windows, serviceDuration(service), booked, now)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"date":             day.Format("2006-01-02"),
+		"timezone":         h.location.String(),
+		"service_uuid":     service.UUID,
+		"duration_minutes": *service.DurationMinutes,
+		"slots":            slots,
+	})
+}
+
+// GetUserBookings godoc
+// @Summary Get the user's bookings
+// @Description Get the appointments of the authenticated user, latest first
+// @Tags Bookings
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Bookings"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/bookings [get]
+func (h *BookingHandler) GetUserBookings(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	page, limit := parsePagination(c)
+
+	bookings, total, err := h.bookingRepo.GetByUserID(userID.(uint64), limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bookings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": bookings,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// GetBooking godoc
+// @Summary Get a booking
+// @Description Get an appointment of the authenticated user
+// @Tags Bookings
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Booking UUID"
+// @Success 200 {object} models.Booking "Booking"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Booking not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/bookings/{uuid} [get]
+func (h *BookingHandler) GetBooking(c *gin.Context) {
+	b, ok := h.ownBooking(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, b)
+}
+
+// CreateBooking godoc
+// @Summary Book an appointment
+// @Description Book a service at one of its available slots. A confirmation email is sent.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateBookingRequest true "Booking details"
+// @Success 201 {object} map[string]interface{} "Booking created"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Service not found"
+// @Failure 409 {object} map[string]string "Slot is not available"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/bookings [post]
+func (h *BookingHandler) CreateBooking(c *gin.Context) {
+	var req CreateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	service, ok := h.bookableService(c, req.ServiceUUID)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	start := req.StartsAt.In(h.location)
+	b := &models.Booking{
+		UserID:    userID.(uint64),
+		ServiceID: service.ID,
+		StartsAt:  start,
+		EndsAt:    start.Add(serviceDuration(service)),
+		Notes:     req.Notes,
+	}
+	if !h.book(c, start, serviceDuration(service), func(dayStart time.Time, fits func([]booking.Interval) bool) error {
+		return h.bookingRepo.Create(b, dayStart, fits)
+	}) {
+		return
+	}
+
+	created, err := h.bookingRepo.FindByUUID(b.UUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch booking"})
+		return
+	}
+	if err := h.emailService.SendBookingConfirmationEmail(created.UserEmail, h.bookingEmail(created)); err != nil {
+		log.Printf("Booking %s: failed to send confirmation email: %v", created.UUID, err)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Booking created successfully",
+		"booking": created,
+	})
+}
+
+// RescheduleBooking godoc
+// @Summary Reschedule a booking
+// @Description Move a confirmed appointment to another available slot. An email with the new time is sent.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Booking UUID"
+// @Param request body RescheduleBookingRequest true "New start time"
+// @Success 200 {object} map[string]interface{} "Booking rescheduled"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Booking not found"
+// @Failure 409 {object} map[string]string "Slot is not available or booking is cancelled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/bookings/{uuid} [put]
+func (h *BookingHandler) RescheduleBooking(c *gin.Context) {
+	var req RescheduleBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	b, ok := h.ownBooking(c)
+	if !ok {
+		return
+	}
+	if b.Status != models.BookingStatusConfirmed {
+		c.JSON(http.StatusConflict, gin.H{"error": "Booking is cancelled"})
+		return
+	}
+	if !b.StartsAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Past appointments cannot be rescheduled"})
+		return
+	}
+
+	start := req.StartsAt.In(h.location)
+	duration := b.EndsAt.Sub(b.StartsAt)
+	if !h.book(c, start, duration, func(dayStart time.Time, fits func([]booking.Interval) bool) error {
+		return h.bookingRepo.Reschedule(b.ID, start, start.Add(duration), dayStart, fits)
+	}) {
+		return
+	}
+
+	updated, err := h.bookingRepo.FindByUUID(b.UUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch booking"})
+		return
+	}
+	if err := h.emailService.SendBookingRescheduledEmail(updated.UserEmail, h.bookingEmail(updated)); err != nil {
+		log.Printf("Booking %s: failed to send rescheduled email: %v", updated.UUID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Booking rescheduled successfully",
+		"booking": updated,
+	})
+}
+
+// CancelBooking godoc
+// @Summary Cancel a booking
+// @Description Cancel a confirmed appointment, freeing its slot. A cancellation email is sent.
+// @Tags Bookings
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Booking UUID"
+// @Success 200 {object} map[string]interface{} "Booking cancelled"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Booking not found"
+// @Failure 409 {object} map[string]string "Booking is already cancelled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/bookings/{uuid} [delete]
+func (h *BookingHandler) CancelBooking(c *gin.Context) {
+	b, ok := h.ownBooking(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bookingRepo.Cancel(b.ID); err != nil {
+		if errors.Is(err, repository.ErrBookingNotConfirmed) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Booking is already cancelled"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel booking"})
+		return
+	}
+	b.Status = models.BookingStatusCancelled
+
+	if err := h.emailService.SendBookingCancelledEmail(b.UserEmail, h.bookingEmail(b)); err != nil {
+		log.Printf("Booking %s: failed to send cancellation email: %v", b.UUID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Booking cancelled successfully",
+		"booking": b,
+	})
+}
+
+// GetAllBookings godoc
+// @Summary Get bookings (Admin only)
+// @Description Get the appointments between two dates in appointment order
+// @Tags Admin - Bookings
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to today"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to 7 days after from"
+// @Param status query string false "Booking status" Enums(confirmed, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Bookings"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/bookings [get]
+func (h *BookingHandler) GetAllBookings(c *gin.Context) {
+	from, to, ok := h.dateRange(c, 7)
+	if !ok {
+		return
+	}
+	status := c.Query("status")
+	if status != "" && status != models.BookingStatusConfirmed && status != models.BookingStatusCancelled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status, must be one of: confirmed, cancelled"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	bookings, total, err := h.bookingRepo.GetAll(from, to.AddDate(0, 0, 1), status, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bookings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": bookings,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// GetBookingHours godoc
+// @Summary Get working hours (Admin only)
+// @Description Get the weekly working hours in which appointments can be booked
+// @Tags Admin - Bookings
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Working hours"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/booking-hours [get]
+func (h *BookingHandler) GetBookingHours(c *gin.Context) {
+	hours, err := h.bookingRepo.GetHours()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch working hours"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"timezone": h.location.String(), "data": hours})
+}
+
+// UpdateBookingHours godoc
+// @Summary Replace working hours (Admin only)
+// @Description Replace the weekly working hours. A weekday can have several periods (e.g. around a lunch break),
+// @Description which must not overlap. Weekdays without periods are closed. Existing bookings are kept.
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body []BookingHoursRequest true "Working hours"
+// @Success 200 {object} map[string]interface{} "Working hours updated"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/booking-hours [put]
+func (h *BookingHandler) UpdateBookingHours(c *gin.Context) {
+	var req []BookingHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	hours := make([]models.BookingHours, 0, len(req))
+	periods := make(map[uint8][][2]int)
+	for _, item := range req {
+		opens, closes, message := parsePeriod(item.OpensAt, item.ClosesAt)
+		if message != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": message})
+			return
+		}
+		periods[*item.Weekday] = append(periods[*item.Weekday], [2]int{opens, closes})
+		hours = append(hours, models.BookingHours{
+			Weekday:  *item.Weekday,
+			OpensAt:  booking.FormatClock(opens),
+			ClosesAt: booking.FormatClock(closes),
+			Capacity: item.Capacity,
+		})
+	}
+	for weekday, list := range periods {
+		sort.Slice(list, func(i, j int) bool { return list[i][0] < list[j][0] })
+		for i := 1; i < len(list); i++ {
+			if list[i][0] < list[i-1][1] {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Working hours overlap on weekday " + strconv.Itoa(int(weekday))})
+				return
+			}
+		}
+	}
+
+	if err := h.bookingRepo.ReplaceHours(hours); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update working hours"})
+		return
+	}
+
+	h.GetBookingHours(c)
+}
+
+// GetBookingExceptions godoc
+// @Summary Get booking exceptions (Admin only)
+// @Description Get the dates with exceptional working hours or closures between two dates
+// @Tags Admin - Bookings
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First date (YYYY-MM-DD), defaults to today"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to 90 days after from"
+// @Success 200 {object} map[string]interface{} "Exceptions"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/booking-exceptions [get]
+func (h *BookingHandler) GetBookingExceptions(c *gin.Context) {
+	from, to, ok := h.dateRange(c, bookingHorizonDays)
+	if !ok {
+		return
+	}
+
+	exceptions, err := h.bookingRepo.GetExceptions(from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch exceptions"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": exceptions})
+}
+
+// CreateBookingException godoc
+// @Summary Add a booking exception (Admin only)
+// @Description Replace the working hours of a date. Without opens_at and closes_at the date is closed; several
+// @Description periods can be added for the same date. Existing bookings are kept.
+// @Tags Admin - Bookings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body BookingExceptionRequest true "Exception"
+// @Success 201 {object} models.BookingException "Exception created"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/booking-exceptions [post]
+func (h *BookingHandler) CreateBookingException(c *gin.Context) {
+	var req BookingExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+		return
+	}
+	exception := &models.BookingException{Date: date.Format("2006-01-02"), Note: req.Note}
+
+	if req.OpensAt != nil || req.ClosesAt != nil {
+		if req.OpensAt == nil || req.ClosesAt == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Both opens_at and closes_at are required for open days"})
+			return
+		}
+		opens, closes, message := parsePeriod(*req.OpensAt, *req.ClosesAt)
+		if message != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": message})
+			return
+		}
+		if req.Capacity == nil || *req.Capacity == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Capacity of at least 1 is required for open days"})
+			return
+		}
+		opensAt, closesAt := booking.FormatClock(opens), booking.FormatClock(closes)
+		exception.OpensAt, exception.ClosesAt, exception.Capacity = &opensAt, &closesAt, req.Capacity
+	}
+
+	if err := h.bookingRepo.CreateException(exception); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create exception"})
+		return
+	}
+	c.JSON(http.StatusCreated, exception)
+}
+
+// DeleteBookingException godoc
+// @Summary Delete a booking exception (Admin only)
+// @Description Delete an exception, restoring the working hours of its date
+// @Tags Admin - Bookings
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Exception ID"
+// @Success 200 {object} map[string]string "Exception deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]string "Exception not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/booking-exceptions/{id} [delete]
+func (h *BookingHandler) DeleteBookingException(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exception ID"})
+		return
+	}
+
+	if err := h.bookingRepo.DeleteException(uint(id)); err != nil {
+		if errors.Is(err, repository.ErrBookingExceptionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Exception not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete exception"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Exception deleted successfully"})
+}
+
+// book checks that start is a bookable time and stores the appointment with save, which must re-check the
+// slot with fits against the day's appointments while the day is locked
+func (h *BookingHandler) book(c *gin.Context, start time.Time, duration time.Duration, save func(dayStart time.Time, fits func([]booking.Interval) bool) error) bool {
+	now := time.Now().In(h.location)
+	year, month, date := start.Date()
+	dayStart := time.Date(year, month, date, 0, 0, 0, 0, h.location)
+	if !start.After(now) || !h.withinHorizon(dayStart, now) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Appointments can be booked up to " + strconv.Itoa(bookingHorizonDays) + " days ahead"})
+		return false
+	}
+
+	windows, err := h.windows(dayStart)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch working hours"})
+		return false
+	}
+
+	err = save(dayStart, func(booked []booking.Interval) bool {
+		return booking.Available(windows, duration, booked, start) > 0
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSlotUnavailable):
+			c.JSON(http.StatusConflict, gin.H{"error": "Slot is not available"})
+		case errors.Is(err, repository.ErrBookingNotConfirmed):
+			c.JSON(http.StatusConflict, gin.H{"error": "Booking is cancelled"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save booking"})
+		}
+		return false
+	}
+	return true
+}
+
+// windows returns the opening windows of a day: its exceptions if it has any, the weekly hours otherwise
+func (h *BookingHandler) windows(day time.Time) ([]booking.Window, error) {
+	date := day.Format("2006-01-02")
+	exceptions, err := h.bookingRepo.GetExceptions(date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []booking.Window
+	if len(exceptions) > 0 {
+		for _, exception := range exceptions {
+			if exception.OpensAt == nil || exception.ClosesAt == nil || exception.Capacity == nil {
+				continue
+			}
+			if window, ok := newBookingWindow(day, *exception.OpensAt, *exception.ClosesAt, *exception.Capacity); ok {
+				windows = append(windows, window)
+			}
+		}
+		return windows, nil
+	}
+
+	hours, err := h.bookingRepo.GetHours()
+	if err != nil {
+		return nil, err
+	}
+	for _, period := range hours {
+		if time.Weekday(period.Weekday) != day.Weekday() {
+			continue
+		}
+		if window, ok := newBookingWindow(day, period.OpensAt, period.ClosesAt, period.Capacity); ok {
+			windows = append(windows, window)
+		}
+	}
+	return windows, nil
+}
+
+func newBookingWindow(day time.Time, opensAt, closesAt string, capacity uint) (booking.Window, bool) {
+	opens, err := booking.ParseClock(opensAt)
+	if err != nil {
+		return booking.Window{}, false
+	}
+	closes, err := booking.ParseClock(closesAt)
+	if err != nil {
+		return booking.Window{}, false
+	}
+	return booking.NewWindow(day, opens, closes, int(capacity)), true
+}
+
+// parsePeriod parses the opening and closing times of a period, returning a client error message when invalid
+func parsePeriod(opensAt, closesAt string) (int, int, string) {
+	opens, err := booking.ParseClock(opensAt)
+	if err != nil {
+		return 0, 0, "Invalid opens_at, expected HH:MM"
+	}
+	closes, err := booking.ParseClock(closesAt)
+	if err != nil {
+		return 0, 0, "Invalid closes_at, expected HH:MM"
+	}
+	if closes <= opens {
+		return 0, 0, "closes_at must be after opens_at"
+	}
+	return opens, closes, ""
+}
+
+// withinHorizon reports whether a day is between today and the booking horizon
+func (h *BookingHandler) withinHorizon(day, now time.Time) bool {
+	year, month, date := now.Date()
+	today := time.Date(year, month, date, 0, 0, 0, 0, h.location)
+	return !day.Before(today) && day.Before(today.AddDate(0, 0, bookingHorizonDays+1))
+}
+
+// dateRange parses the from and to query dates, defaulting to today and days after from
+func (h *BookingHandler) dateRange(c *gin.Context, days int) (time.Time, time.Time, bool) {
+	year, month, date := time.Now().In(h.location).Date()
+	from := time.Date(year, month, date, 0, 0, 0, 0, h.location)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, h.location)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return from, from, false
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, days)
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, h.location)
+		if err != nil || parsed.Before(from) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD not before from"})
+			return from, from, false
+		}
+		to = parsed
+	}
+	return from, to, true
+}
+
+// bookableService loads an active service with a duration that is not a listing promotion
+func (h *BookingHandler) bookableService(c *gin.Context, uuid string) (*models.Service, bool) {
+	service, err := h.serviceRepo.FindByUUID(uuid)
+	if err != nil {
+		if errors.Is(err, repository.ErrServiceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service"})
+		return nil, false
+	}
+	if !service.Active || service.Promotion != nil || service.DurationMinutes == nil || *service.DurationMinutes == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Service cannot be booked"})
+		return nil, false
+	}
+	return service, true
+}
+
+// ownBooking loads the booking in the path, which must belong to the user unless they are an admin
+func (h *BookingHandler) ownBooking(c *gin.Context) (*models.Booking, bool) {
+	b, err := h.bookingRepo.FindByUUID(c.Param("uuid"))
+	if err != nil {
+		if errors.Is(err, repository.ErrBookingNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch booking"})
+		return nil, false
+	}
+
+	actor := newVehicleActor(c)
+	if b.UserID != actor.userID && actor.roleID != middleware.AdminRoleID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		return nil, false
+	}
+	return b, true
+}
+
+func (h *BookingHandler) bookingEmail(b *models.Booking) services.BookingEmail {
+	return services.BookingEmail{
+		FirstName: firstName(b.UserName),
+		Service:   b.ServiceTitle,
+		When:      b.StartsAt.In(h.location).Format("Monday, 2 January 2006 15:04") + " (" + h.location.String() + ")",
+		Reference: b.UUID,
+	}
+}
+
+// firstName returns the first word of a full name
+func firstName(name string) string {
+	if fields := strings.Fields(name); len(fields) > 0 {
+		return fields[0]
+	}
+	return name
+}
+
+func serviceDuration(service *models.Service) time.Duration {
+	return time.Duration(*service.DurationMinutes) * time.Minute
+}
@@ -6,6 +6,7 @@ import (
 
 	"autoelys_backend/internal/models"
 	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
@@ -26,6 +27,7 @@ func NewBrandHandler(brandRepo *repository.BrandRepository, automobileRepo *repo
 type BrandResponse struct {
 	ID   uint64 `json:"id" example:"1"`
 	Name string `json:"name" example:"AUDI"`
+	Slug string `json:"slug" example:"audi"`
 }
 
 // AutomobileResponse represents the API response for an automobile
@@ -41,6 +43,7 @@ type AutomobileResponse struct {
 // @Accept json
 // @Produce json
 // @Success 200 {object} map[string]interface{} "success with brands array"
+// @Success 304 {string} string "Not modified (If-None-Match matched)"
 // @Failure 500 {object} map[string]interface{} "internal server error"
 // @Router /api/brands [get]
 func (h *BrandHandler) GetAllBrands(c *gin.Context) {
@@ -129,6 +132,7 @@ func mapBrandToResponse(brand *models.Brand) BrandResponse {
 	response := BrandResponse{
 		ID:   brand.ID,
 		Name: brand.Name,
+		Slug: utils.GenerateSlug(brand.Name),
 	}
 
 	return response
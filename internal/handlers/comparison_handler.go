@@ -0,0 +1,594 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/currency"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ComparisonHandler struct {
+	vehicleRepo      *repository.VehicleRepository
+	comparisonRepo   *repository.ComparisonRepository
+	exchangeRateRepo *repository.ExchangeRateRepository
+}
+
+func NewComparisonHandler(vehicleRepo *repository.VehicleRepository, comparisonRepo *repository.ComparisonRepository, exchangeRateRepo *repository.ExchangeRateRepository) *ComparisonHandler {
+	return &ComparisonHandler{
+		vehicleRepo:      vehicleRepo,
+		comparisonRepo:   comparisonRepo,
+		exchangeRateRepo: exchangeRateRepo,
+	}
+}
+
+// ComparisonVehicle represents a compared vehicle (a column of the comparison)
+// @Description Compared vehicle summary
+type ComparisonVehicle struct {
+	UUID          string  `json:"uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Slug          string  `json:"slug" example:"bmw-320d-2019-xdrive-impecabil"`
+	Title         string  `json:"title" example:"BMW 320d 2019 xDrive - Impecabil"`
+	FeaturedImage *string `json:"featured_image,omitempty"`
+	Price         float64 `json:"price" example:"18500"`
+	Currency      string  `json:"currency" example:"euro"`
+	StatusName    string  `json:"status_name" example:"active"`
+}
+
+// ComparisonAttribute represents one attribute aligned across the compared vehicles (a row of the comparison)
+// @Description Attribute values in the same order as the compared vehicles
+type ComparisonAttribute struct {
+	Key       string        `json:"key" example:"kilometers"`
+	Label     string        `json:"label" example:"Kilometers"`
+	Values    []interface{} `json:"values"`
+	Different bool          `json:"different" example:"true"`
+	Best      []int         `json:"best,omitempty"`
+}
+
+// VehicleComparison represents a side-by-side vehicle comparison
+// @Description Vehicles aligned by attribute with differences highlighted
+type VehicleComparison struct {
+	Currency   string                `json:"currency" example:"lei"`
+	Vehicles   []ComparisonVehicle   `json:"vehicles"`
+	Attributes []ComparisonAttribute `json:"attributes"`
+}
+
+// AddComparisonRequest represents the add to comparison payload
+// @Description Add a vehicle to the comparison list
+type AddComparisonRequest struct {
+	Slug string `json:"slug" binding:"required" example:"bmw-320d-2019-xdrive-impecabil"`
+}
+
+// lookupDisplayNames maps lookup values (e.g. "motorina") to their display names, per lookup table
+type lookupDisplayNames struct {
+	personTypes   map[string]string
+	fuelTypes     map[string]string
+	bodyTypes     map[string]string
+	conditions    map[string]string
+	transmissions map[string]string
+	steerings     map[string]string
+}
+
+// comparisonField describes how one attribute is read from a vehicle and which value is better, if any
+type comparisonField struct {
+	key    string
+	label  string
+	better string // "lower", "higher" or empty when no value is better
+	value  func(vehicle *models.Vehicle, names *lookupDisplayNames) interface{}
+}
+
+var comparisonFields = []comparisonField{
+	{key: "brand", label: "Brand", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return v.Brand }},
+	{key: "model", label: "Model", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return v.Model }},
+	{key: "year", label: "Year", better: "higher", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return v.Year }},
+	{key: "kilometers", label: "Kilometers", better: "lower", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return intOrNil(v.Kilometers) }},
+	{key: "fuel_type", label: "Fuel type", value: func(v *models.Vehicle, n *lookupDisplayNames) interface{} {
+		return displayNameOrNil(n.fuelTypes, v.FuelType)
+	}},
+	{key: "transmission", label: "Transmission", value: func(v *models.Vehicle, n *lookupDisplayNames) interface{} {
+		return displayNameOrNil(n.transmissions, v.Transmission)
+	}},
+	{key: "body_type", label: "Body type", value: func(v *models.Vehicle, n *lookupDisplayNames) interface{} {
+		return displayNameOrNil(n.bodyTypes, v.BodyType)
+	}},
+	{key: "engine_capacity", label: "Engine capacity (cm3)", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return intOrNil(v.EngineCapacity) }},
+	{key: "power_hp", label: "Power (HP)", better: "higher", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return intOrNil(v.PowerHP) }},
+	{key: "condition", label: "Condition", value: func(v *models.Vehicle, n *lookupDisplayNames) interface{} {
+		return displayNameOrNil(n.conditions, v.Condition)
+	}},
+	{key: "steering", label: "Steering", value: func(v *models.Vehicle, n *lookupDisplayNames) interface{} {
+		return displayNameOrNil(n.steerings, v.Steering)
+	}},
+	{key: "color", label: "Color", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return stringOrNil(v.Color) }},
+	{key: "number_of_keys", label: "Number of keys", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return intOrNil(v.NumberOfKeys) }},
+	{key: "registered", label: "Registered", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return v.Registered }},
+	{key: "negotiable", label: "Negotiable", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return v.Negotiable }},
+	{key: "person_type", label: "Seller type", value: func(v *models.Vehicle, n *lookupDisplayNames) interface{} {
+		return displayNameOrNil(n.personTypes, v.PersonType)
+	}},
+	{key: "city", label: "City", value: func(v *models.Vehicle, _ *lookupDisplayNames) interface{} { return v.City }},
+}
+
+// CompareVehicles godoc
+// @Summary Compare vehicles side by side (Public)
+// @Description Compare 2 to 4 vehicles by slug. Attributes are aligned in the order of the slugs, lookup values use their display names and every attribute is flagged when the vehicles differ. Prices are shown in a common currency.
+// @Tags vehicles
+// @Produce json
+// @Param slugs query string true "Comma-separated vehicle slugs (2 to 4)"
+// @Param currency query string false "Currency to compare prices in (lei, euro, usd; default: the shared currency of the vehicles, otherwise lei)"
+// @Success 200 {object} map[string]interface{} "Vehicle comparison (data: VehicleComparison)"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/vehicles/compare [get]
+func (h *ComparisonHandler) CompareVehicles(c *gin.Context) {
+	var slugs []string
+	seen := make(map[string]bool)
+	for _, slug := range strings.Split(c.Query("slugs"), ",") {
+		slug = strings.TrimSpace(slug)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+
+	if len(slugs) < 2 || len(slugs) > repository.MaxComparisonVehicles {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": fmt.Sprintf("Provide between 2 and %d distinct vehicle slugs", repository.MaxComparisonVehicles),
+		})
+		return
+	}
+
+	h.respondWithComparison(c, slugs, true)
+}
+
+// GetComparison godoc
+// @Summary Get the saved comparison list
+// @Description Retrieve the authenticated user's saved comparison list, aligned by attribute like the public compare endpoint
+// @Tags vehicles
+// @Produce json
+// @Param currency query string false "Currency to compare prices in (lei, euro, usd)"
+// @Success 200 {object} map[string]interface{} "Saved vehicle comparison (data: VehicleComparison)"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/comparisons [get]
+// @Security BearerAuth
+func (h *ComparisonHandler) GetComparison(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	slugs, err := h.comparisonRepo.GetSlugs(userID.(uint64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve comparison list",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.respondWithComparison(c, slugs, false)
+}
+
+// AddToComparison godoc
+// @Summary Add a vehicle to the saved comparison list
+// @Description Add a vehicle by slug to the authenticated user's comparison list (maximum 4 vehicles). Adding a vehicle twice has no effect.
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Param request body AddComparisonRequest true "Vehicle to compare"
+// @Success 200 {object} map[string]interface{} "Updated comparison (data: VehicleComparison)"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 409 {object} map[string]interface{} "Comparison list is full"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/comparisons [post]
+// @Security BearerAuth
+func (h *ComparisonHandler) AddToComparison(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	var req AddComparisonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request payload",
+		})
+		return
+	}
+
+	vehicle, err := h.vehicleRepo.GetBySlug(req.Slug)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicle",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if vehicle == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Vehicle not found",
+		})
+		return
+	}
+
+	if err := h.comparisonRepo.Add(userID.(uint64), vehicle.ID); err != nil {
+		if errors.Is(err, repository.ErrComparisonFull) {
+			c.JSON(http.StatusConflict, gin.H{
+				"status":  "error",
+				"message": fmt.Sprintf("Comparison list already holds %d vehicles", repository.MaxComparisonVehicles),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to update comparison list",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.GetComparison(c)
+}
+
+// RemoveFromComparison godoc
+// @Summary Remove a vehicle from the saved comparison list
+// @Description Remove a vehicle by slug from the authenticated user's comparison list
+// @Tags vehicles
+// @Produce json
+// @Param slug path string true "Vehicle slug"
+// @Success 200 {object} map[string]interface{} "Updated comparison (data: VehicleComparison)"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Vehicle not in comparison list"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/comparisons/{slug} [delete]
+// @Security BearerAuth
+func (h *ComparisonHandler) RemoveFromComparison(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	vehicle, err := h.vehicleRepo.GetBySlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicle",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	removed := false
+	if vehicle != nil {
+		removed, err = h.comparisonRepo.Remove(userID.(uint64), vehicle.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Failed to update comparison list",
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+	if !removed {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Vehicle not in comparison list",
+		})
+		return
+	}
+
+	h.GetComparison(c)
+}
+
+// ClearComparison godoc
+// @Summary Clear the saved comparison list
+// @Description Remove all vehicles from the authenticated user's comparison list
+// @Tags vehicles
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Comparison list cleared"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/comparisons [delete]
+// @Security BearerAuth
+func (h *ComparisonHandler) ClearComparison(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.comparisonRepo.Clear(userID.(uint64)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to clear comparison list",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Comparison list cleared",
+	})
+}
+
+// respondWithComparison loads the vehicles by slug (keeping their order) and writes the aligned comparison.
+// With strict set, an unknown slug is a 404; otherwise vehicles that no longer exist are skipped.
+func (h *ComparisonHandler) respondWithComparison(c *gin.Context, slugs []string, strict bool) {
+	displayCurrency, rates, ok := parseDisplayCurrency(c, h.exchangeRateRepo)
+	if !ok {
+		return
+	}
+	if rates == nil {
+		var err error
+		rates, err = h.exchangeRateRepo.GetRates()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Failed to retrieve exchange rates",
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	vehicles := make([]*models.Vehicle, 0, len(slugs))
+	for _, slug := range slugs {
+		vehicle, err := h.vehicleRepo.GetBySlug(slug)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Failed to retrieve vehicle",
+				"error":   err.Error(),
+			})
+			return
+		}
+		if vehicle == nil {
+			if strict {
+				c.JSON(http.StatusNotFound, gin.H{
+					"status":  "error",
+					"message": "Vehicle not found: " + slug,
+				})
+				return
+			}
+			continue
+		}
+		vehicles = append(vehicles, vehicle)
+	}
+
+	names, err := h.loadLookupDisplayNames()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicle attributes",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"count":  len(vehicles),
+		"data":   buildComparison(vehicles, names, displayCurrency, rates),
+	})
+}
+
+// loadLookupDisplayNames reads the display names of all vehicle lookup tables
+func (h *ComparisonHandler) loadLookupDisplayNames() (*lookupDisplayNames, error) {
+	names := &lookupDisplayNames{
+		personTypes:   make(map[string]string),
+		fuelTypes:     make(map[string]string),
+		bodyTypes:     make(map[string]string),
+		conditions:    make(map[string]string),
+		transmissions: make(map[string]string),
+		steerings:     make(map[string]string),
+	}
+
+	personTypes, err := h.vehicleRepo.GetAllPersonTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range personTypes {
+		names.personTypes[t.Name] = t.DisplayName
+	}
+
+	fuelTypes, err := h.vehicleRepo.GetAllFuelTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range fuelTypes {
+		names.fuelTypes[t.Name] = t.DisplayName
+	}
+
+	bodyTypes, err := h.vehicleRepo.GetAllBodyTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range bodyTypes {
+		names.bodyTypes[t.Name] = t.DisplayName
+	}
+
+	conditions, err := h.vehicleRepo.GetAllConditions()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range conditions {
+		names.conditions[t.Name] = t.DisplayName
+	}
+
+	transmissions, err := h.vehicleRepo.GetAllTransmissions()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transmissions {
+		names.transmissions[t.Name] = t.DisplayName
+	}
+
+	steerings, err := h.vehicleRepo.GetAllSteerings()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range steerings {
+		names.steerings[t.Name] = t.DisplayName
+	}
+
+	return names, nil
+}
+
+// buildComparison aligns the vehicles attribute by attribute. Prices are converted to the display currency,
+// or when none was requested to the currency all vehicles share (falling back to the base currency).
+func buildComparison(vehicles []*models.Vehicle, names *lookupDisplayNames, displayCurrency string, rates currency.Rates) *VehicleComparison {
+	if displayCurrency == "" {
+		displayCurrency = models.BaseCurrency
+		if len(vehicles) > 0 {
+			displayCurrency = vehicles[0].Currency
+			for _, vehicle := range vehicles[1:] {
+				if vehicle.Currency != displayCurrency {
+					displayCurrency = models.BaseCurrency
+					break
+				}
+			}
+		}
+	}
+
+	comparison := &VehicleComparison{
+		Currency:   displayCurrency,
+		Vehicles:   make([]ComparisonVehicle, 0, len(vehicles)),
+		Attributes: make([]ComparisonAttribute, 0, len(comparisonFields)+1),
+	}
+
+	for _, vehicle := range vehicles {
+		comparison.Vehicles = append(comparison.Vehicles, ComparisonVehicle{
+			UUID:          vehicle.UUID,
+			Slug:          vehicle.Slug,
+			Title:         vehicle.Title,
+			FeaturedImage: vehicle.FeaturedImage,
+			Price:         vehicle.Price,
+			Currency:      vehicle.Currency,
+			StatusName:    vehicle.StatusName,
+		})
+	}
+
+	price := ComparisonAttribute{Key: "price", Label: "Price (" + displayCurrency + ")"}
+	for _, vehicle := range vehicles {
+		if converted, ok := rates.Convert(vehicle.Price, vehicle.Currency, displayCurrency); ok {
+			price.Values = append(price.Values, converted)
+		} else {
+			price.Values = append(price.Values, nil)
+		}
+	}
+	finishComparisonAttribute(&price, "lower")
+	comparison.Attributes = append(comparison.Attributes, price)
+
+	for _, field := range comparisonFields {
+		attribute := ComparisonAttribute{Key: field.key, Label: field.label}
+		for _, vehicle := range vehicles {
+			attribute.Values = append(attribute.Values, field.value(vehicle, names))
+		}
+		finishComparisonAttribute(&attribute, field.better)
+		comparison.Attributes = append(comparison.Attributes, attribute)
+	}
+
+	return comparison
+}
+
+// finishComparisonAttribute flags attributes whose values differ and, for numeric attributes with a
+// preferred direction, marks the indexes of the best values
+func finishComparisonAttribute(attribute *ComparisonAttribute, better string) {
+	if attribute.Values == nil {
+		attribute.Values = []interface{}{}
+	}
+	for i := 1; i < len(attribute.Values); i++ {
+		if fmt.Sprint(attribute.Values[i]) != fmt.Sprint(attribute.Values[0]) {
+			attribute.Different = true
+			break
+		}
+	}
+	if !attribute.Different || better == "" {
+		return
+	}
+
+	var best float64
+	found := false
+	for _, value := range attribute.Values {
+		number, ok := comparisonNumber(value)
+		if !ok {
+			continue
+		}
+		if !found || (better == "lower" && number < best) || (better == "higher" && number > best) {
+			best, found = number, true
+		}
+	}
+	if !found {
+		return
+	}
+	for i, value := range attribute.Values {
+		if number, ok := comparisonNumber(value); ok && number == best {
+			attribute.Best = append(attribute.Best, i)
+		}
+	}
+}
+
+func comparisonNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func intOrNil(value *int) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func stringOrNil(value *string) interface{} {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return *value
+}
+
+func displayNameOrNil(names map[string]string, name string) interface{} {
+	if name == "" {
+		return nil
+	}
+	if displayName, ok := names[name]; ok {
+		return displayName
+	}
+	return name
+}
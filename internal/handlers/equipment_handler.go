@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EquipmentHandler struct {
+	equipmentRepo *repository.EquipmentRepository
+}
+
+func NewEquipmentHandler(equipmentRepo *repository.EquipmentRepository) *EquipmentHandler {
+	return &EquipmentHandler{
+		equipmentRepo: equipmentRepo,
+	}
+}
+
+// EquipmentCategoryRequest represents the create and update equipment category payload
+// @Description Equipment category
+type EquipmentCategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=50" example:"comfort"`
+	DisplayName string `json:"display_name" binding:"required,max=100" example:"Confort"`
+	SortOrder   int    `json:"sort_order" example:"1"`
+}
+
+// EquipmentRequest represents the create and update equipment payload
+// @Description Equipment item
+type EquipmentRequest struct {
+	CategoryID  uint16 `json:"category_id" binding:"required" example:"1"`
+	Name        string `json:"name" binding:"required,max=50" example:"heated_seats"`
+	DisplayName string `json:"display_name" binding:"required,max=100" example:"Scaune încălzite"`
+	SortOrder   int    `json:"sort_order" example:"4"`
+}
+
+// normalizeEquipmentName lowercases a catalog name and replaces spaces and dashes with underscores
+func normalizeEquipmentName(name string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+func parseEquipmentID(c *gin.Context) (uint16, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 16)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid id"})
+		return 0, false
+	}
+	return uint16(id), true
+}
+
+// GetEquipment godoc
+// @Summary Get the equipment catalog (Public)
+// @Description Get all equipment grouped by category. The names are used in the equipment field of vehicles and the equipment filter of the vehicle list.
+// @Tags equipment
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Equipment categories with their equipment"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/equipment [get]
+func (h *EquipmentHandler) GetEquipment(c *gin.Context) {
+	categories, err := h.equipmentRepo.GetCatalog()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch equipment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// CreateEquipmentCategory godoc
+// @Summary Create an equipment category (Admin only)
+// @Tags Admin - Equipment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body EquipmentCategoryRequest true "Category"
+// @Success 201 {object} map[string]interface{} "Category created successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 409 {object} map[string]string "Name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/equipment-categories [post]
+func (h *EquipmentHandler) CreateEquipmentCategory(c *gin.Context) {
+	var req EquipmentCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	category := &models.EquipmentCategory{
+		Name:        normalizeEquipmentName(req.Name),
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		SortOrder:   req.SortOrder,
+	}
+	if err := h.equipmentRepo.CreateCategory(category); err != nil {
+		h.respondError(c, err, "Failed to create equipment category")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Equipment category created successfully",
+		"category": category,
+	})
+}
+
+// UpdateEquipmentCategory godoc
+// @Summary Update an equipment category (Admin only)
+// @Tags Admin - Equipment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param request body EquipmentCategoryRequest true "Category"
+// @Success 200 {object} map[string]interface{} "Category updated successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Category not found"
+// @Failure 409 {object} map[string]string "Name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/equipment-categories/{id} [put]
+func (h *EquipmentHandler) UpdateEquipmentCategory(c *gin.Context) {
+	id, ok := parseEquipmentID(c)
+	if !ok {
+		return
+	}
+
+	var req EquipmentCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	category := &models.EquipmentCategory{
+		ID:          id,
+		Name:        normalizeEquipmentName(req.Name),
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		SortOrder:   req.SortOrder,
+	}
+	if err := h.equipmentRepo.UpdateCategory(category); err != nil {
+		h.respondError(c, err, "Failed to update equipment category")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Equipment category updated successfully",
+		"category": category,
+	})
+}
+
+// DeleteEquipmentCategory godoc
+// @Summary Delete an equipment category (Admin only)
+// @Description Delete an equipment category. Only categories without equipment can be deleted.
+// @Tags Admin - Equipment
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} map[string]string "Category deleted successfully"
+// @Failure 404 {object} map[string]string "Category not found"
+// @Failure 409 {object} map[string]string "Category still has equipment"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/equipment-categories/{id} [delete]
+func (h *EquipmentHandler) DeleteEquipmentCategory(c *gin.Context) {
+	id, ok := parseEquipmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.equipmentRepo.DeleteCategory(id); err != nil {
+		h.respondError(c, err, "Failed to delete equipment category")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Equipment category deleted successfully"})
+}
+
+// CreateEquipment godoc
+// @Summary Create an equipment item (Admin only)
+// @Tags Admin - Equipment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body EquipmentRequest true "Equipment"
+// @Success 201 {object} map[string]interface{} "Equipment created successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Category not found"
+// @Failure 409 {object} map[string]string "Name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/equipment [post]
+func (h *EquipmentHandler) CreateEquipment(c *gin.Context) {
+	var req EquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	item := &models.Equipment{
+		CategoryID:  req.CategoryID,
+		Name:        normalizeEquipmentName(req.Name),
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		SortOrder:   req.SortOrder,
+	}
+	if err := h.equipmentRepo.Create(item); err != nil {
+		h.respondError(c, err, "Failed to create equipment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "Equipment created successfully",
+		"equipment": item,
+	})
+}
+
+// UpdateEquipment godoc
+// @Summary Update an equipment item (Admin only)
+// @Description Update an equipment item. Renaming it changes the name used by API clients and imports.
+// @Tags Admin - Equipment
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Equipment ID"
+// @Param request body EquipmentRequest true "Equipment"
+// @Success 200 {object} map[string]interface{} "Equipment updated successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 404 {object} map[string]string "Equipment or category not found"
+// @Failure 409 {object} map[string]string "Name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/equipment/{id} [put]
+func (h *EquipmentHandler) UpdateEquipment(c *gin.Context) {
+	id, ok := parseEquipmentID(c)
+	if !ok {
+		return
+	}
+
+	var req EquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	item := &models.Equipment{
+		ID:          id,
+		CategoryID:  req.CategoryID,
+		Name:        normalizeEquipmentName(req.Name),
+		DisplayName: strings.TrimSpace(req.DisplayName),
+		SortOrder:   req.SortOrder,
+	}
+	if err := h.equipmentRepo.Update(item); err != nil {
+		h.respondError(c, err, "Failed to update equipment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Equipment updated successfully",
+		"equipment": item,
+	})
+}
+
+// DeleteEquipment godoc
+// @Summary Delete an equipment item (Admin only)
+// @Description Delete an equipment item and remove it from all vehicles
+// @Tags Admin - Equipment
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Equipment ID"
+// @Success 200 {object} map[string]string "Equipment deleted successfully"
+// @Failure 404 {object} map[string]string "Equipment not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/equipment/{id} [delete]
+func (h *EquipmentHandler) DeleteEquipment(c *gin.Context) {
+	id, ok := parseEquipmentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.equipmentRepo.Delete(id); err != nil {
+		h.respondError(c, err, "Failed to delete equipment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Equipment deleted successfully"})
+}
+
+// respondError maps equipment repository errors to responses
+func (h *EquipmentHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrEquipmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Equipment not found"})
+	case errors.Is(err, repository.ErrEquipmentCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Equipment category not found"})
+	case errors.Is(err, repository.ErrDuplicateEquipmentName):
+		c.JSON(http.StatusConflict, gin.H{"error": "Name already exists"})
+	case errors.Is(err, repository.ErrEquipmentCategoryInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": "Equipment category still has equipment"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/currency"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateHandler struct {
+	exchangeRateRepo *repository.ExchangeRateRepository
+}
+
+func NewExchangeRateHandler(exchangeRateRepo *repository.ExchangeRateRepository) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		exchangeRateRepo: exchangeRateRepo,
+	}
+}
+
+// ExchangeRatesResponse represents the exchange rates response
+// @Description Exchange rates expressed in the base currency
+type ExchangeRatesResponse struct {
+	Base  string                `json:"base" example:"lei"`
+	Rates []models.ExchangeRate `json:"rates"`
+}
+
+// UpdateExchangeRatesRequest represents the update exchange rates payload
+// @Description Value of one unit of each currency in the base currency (lei)
+type UpdateExchangeRatesRequest struct {
+	Rates map[string]float64 `json:"rates" binding:"required" example:"euro:4.97,usd:4.58"`
+}
+
+// GetExchangeRates godoc
+// @Summary Get exchange rates (Public)
+// @Description Get the exchange rates used to convert and compare prices. Each rate is the value of one unit of the currency in the base currency.
+// @Tags exchange-rates
+// @Produce json
+// @Success 200 {object} ExchangeRatesResponse "Exchange rates"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/exchange-rates [get]
+func (h *ExchangeRateHandler) GetExchangeRates(c *gin.Context) {
+	rates, err := h.exchangeRateRepo.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch exchange rates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ExchangeRatesResponse{
+		Base:  models.BaseCurrency,
+		Rates: rates,
+	})
+}
+
+// UpdateExchangeRates godoc
+// @Summary Update exchange rates (Admin only)
+// @Description Set exchange rates for supported currencies (lei, euro, usd) and recompute the normalized price of all vehicles. Currencies not included keep their current rate.
+// @Tags Admin - Exchange Rates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateExchangeRatesRequest true "Exchange rates"
+// @Success 200 {object} ExchangeRatesResponse "Updated exchange rates"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/exchange-rates [put]
+func (h *ExchangeRateHandler) UpdateExchangeRates(c *gin.Context) {
+	var req UpdateExchangeRatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	rates, err := currency.Validate(req.Rates)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.exchangeRateRepo.SaveRates(rates); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update exchange rates"})
+		return
+	}
+
+	h.GetExchangeRates(c)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"autoelys_backend/internal/feeds"
+	"autoelys_backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	vehicleRepo *repository.VehicleRepository
+	options     feeds.Options
+}
+
+// NewFeedHandler creates a feed handler; options carry the site and media base URLs
+func NewFeedHandler(vehicleRepo *repository.VehicleRepository, options feeds.Options) *FeedHandler {
+	return &FeedHandler{
+		vehicleRepo: vehicleRepo,
+		options:     options,
+	}
+}
+
+// GetFeed godoc
+// @Summary Get the listing feed (Feed token required)
+// @Description Export listings for aggregators and ad platforms as a generic XML vehicle feed, a CSV product catalog or a JSON feed. Without since the feed holds all active listings; with since it holds the listings of any status changed after that time, and listings no longer active have availability "out of stock" so consumers can remove them.
+// @Tags feeds
+// @Produce xml
+// @Produce plain
+// @Produce json
+// @Param format path string true "Feed format (xml, csv, json)"
+// @Param since query string false "Only listings changed after this time (RFC 3339, e.g. 2026-03-01T00:00:00Z)"
+// @Param token query string false "Feed token (alternatively sent in the X-Feed-Token header)"
+// @Success 200 {string} string "Feed"
+// @Failure 400 {object} map[string]string "Invalid format or since value"
+// @Failure 401 {object} map[string]string "Missing or invalid feed token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/feeds/{format} [get]
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	format := feeds.Format(c.Param("format"))
+	if !feeds.IsSupported(format) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported feed format. Allowed: xml, csv, json"})
+		return
+	}
+
+	options := h.options
+	options.GeneratedAt = time.Now()
+	if value := c.Query("since"); value != "" {
+		since, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since value, expected an RFC 3339 time"})
+			return
+		}
+		options.Since = since
+	}
+
+	vehicles, err := feeds.Load(h.vehicleRepo, options.Since)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vehicles"})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := feeds.Write(&buf, format, vehicles, options); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate feed"})
+		return
+	}
+
+	c.Data(http.StatusOK, feeds.ContentType(format), buf.Bytes())
+}
@@ -0,0 +1,738 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/middleware"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// organizationLogoDir is where organization logos are stored
+const organizationLogoDir = "./uploads/organizations"
+
+// vatCodePattern matches a VAT code after normalization: an optional country prefix and 2-12 digits or letters
+var vatCodePattern = regexp.MustCompile(`^([A-Z]{2})?[0-9A-Z]{2,12}$`)
+
+type OrganizationHandler struct {
+	organizationRepo *repository.OrganizationRepository
+	userRepo         *repository.UserRepository
+	vehicleRepo      *repository.VehicleRepository
+	exchangeRateRepo *repository.ExchangeRateRepository
+}
+
+func NewOrganizationHandler(organizationRepo *repository.OrganizationRepository, userRepo *repository.UserRepository, vehicleRepo *repository.VehicleRepository, exchangeRateRepo *repository.ExchangeRateRepository) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationRepo: organizationRepo,
+		userRepo:         userRepo,
+		vehicleRepo:      vehicleRepo,
+		exchangeRateRepo: exchangeRateRepo,
+	}
+}
+
+// OrganizationRequest represents the create and update organization payload
+// @Description Organization profile. On update every field is replaced; omitted optional fields are cleared.
+type OrganizationRequest struct {
+	Name        string  `json:"name" binding:"required,min=2,max=255" example:"Auto Elys Cluj"`
+	Description *string `json:"description" example:"Used cars with warranty"`
+	Email       *string `json:"email" binding:"omitempty,email" example:"contact@dealer.ro"`
+	Phone       *string `json:"phone" binding:"omitempty,max=50" example:"+40740000000"`
+	Website     *string `json:"website" binding:"omitempty,url" example:"https://dealer.ro"`
+	Address     *string `json:"address" binding:"omitempty,max=255" example:"Str. Fabricii 12"`
+	City        *string `json:"city" binding:"omitempty,max=100" example:"Cluj-Napoca"`
+	County      *string `json:"county" binding:"omitempty,max=100" example:"Cluj"`
+	PostalCode  *string `json:"postal_code" binding:"omitempty,max=20" example:"400632"`
+	VATCode     *string `json:"vat_code" example:"RO12345678"`
+}
+
+// AddOrganizationMemberRequest represents the add member payload
+// @Description Existing user to add to the organization
+type AddOrganizationMemberRequest struct {
+	Email string `json:"email" binding:"required,email" example:"agent@dealer.ro"`
+	Role  string `json:"role" binding:"required" example:"agent" enums:"owner,manager,agent"`
+}
+
+// UpdateOrganizationMemberRequest represents the change member role payload
+// @Description New role of the member
+type UpdateOrganizationMemberRequest struct {
+	Role string `json:"role" binding:"required" example:"manager" enums:"owner,manager,agent"`
+}
+
+// applyTo copies the request onto the organization; it returns a client error message when invalid
+func (req *OrganizationRequest) applyTo(org *models.Organization) string {
+	org.Name = strings.TrimSpace(req.Name)
+	org.Description = trimmedOrNil(req.Description)
+	org.Email = trimmedOrNil(req.Email)
+	org.Phone = trimmedOrNil(req.Phone)
+	org.Website = trimmedOrNil(req.Website)
+	org.Address = trimmedOrNil(req.Address)
+	org.City = trimmedOrNil(req.City)
+	org.County = trimmedOrNil(req.County)
+	org.PostalCode = trimmedOrNil(req.PostalCode)
+	org.VATCode = nil
+
+	if req.VATCode != nil {
+		vatCode := strings.ToUpper(strings.Join(strings.Fields(*req.VATCode), ""))
+		if vatCode != "" {
+			if !vatCodePattern.MatchString(vatCode) {
+				return "Invalid vat_code value"
+			}
+			org.VATCode = &vatCode
+		}
+	}
+	return ""
+}
+
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// membership loads the organization of the :uuid path parameter and the role of the authenticated user in it.
+// Admins act as owners of every organization. It responds and returns false when the organization does not
+// exist or the user is not a member.
+func (h *OrganizationHandler) membership(c *gin.Context) (*models.Organization, string, bool) {
+	org, err := h.organizationRepo.FindByUUID(c.Param("uuid"))
+	if errors.Is(err, repository.ErrOrganizationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+		return nil, "", false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organization"})
+		return nil, "", false
+	}
+
+	if roleID, _ := c.Get("role_id"); roleID == middleware.AdminRoleID {
+		return org, models.OrganizationRoleOwner, true
+	}
+
+	userID, _ := c.Get("user_id")
+	role, err := h.organizationRepo.GetMemberRole(org.ID, userID.(uint64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organization membership"})
+		return nil, "", false
+	}
+	if role == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not a member of this organization"})
+		return nil, "", false
+	}
+	return org, role, true
+}
+
+// uniqueSlug generates an organization slug from the name, suffixed with -2, -3, ... when it is already taken
+func (h *OrganizationHandler) uniqueSlug(name string) (string, error) {
+	base := utils.GenerateSlug(name)
+	slug := base
+	for n := 2; ; n++ {
+		exists, err := h.organizationRepo.SlugExists(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Create a dealer organization. The authenticated user becomes its owner. The public storefront slug is generated from the name and does not change on rename.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body OrganizationRequest true "Organization profile"
+// @Success 201 {object} map[string]interface{} "Organization created successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	org := &models.Organization{}
+	if errMsg := req.applyTo(org); errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+
+	slug, err := h.uniqueSlug(org.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+		return
+	}
+	org.Slug = slug
+
+	if err := h.organizationRepo.Create(org, userID.(uint64)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create organization"})
+		return
+	}
+
+	created, err := h.organizationRepo.FindByID(org.ID)
+	if err == nil {
+		org = created
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Organization created successfully",
+		"organization": models.OrganizationMembership{Organization: *org, Role: models.OrganizationRoleOwner},
+	})
+}
+
+// GetUserOrganizations godoc
+// @Summary Get my organizations
+// @Description Get the organizations the authenticated user belongs to, with the user's role in each
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Organizations"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/organizations [get]
+func (h *OrganizationHandler) GetUserOrganizations(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	memberships, err := h.organizationRepo.GetByUserID(userID.(uint64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"organizations": memberships})
+}
+
+// GetOrganization godoc
+// @Summary Get an organization (Members only)
+// @Description Get the organization profile and the role of the authenticated user
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Success 200 {object} map[string]interface{} "Organization"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not a member"
+// @Failure 404 {object} map[string]string "Organization not found"
+// @Router /api/organizations/{uuid} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	org, role, ok := h.membership(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"organization": models.OrganizationMembership{Organization: *org, Role: role},
+	})
+}
+
+// UpdateOrganization godoc
+// @Summary Update an organization (Owners and managers)
+// @Description Replace the organization profile. The storefront slug and the logo are kept.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Param request body OrganizationRequest true "Organization profile"
+// @Success 200 {object} map[string]interface{} "Organization updated successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Organization not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid} [put]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	org, role, ok := h.membership(c)
+	if !ok {
+		return
+	}
+	if !models.CanManageOrganization(role) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners and managers can update the organization"})
+		return
+	}
+
+	var req OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if errMsg := req.applyTo(org); errMsg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+
+	if err := h.organizationRepo.Update(org); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update organization"})
+		return
+	}
+
+	updated, err := h.organizationRepo.FindByID(org.ID)
+	if err == nil {
+		org = updated
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Organization updated successfully",
+		"organization": models.OrganizationMembership{Organization: *org, Role: role},
+	})
+}
+
+// DeleteOrganization godoc
+// @Summary Delete an organization (Owners only)
+// @Description Delete the organization and its memberships. Its vehicles are kept and stay with the members who listed them.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Success 200 {object} map[string]string "Organization deleted successfully"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Organization not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	org, role, ok := h.membership(c)
+	if !ok {
+		return
+	}
+	if role != models.OrganizationRoleOwner {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can delete the organization"})
+		return
+	}
+
+	if err := h.organizationRepo.Delete(org.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete organization"})
+		return
+	}
+	if org.Logo != nil {
+		_ = utils.DeleteFile(*org.Logo)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Organization deleted successfully"})
+}
+
+// UploadOrganizationLogo godoc
+// @Summary Upload the organization logo (Owners and managers)
+// @Description Upload or replace the organization logo (jpg, jpeg, png, webp; max 10MB)
+// @Tags organizations
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Param logo formData file true "Logo image"
+// @Success 200 {object} map[string]interface{} "Logo uploaded successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Organization not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid}/logo [post]
+func (h *OrganizationHandler) UploadOrganizationLogo(c *gin.Context) {
+	org, role, ok := h.membership(c)
+	if !ok {
+		return
+	}
+	if !models.CanManageOrganization(role) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners and managers can change the logo"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("logo")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Logo file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read the logo file"})
+		return
+	}
+	defer file.Close()
+
+	logo, err := utils.SaveImage(file, fileHeader.Filename, organizationLogoDir)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.organizationRepo.UpdateLogo(org.ID, &logo); err != nil {
+		_ = utils.DeleteFile(logo)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save logo"})
+		return
+	}
+	if org.Logo != nil {
+		_ = utils.DeleteFile(*org.Logo)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logo uploaded successfully",
+		"logo":    logo,
+	})
+}
+
+// GetOrganizationMembers godoc
+// @Summary Get organization members (Members only)
+// @Description Get the members of the organization with their roles
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Success 200 {object} map[string]interface{} "Members"
+// @Failure 403 {object} map[string]string "Not a member"
+// @Failure 404 {object} map[string]string "Organization not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid}/members [get]
+func (h *OrganizationHandler) GetOrganizationMembers(c *gin.Context) {
+	org, _, ok := h.membership(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationRepo.GetMembers(org.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// AddOrganizationMember godoc
+// @Summary Add an organization member (Owners and managers)
+// @Description Add a registered user to the organization by email. Owners can add any role; managers can add agents.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Param request body AddOrganizationMemberRequest true "Member"
+// @Success 201 {object} map[string]string "Member added successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Organization or user not found"
+// @Failure 409 {object} map[string]string "Already a member"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid}/members [post]
+func (h *OrganizationHandler) AddOrganizationMember(c *gin.Context) {
+	org, role, ok := h.membership(c)
+	if !ok {
+		return
+	}
+
+	var req AddOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if !models.IsValidOrganizationRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role, must be one of: owner, manager, agent"})
+		return
+	}
+	if !canAssignRole(role, req.Role) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to add members with this role"})
+		return
+	}
+
+	user, err := h.userRepo.FindByEmail(req.Email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No user registered with this email"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch user"})
+		return
+	}
+
+	if err := h.organizationRepo.AddMember(org.ID, user.ID, req.Role); err != nil {
+		if errors.Is(err, repository.ErrDuplicateMember) {
+			c.JSON(http.StatusConflict, gin.H{"error": "User is already a member of the organization"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add member"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Member added successfully"})
+}
+
+// UpdateOrganizationMember godoc
+// @Summary Change a member's role (Owners only)
+// @Description Change the role of an organization member. The last owner cannot be demoted.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Param user_uuid path string true "Member user UUID"
+// @Param request body UpdateOrganizationMemberRequest true "Role"
+// @Success 200 {object} map[string]string "Member updated successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Organization or member not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid}/members/{user_uuid} [put]
+func (h *OrganizationHandler) UpdateOrganizationMember(c *gin.Context) {
+	org, role, ok := h.membership(c)
+	if !ok {
+		return
+	}
+	if role != models.OrganizationRoleOwner {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only owners can change member roles"})
+		return
+	}
+
+	var req UpdateOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if !models.IsValidOrganizationRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role, must be one of: owner, manager, agent"})
+		return
+	}
+
+	member, _, ok := h.member(c, org)
+	if !ok {
+		return
+	}
+	if err := h.organizationRepo.UpdateMemberRole(org.ID, member.ID, req.Role); err != nil {
+		h.respondMemberChangeError(c, err, "Failed to update member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member updated successfully"})
+}
+
+// RemoveOrganizationMember godoc
+// @Summary Remove an organization member
+// @Description Remove a member from the organization. Owners can remove anyone, managers can remove agents, and every member can leave. The last owner cannot be removed. The member's vehicles stay listed for the organization.
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Param user_uuid path string true "Member user UUID"
+// @Success 200 {object} map[string]string "Member removed successfully"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Organization or member not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid}/members/{user_uuid} [delete]
+func (h *OrganizationHandler) RemoveOrganizationMember(c *gin.Context) {
+	org, role, ok := h.membership(c)
+	if !ok {
+		return
+	}
+
+	member, memberRole, ok := h.member(c, org)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	isSelf := member.ID == userID.(uint64)
+	if !isSelf && !canAssignRole(role, memberRole) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to remove this member"})
+		return
+	}
+	if err := h.organizationRepo.RemoveMember(org.ID, member.ID); err != nil {
+		h.respondMemberChangeError(c, err, "Failed to remove member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// member loads the member of the :user_uuid path parameter, responding and returning false when not found
+func (h *OrganizationHandler) member(c *gin.Context, org *models.Organization) (*models.User, string, bool) {
+	user, err := h.userRepo.FindByUUID(c.Param("user_uuid"))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		return nil, "", false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch member"})
+		return nil, "", false
+	}
+
+	role, err := h.organizationRepo.GetMemberRole(org.ID, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch member"})
+		return nil, "", false
+	}
+	if role == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+		return nil, "", false
+	}
+	return user, role, true
+}
+
+// respondMemberChangeError responds to a failed role change or removal of a member
+func (h *OrganizationHandler) respondMemberChangeError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repository.ErrLastOwner):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The organization must keep at least one owner"})
+	case errors.Is(err, repository.ErrOrganizationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Organization not found"})
+	case errors.Is(err, repository.ErrMemberNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Member not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// canAssignRole reports whether a member with the given role may add or remove members with the target role
+func canAssignRole(role, target string) bool {
+	switch role {
+	case models.OrganizationRoleOwner:
+		return true
+	case models.OrganizationRoleManager:
+		return target == models.OrganizationRoleAgent
+	}
+	return false
+}
+
+// GetOrganizationVehicles godoc
+// @Summary Get organization vehicles (Members only)
+// @Description Get all vehicles listed for the organization, of any status, newest first
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Organization UUID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Vehicles"
+// @Failure 403 {object} map[string]string "Not a member"
+// @Failure 404 {object} map[string]string "Organization not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organizations/{uuid}/vehicles [get]
+func (h *OrganizationHandler) GetOrganizationVehicles(c *gin.Context) {
+	org, _, ok := h.membership(c)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePagination(c)
+	vehicles, total, err := h.vehicleRepo.GetAll(repository.VehicleSearchParams{
+		OrganizationID:  org.ID,
+		IncludeInactive: true,
+		Limit:           limit,
+		Offset:          (page - 1) * limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vehicles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": vehicles,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// GetDealer godoc
+// @Summary Get a dealer storefront (Public)
+// @Description Get the public profile of a dealer organization and its active listings, newest first
+// @Tags organizations
+// @Produce json
+// @Param slug path string true "Organization slug"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Param currency query string false "Convert prices to this currency (lei, euro, usd)"
+// @Success 200 {object} map[string]interface{} "Dealer and listings"
+// @Failure 400 {object} map[string]interface{} "Invalid currency"
+// @Failure 404 {object} map[string]interface{} "Dealer not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/dealers/{slug} [get]
+func (h *OrganizationHandler) GetDealer(c *gin.Context) {
+	org, err := h.organizationRepo.FindBySlug(c.Param("slug"))
+	if errors.Is(err, repository.ErrOrganizationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Dealer not found",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve dealer",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	displayCurrency, rates, ok := parseDisplayCurrency(c, h.exchangeRateRepo)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePagination(c)
+	vehicles, total, err := h.vehicleRepo.GetAll(repository.VehicleSearchParams{
+		OrganizationID: org.ID,
+		Limit:          limit,
+		Offset:         (page - 1) * limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicles",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	for i := range vehicles {
+		maskVehicleVIN(&vehicles[i])
+		convertVehiclePrice(&vehicles[i], displayCurrency, rates)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"dealer": org,
+		"data":   vehicles,
+		"pagination": gin.H{
+			"page":        page,
+			"limit":       limit,
+			"total":       total,
+			"total_pages": (total + limit - 1) / limit,
+		},
+	})
+}
+
+// parsePagination reads the page and limit query parameters (default 1 and 20, limit at most 100)
+func parsePagination(c *gin.Context) (int, int) {
+	page := 1
+	if val, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil && val > 0 {
+		page = val
+	}
+
+	limit := 20
+	if val, err := strconv.Atoi(c.DefaultQuery("limit", "20")); err == nil && val > 0 {
+		limit = val
+		if limit > 100 {
+			limit = 100
+		}
+	}
+	return page, limit
+}
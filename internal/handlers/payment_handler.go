@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/middleware"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/payments"
+	"autoelys_backend/internal/repository"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookSize limits the body of payment webhooks
+const maxWebhookSize = 1 << 20
+
+type PaymentHandler struct {
+	paymentRepo      *repository.PaymentRepository
+	serviceOrderRepo *repository.ServiceOrderRepository
+	provider         payments.Provider
+}
+
+func NewPaymentHandler(paymentRepo *repository.PaymentRepository, serviceOrderRepo *repository.ServiceOrderRepository, provider payments.Provider) *PaymentHandler {
+	return &PaymentHandler{
+		paymentRepo:      paymentRepo,
+		serviceOrderRepo: serviceOrderRepo,
+		provider:         provider,
+	}
+}
+
+// ProviderRequired answers 503 Service Unavailable on the payment routes that need a payment provider when
+// none is configured
+func (h *PaymentHandler) ProviderRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if h.provider == nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Payments are not available"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// ConfirmPaymentRequest represents the confirm payment payload
+// @Description Confirm payment request payload
+type ConfirmPaymentRequest struct {
+	PaymentMethod string `json:"payment_method" binding:"required" example:"mock_success"`
+}
+
+// CreatePayment godoc
+// @Summary Pay for a service order
+// @Description Start a payment for a pending service order of the user. Requests are idempotent per Idempotency-Key:
+// @Description repeating one returns the payment it created. Once the payment succeeds the order is activated.
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Service order UUID"
+// @Param Idempotency-Key header string true "Unique key for this payment attempt"
+// @Success 201 {object} map[string]interface{} "Payment created"
+// @Success 200 {object} map[string]interface{} "Payment previously created with this Idempotency-Key"
+// @Failure 400 {object} map[string]string "Missing Idempotency-Key"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Service order not found"
+// @Failure 409 {object} map[string]string "Service order cannot be paid"
+// @Failure 422 {object} map[string]string "Idempotency-Key used for another service order"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 502 {object} map[string]string "Payment provider error"
+// @Failure 503 {object} map[string]string "Payments are not available"
+// @Router /api/user/service-orders/{uuid}/payments [post]
+func (h *PaymentHandler) CreatePayment(c *gin.Context) {
+	key := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
+	if key == "" || len(key) > 255 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key header is required (at most 255 characters)"})
+		return
+	}
+	actor := newVehicleActor(c)
+
+	if h.replayPayment(c, actor.userID, key) {
+		return
+	}
+
+	order, err := h.serviceOrderRepo.FindByUUID(c.Param("uuid"))
+	if err != nil {
+		if errors.Is(err, repository.ErrServiceOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service order not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service order"})
+		return
+	}
+	if order.UserID != actor.userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Service order not found"})
+		return
+	}
+
+	payment := &models.Payment{
+		UserID:         actor.userID,
+		ServiceOrderID: order.ID,
+		Provider:       h.provider.Name(),
+		IdempotencyKey: key,
+		Amount:         order.Price,
+		Currency:       order.Currency,
+	}
+	if err := h.paymentRepo.Create(payment); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDuplicateIdempotencyKey):
+			// A concurrent request with the same key won the race
+			if !h.replayPayment(c, actor.userID, key) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create payment"})
+			}
+		case errors.Is(err, repository.ErrServiceOrderNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": "Service order is not pending"})
+		case errors.Is(err, repository.ErrServiceOrderPaymentInProgress):
+			c.JSON(http.StatusConflict, gin.H{"error": "Service order already has a payment in progress"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create payment"})
+		}
+		return
+	}
+
+	intent, err := h.provider.CreateIntent(c.Request.Context(), payments.IntentRequest{
+		Amount:         payments.ToMinorUnits(payment.Amount),
+		Currency:       payment.Currency,
+		Reference:      payment.UUID,
+		IdempotencyKey: payment.UUID,
+	})
+	if err != nil {
+		log.Printf("Payment %s: failed to create intent: %v", payment.UUID, err)
+		h.failPayment(payment)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Payment provider error"})
+		return
+	}
+	if err := h.paymentRepo.SetProviderIntent(payment.ID, intent.ID); err != nil {
+		// Without its intent the payment can never settle, and a pending one blocks the service order
+		log.Printf("Payment %s: failed to store intent %s: %v", payment.UUID, intent.ID, err)
+		h.failPayment(payment)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create payment"})
+		return
+	}
+
+	h.respondPayment(c, http.StatusCreated, payment.UUID, "Payment created successfully")
+}
+
+// failPayment marks a payment that could not be set up with the provider as failed, releasing its service order
+func (h *PaymentHandler) failPayment(payment *models.Payment) {
+	if _, err := h.paymentRepo.Transition(payment.ID, repository.PaymentTransition{
+		Status:        payments.StatusFailed,
+		Source:        models.PaymentSourceSystem,
+		FailureReason: "provider_error",
+	}); err != nil {
+		log.Printf("Payment %s: failed to record failure: %v", payment.UUID, err)
+	}
+}
+
+// replayPayment responds with the payment a user already created with an Idempotency-Key, if any
+func (h *PaymentHandler) replayPayment(c *gin.Context, userID uint64, key string) bool {
+	payment, err := h.paymentRepo.FindByIdempotencyKey(userID, key)
+	if errors.Is(err, repository.ErrPaymentNotFound) {
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payment"})
+		return true
+	}
+	if payment.ServiceOrderUUID != c.Param("uuid") {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used for another service order"})
+		return true
+	}
+
+	h.respondPayment(c, http.StatusOK, payment.UUID, "Payment already created")
+	return true
+}
+
+// GetPayment godoc
+// @Summary Get a payment
+// @Description Get a payment of the user, with its status changes
+// @Tags Payments
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Payment UUID"
+// @Success 200 {object} models.Payment "Payment"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Payment not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/payments/{uuid} [get]
+func (h *PaymentHandler) GetPayment(c *gin.Context) {
+	payment, ok := h.ownPayment(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.paymentRepo.GetEvents(payment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payment"})
+		return
+	}
+	payment.Events = events
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// ConfirmPayment godoc
+// @Summary Confirm a payment
+// @Description Charge a pending payment with a payment method. The mock provider accepts mock_success,
+// @Description mock_failure and mock_delayed (processing until a webhook reports success).
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Payment UUID"
+// @Param request body ConfirmPaymentRequest true "Payment method"
+// @Success 200 {object} map[string]interface{} "Payment confirmed"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Payment not found"
+// @Failure 409 {object} map[string]string "Payment is not pending"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 503 {object} map[string]string "Payments are not available"
+// @Router /api/user/payments/{uuid}/confirm [post]
+func (h *PaymentHandler) ConfirmPayment(c *gin.Context) {
+	var req ConfirmPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	payment, ok := h.ownPayment(c)
+	if !ok {
+		return
+	}
+	if payment.Status != payments.StatusPending || payment.ProviderIntentID == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Payment is not pending"})
+		return
+	}
+	if !h.sameProvider(c, payment) {
+		return
+	}
+
+	intent, err := h.provider.Confirm(c.Request.Context(), *payment.ProviderIntentID, req.PaymentMethod)
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidState) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Payment is not pending"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payment could not be confirmed: " + err.Error()})
+		return
+	}
+
+	if !h.applyIntent(c, payment, intent, models.PaymentSourceUser) {
+		return
+	}
+	h.respondPayment(c, http.StatusOK, payment.UUID, "Payment confirmed")
+}
+
+// RefundPayment godoc
+// @Summary Refund a payment (Admin only)
+// @Description Refund a succeeded payment in full. The promotion of the service order keeps running.
+// @Tags Admin - Services
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Payment UUID"
+// @Success 200 {object} map[string]interface{} "Payment refunded"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]string "Payment not found"
+// @Failure 409 {object} map[string]string "Payment has not succeeded"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 502 {object} map[string]string "Payment provider error"
+// @Failure 503 {object} map[string]string "Payments are not available"
+// @Router /api/admin/payments/{uuid}/refund [post]
+func (h *PaymentHandler) RefundPayment(c *gin.Context) {
+	payment, err := h.paymentRepo.FindByUUID(c.Param("uuid"))
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payment"})
+		return
+	}
+	if payment.Status != payments.StatusSucceeded || payment.ProviderIntentID == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Only succeeded payments can be refunded"})
+		return
+	}
+	if !h.sameProvider(c, payment) {
+		return
+	}
+
+	intent, err := h.provider.Refund(c.Request.Context(), *payment.ProviderIntentID)
+	if err != nil {
+		log.Printf("Payment %s: failed to refund: %v", payment.UUID, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Payment provider error"})
+		return
+	}
+
+	if !h.applyIntent(c, payment, intent, models.PaymentSourceAdmin) {
+		return
+	}
+	h.respondPayment(c, http.StatusOK, payment.UUID, "Payment refunded")
+}
+
+// HandleWebhook godoc
+// @Summary Payment provider webhook
+// @Description Receive a signed payment status notification. Redelivered events are applied once.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Payment provider" Enums(mock)
+// @Success 200 {object} map[string]interface{} "Event received"
+// @Failure 400 {object} map[string]string "Invalid payload"
+// @Failure 401 {object} map[string]string "Invalid signature"
+// @Failure 404 {object} map[string]string "Unknown provider or payment"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 503 {object} map[string]string "Payments are not available"
+// @Router /api/payments/webhooks/{provider} [post]
+func (h *PaymentHandler) HandleWebhook(c *gin.Context) {
+	if c.Param("provider") != h.provider.Name() {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown payment provider"})
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+	event, err := h.provider.VerifyWebhook(payload, c.Request.Header)
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid signature"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payload"})
+		return
+	}
+
+	payment, err := h.paymentRepo.FindByProviderIntent(h.provider.Name(), event.IntentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payment"})
+		return
+	}
+
+	applied, err := h.paymentRepo.Transition(payment.ID, repository.PaymentTransition{
+		Status:          event.Status,
+		Source:          models.PaymentSourceWebhook,
+		ProviderEventID: event.ID,
+		FailureReason:   event.FailureReason,
+	})
+	if errors.Is(err, repository.ErrPaymentOrderNotPending) {
+		h.refundUnfulfilled(c.Request.Context(), payment)
+	} else if errors.Is(err, repository.ErrInvalidPaymentTransition) {
+		// Acknowledge out of order events so the provider stops redelivering them
+		log.Printf("Payment %s: ignored webhook event %s (%s -> %s)", payment.UUID, event.ID, payment.Status, event.Status)
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update payment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true, "applied": applied})
+}
+
+// ownPayment loads the payment in the path, which must belong to the user unless they are an admin
+func (h *PaymentHandler) ownPayment(c *gin.Context) (*models.Payment, bool) {
+	actor := newVehicleActor(c)
+	payment, err := h.paymentRepo.FindByUUID(c.Param("uuid"))
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payment"})
+		return nil, false
+	}
+	if payment.UserID != actor.userID && actor.roleID != middleware.AdminRoleID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+		return nil, false
+	}
+	return payment, true
+}
+
+// sameProvider checks that a payment was made through the configured provider
+func (h *PaymentHandler) sameProvider(c *gin.Context, payment *models.Payment) bool {
+	if payment.Provider != h.provider.Name() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Payment was made through the " + payment.Provider + " provider, which is not configured"})
+		return false
+	}
+	return true
+}
+
+// applyIntent records the status the provider reported for a payment. A webhook may have recorded it first.
+func (h *PaymentHandler) applyIntent(c *gin.Context, payment *models.Payment, intent *payments.Intent, source string) bool {
+	_, err := h.paymentRepo.Transition(payment.ID, repository.PaymentTransition{
+		Status:        intent.Status,
+		Source:        source,
+		FailureReason: intent.FailureReason,
+	})
+	if errors.Is(err, repository.ErrPaymentOrderNotPending) {
+		h.refundUnfulfilled(c.Request.Context(), payment)
+		return true
+	}
+	if err != nil && !errors.Is(err, repository.ErrInvalidPaymentTransition) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update payment"})
+		return false
+	}
+	return true
+}
+
+// refundUnfulfilled refunds a payment that succeeded after its service order stopped being pending, such as
+// a delayed payment of a cancelled order, since the promotion it paid for will not run
+func (h *PaymentHandler) refundUnfulfilled(ctx context.Context, payment *models.Payment) {
+	log.Printf("Payment %s: succeeded for service order %s which is no longer pending, refunding", payment.UUID, payment.ServiceOrderUUID)
+	if payment.ProviderIntentID == nil {
+		log.Printf("Payment %s: cannot refund without a provider intent", payment.UUID)
+		return
+	}
+
+	intent, err := h.provider.Refund(ctx, *payment.ProviderIntentID)
+	if err != nil {
+		log.Printf("Payment %s: failed to refund: %v", payment.UUID, err)
+		return
+	}
+	_, err = h.paymentRepo.Transition(payment.ID, repository.PaymentTransition{
+		Status:        intent.Status,
+		Source:        models.PaymentSourceSystem,
+		FailureReason: "service_order_not_pending",
+	})
+	if err != nil {
+		log.Printf("Payment %s: failed to record refund: %v", payment.UUID, err)
+	}
+}
+
+// respondPayment responds with the current state of a payment
+func (h *PaymentHandler) respondPayment(c *gin.Context, status int, paymentUUID, message string) {
+	payment, err := h.paymentRepo.FindByUUID(paymentUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch payment"})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"message": message,
+		"payment": payment,
+	})
+}
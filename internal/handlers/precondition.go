@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"autoelys_backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// versionETag returns the strong ETag of a version of a resource
+func versionETag(version uint64) string {
+	return `"` + strconv.FormatUint(version, 10) + `"`
+}
+
+// setVersionETag sends the version of the resource in the response as its ETag
+func setVersionETag(c *gin.Context, version uint64) {
+	c.Header("ETag", versionETag(version))
+}
+
+// versionPrecondition is a write rejected because it was not made against the current version of a resource
+type versionPrecondition struct {
+	status  int
+	message string
+	current uint64
+}
+
+// checkIfMatch requires writes to send the ETag of the version they were made against in If-Match.
+// It returns nil when that is the current version of the resource, 428 Precondition Required without
+// If-Match and 412 Precondition Failed when another write changed the resource since the client read it.
+func checkIfMatch(c *gin.Context, resource string, current uint64) *versionPrecondition {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		return &versionPrecondition{
+			status:  http.StatusPreconditionRequired,
+			message: "If-Match header is required: send the ETag of the " + resource + " being updated",
+			current: current,
+		}
+	}
+
+	etag := versionETag(current)
+	for _, candidate := range strings.Split(header, ",") {
+		if candidate = strings.TrimSpace(candidate); candidate == "*" || candidate == etag {
+			return nil
+		}
+	}
+	return staleVersion(resource, current)
+}
+
+// versionConflict returns the rejection of a write that lost the race with another write, nil for other errors
+func versionConflict(resource string, err error) *versionPrecondition {
+	var conflict *repository.VersionConflictError
+	if !errors.As(err, &conflict) {
+		return nil
+	}
+	return staleVersion(resource, conflict.Current)
+}
+
+func staleVersion(resource string, current uint64) *versionPrecondition {
+	return &versionPrecondition{
+		status:  http.StatusPreconditionFailed,
+		message: "The " + resource + " was modified since it was read; the current version is " + strconv.FormatUint(current, 10),
+		current: current,
+	}
+}
+
+// respond sends the rejection with the current version and its ETag; body holds the error in the style of the handler
+func (p *versionPrecondition) respond(c *gin.Context, body gin.H) {
+	setVersionETag(c, p.current)
+	body["current_version"] = p.current
+	body["current_etag"] = versionETag(p.current)
+	c.JSON(p.status, body)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -30,7 +31,9 @@ type ServiceData struct {
 	Price           float64 `json:"price" example:"150.00"`
 	Currency        string  `json:"currency" example:"lei"`
 	DurationMinutes *uint   `json:"duration_minutes,omitempty" example:"30"`
+	Promotion       *string `json:"promotion,omitempty" example:"top"`
 	Active          bool    `json:"active" example:"true"`
+	Version         uint64  `json:"version" example:"3"`
 	CreatedAt       string  `json:"created_at" example:"2024-01-15T10:30:00Z"`
 	UpdatedAt       string  `json:"updated_at" example:"2024-01-15T10:30:00Z"`
 }
@@ -48,8 +51,9 @@ type CreateServiceRequest struct {
 	Title           string  `json:"title" binding:"required" example:"Oil Change"`
 	Description     *string `json:"description" example:"Full synthetic oil change service"`
 	Price           float64 `json:"price" binding:"required" example:"150.00"`
-	Currency        string  `json:"currency" example:"lei"`
+	Currency        string  `json:"currency" example:"lei" enums:"lei,euro,usd"`
 	DurationMinutes *uint   `json:"duration_minutes" example:"30"`
+	Promotion       *string `json:"promotion" example:"top" enums:"top,highlight"`
 	Active          *bool   `json:"active" example:"true"`
 }
 
@@ -59,8 +63,9 @@ type UpdateServiceRequest struct {
 	Title           string  `json:"title" example:"Oil Change"`
 	Description     *string `json:"description" example:"Full synthetic oil change service"`
 	Price           float64 `json:"price" example:"150.00"`
-	Currency        string  `json:"currency" example:"lei"`
+	Currency        string  `json:"currency" example:"lei" enums:"lei,euro,usd"`
 	DurationMinutes *uint   `json:"duration_minutes" example:"30"`
+	Promotion       *string `json:"promotion" example:"top" enums:"top,highlight"`
 	Active          *bool   `json:"active" example:"true"`
 }
 
@@ -73,7 +78,9 @@ func serviceToData(service *models.Service) ServiceData {
 		Price:           service.Price,
 		Currency:        service.Currency,
 		DurationMinutes: service.DurationMinutes,
+		Promotion:       service.Promotion,
 		Active:          service.Active,
+		Version:         service.Version,
 		CreatedAt:       service.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:       service.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
@@ -157,9 +164,19 @@ func (h *ServiceHandler) CreateService(c *gin.Context) {
 		return
 	}
 
-	currency := req.Currency
+	currency := models.NormalizeCurrency(req.Currency)
 	if currency == "" {
-		currency = "lei"
+		currency = models.BaseCurrency
+	}
+	if !models.IsSupportedCurrency(currency) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": unsupportedCurrencyMessage()})
+		return
+	}
+
+	promotion, ok := normalizePromotion(req.Promotion)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": invalidPromotionMessage()})
+		return
 	}
 
 	active := true
@@ -173,9 +190,15 @@ func (h *ServiceHandler) CreateService(c *gin.Context) {
 		Price:           req.Price,
 		Currency:        currency,
 		DurationMinutes: req.DurationMinutes,
+		Promotion:       promotion,
 		Active:          active,
 	}
 
+	if !promotionHasDuration(service) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Promotion services require a duration"})
+		return
+	}
+
 	if err := h.serviceRepo.Create(service); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create service"})
 		return
@@ -195,6 +218,7 @@ func (h *ServiceHandler) CreateService(c *gin.Context) {
 // @Security BearerAuth
 // @Param uuid path string true "Service UUID"
 // @Success 200 {object} ServiceData "Service details"
+// @Header 200 {string} ETag "Version of the service, to send as If-Match when updating it"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 403 {object} map[string]string "Forbidden - Admin access required"
 // @Failure 404 {object} map[string]string "Service not found"
@@ -217,23 +241,28 @@ func (h *ServiceHandler) GetService(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, service.Version)
 	c.JSON(http.StatusOK, serviceToData(service))
 }
 
 // UpdateService godoc
 // @Summary Update a service (Admin only)
-// @Description Update service information by UUID
+// @Description Update service information by UUID. Requires the ETag of the version being updated in If-Match; an update of a service modified since that version is rejected with 412.
 // @Tags Admin - Services
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param uuid path string true "Service UUID"
+// @Param If-Match header string true "ETag of the service version being updated"
 // @Param request body UpdateServiceRequest true "Service update details"
 // @Success 200 {object} map[string]interface{} "Service updated successfully"
+// @Header 200 {string} ETag "New version of the service"
 // @Failure 400 {object} map[string]string "Bad request"
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 403 {object} map[string]string "Forbidden - Admin access required"
 // @Failure 404 {object} map[string]string "Service not found"
+// @Failure 412 {object} map[string]interface{} "Service modified since it was read, with current_version and current_etag"
+// @Failure 428 {object} map[string]interface{} "If-Match header missing"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/admin/services/{uuid} [put]
 func (h *ServiceHandler) UpdateService(c *gin.Context) {
@@ -253,6 +282,11 @@ func (h *ServiceHandler) UpdateService(c *gin.Context) {
 		return
 	}
 
+	if precondition := checkIfMatch(c, "service", service.Version); precondition != nil {
+		precondition.respond(c, gin.H{"error": precondition.message})
+		return
+	}
+
 	var req UpdateServiceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
@@ -269,20 +303,46 @@ func (h *ServiceHandler) UpdateService(c *gin.Context) {
 		service.Price = req.Price
 	}
 	if req.Currency != "" {
-		service.Currency = req.Currency
+		if !models.IsSupportedCurrency(req.Currency) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": unsupportedCurrencyMessage()})
+			return
+		}
+		service.Currency = models.NormalizeCurrency(req.Currency)
 	}
 	if req.DurationMinutes != nil {
 		service.DurationMinutes = req.DurationMinutes
 	}
+	if req.Promotion != nil {
+		promotion, ok := normalizePromotion(req.Promotion)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": invalidPromotionMessage()})
+			return
+		}
+		service.Promotion = promotion
+	}
 	if req.Active != nil {
 		service.Active = *req.Active
 	}
 
+	if !promotionHasDuration(service) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Promotion services require a duration"})
+		return
+	}
+
 	if err := h.serviceRepo.Update(service); err != nil {
+		if precondition := versionConflict("service", err); precondition != nil {
+			precondition.respond(c, gin.H{"error": precondition.message})
+			return
+		}
+		if errors.Is(err, repository.ErrServiceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service"})
 		return
 	}
 
+	setVersionETag(c, service.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Service updated successfully",
 		"service": serviceToData(service),
@@ -301,6 +361,7 @@ func (h *ServiceHandler) UpdateService(c *gin.Context) {
 // @Failure 401 {object} map[string]string "Unauthorized"
 // @Failure 403 {object} map[string]string "Forbidden - Admin access required"
 // @Failure 404 {object} map[string]string "Service not found"
+// @Failure 409 {object} map[string]string "Service has been ordered or booked"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/admin/services/{uuid} [delete]
 func (h *ServiceHandler) DeleteService(c *gin.Context) {
@@ -321,6 +382,10 @@ func (h *ServiceHandler) DeleteService(c *gin.Context) {
 	}
 
 	if err := h.serviceRepo.Delete(service.ID); err != nil {
+		if errors.Is(err, repository.ErrServiceInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Service has been ordered or booked, deactivate it instead"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete service"})
 		return
 	}
@@ -336,6 +401,7 @@ func (h *ServiceHandler) DeleteService(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20) maximum(100)
 // @Success 200 {object} GetAllServicesResponse "List of services"
+// @Success 304 {string} string "Not modified (If-None-Match matched)"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/services [get]
 func (h *ServiceHandler) GetPublicServices(c *gin.Context) {
@@ -381,3 +447,31 @@ func (h *ServiceHandler) GetPublicServices(c *gin.Context) {
 		},
 	})
 }
+
+func unsupportedCurrencyMessage() string {
+	return "Unsupported currency, must be one of: " + strings.Join(models.SupportedCurrencies, ", ")
+}
+
+// normalizePromotion validates an optional promotion; an empty value means the service grants none
+func normalizePromotion(promotion *string) (*string, bool) {
+	if promotion == nil {
+		return nil, true
+	}
+	value := strings.ToLower(strings.TrimSpace(*promotion))
+	if value == "" {
+		return nil, true
+	}
+	if !models.IsValidPromotion(value) {
+		return nil, false
+	}
+	return &value, true
+}
+
+func invalidPromotionMessage() string {
+	return "Invalid promotion, must be one of: " + strings.Join(models.Promotions, ", ")
+}
+
+// promotionHasDuration reports whether a promotion service defines how long the promotion runs
+func promotionHasDuration(service *models.Service) bool {
+	return service.Promotion == nil || (service.DurationMinutes != nil && *service.DurationMinutes > 0)
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceOrderHandler struct {
+	serviceOrderRepo *repository.ServiceOrderRepository
+	serviceRepo      *repository.ServiceRepository
+	vehicleRepo      *repository.VehicleRepository
+	organizationRepo *repository.OrganizationRepository
+}
+
+func NewServiceOrderHandler(serviceOrderRepo *repository.ServiceOrderRepository, serviceRepo *repository.ServiceRepository, vehicleRepo *repository.VehicleRepository, organizationRepo *repository.OrganizationRepository) *ServiceOrderHandler {
+	return &ServiceOrderHandler{
+		serviceOrderRepo: serviceOrderRepo,
+		serviceRepo:      serviceRepo,
+		vehicleRepo:      vehicleRepo,
+		organizationRepo: organizationRepo,
+	}
+}
+
+// OrderPromotionRequest represents the order promotion payload
+// @Description Order promotion request payload
+type OrderPromotionRequest struct {
+	ServiceUUID string `json:"service_uuid" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// OrderPromotion godoc
+// @Summary Order a promotion for a vehicle
+// @Description Order an active promotion service (top listing or homepage highlight) for an active vehicle the user can edit.
+// @Description The order is pending until it is activated, which extends the vehicle's promotion window by the service duration.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Vehicle UUID"
+// @Param request body OrderPromotionRequest true "Promotion service"
+// @Success 201 {object} map[string]interface{} "Service order created"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Vehicle or service not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/vehicles/{uuid}/promotions [post]
+func (h *ServiceOrderHandler) OrderPromotion(c *gin.Context) {
+	var req OrderPromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	actor := newVehicleActor(c)
+	vehicle, err := h.vehicleRepo.GetByUUID(c.Param("uuid"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vehicle"})
+		return
+	}
+	if vehicle == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Vehicle not found"})
+		return
+	}
+	_, canEdit, err := vehicleAccess(h.organizationRepo, vehicle, actor.userID, actor.roleID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+		return
+	}
+	if !canEdit {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to promote this vehicle"})
+		return
+	}
+	if vehicle.Status != models.VehicleStatusActive {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Only active vehicles can be promoted"})
+		return
+	}
+
+	service, err := h.serviceRepo.FindByUUID(req.ServiceUUID)
+	if err != nil {
+		if errors.Is(err, repository.ErrServiceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service"})
+		return
+	}
+	if !service.Active || service.Promotion == nil || !promotionHasDuration(service) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Service is not an available promotion"})
+		return
+	}
+
+	order := &models.ServiceOrder{
+		UserID:          actor.userID,
+		VehicleID:       vehicle.ID,
+		ServiceID:       service.ID,
+		Promotion:       *service.Promotion,
+		Price:           service.Price,
+		Currency:        service.Currency,
+		DurationMinutes: *service.DurationMinutes,
+	}
+	if err := h.serviceOrderRepo.Create(order); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create service order"})
+		return
+	}
+
+	created, err := h.serviceOrderRepo.FindByUUID(order.UUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service order"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Service order created successfully",
+		"order":   created,
+	})
+}
+
+// GetUserServiceOrders godoc
+// @Summary Get the user's service orders
+// @Description Get the promotion orders placed by the authenticated user, newest first
+// @Tags Promotions
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Service orders"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/service-orders [get]
+func (h *ServiceOrderHandler) GetUserServiceOrders(c *gin.Context) {
+	actor := newVehicleActor(c)
+	page, limit := parsePagination(c)
+
+	orders, total, err := h.serviceOrderRepo.GetByUserID(actor.userID, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service orders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": orders,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// GetAllServiceOrders godoc
+// @Summary Get all service orders (Admin only)
+// @Description Get a paginated list of promotion orders, newest first
+// @Tags Admin - Services
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Order status" Enums(pending, active, expired, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Service orders"
+// @Failure 400 {object} map[string]string "Invalid status"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/service-orders [get]
+func (h *ServiceOrderHandler) GetAllServiceOrders(c *gin.Context) {
+	status := strings.ToLower(c.Query("status"))
+	switch status {
+	case "", models.ServiceOrderStatusPending, models.ServiceOrderStatusActive, models.ServiceOrderStatusExpired, models.ServiceOrderStatusCancelled:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status, must be one of: pending, active, expired, cancelled"})
+		return
+	}
+
+	page, limit := parsePagination(c)
+	orders, total, err := h.serviceOrderRepo.GetAll(status, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service orders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": orders,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// ActivateServiceOrder godoc
+// @Summary Activate a service order (Admin only)
+// @Description Activate a pending order, extending the vehicle's promotion window by the ordered duration
+// @Tags Admin - Services
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Service order UUID"
+// @Success 200 {object} map[string]interface{} "Service order activated"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]string "Service order not found"
+// @Failure 409 {object} map[string]string "Service order is not pending"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/service-orders/{uuid}/activate [post]
+func (h *ServiceOrderHandler) ActivateServiceOrder(c *gin.Context) {
+	h.transition(c, h.serviceOrderRepo.Activate, "Service order activated successfully")
+}
+
+// CancelServiceOrder godoc
+// @Summary Cancel a service order (Admin only)
+// @Description Cancel a pending order that has no pending, processing or succeeded payment. A payment succeeding after all for a cancelled order is refunded automatically.
+// @Tags Admin - Services
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Service order UUID"
+// @Success 200 {object} map[string]interface{} "Service order cancelled"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden - Admin access required"
+// @Failure 404 {object} map[string]string "Service order not found"
+// @Failure 409 {object} map[string]string "Service order is not pending or has a payment in progress"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/admin/service-orders/{uuid}/cancel [post]
+func (h *ServiceOrderHandler) CancelServiceOrder(c *gin.Context) {
+	h.transition(c, h.serviceOrderRepo.Cancel, "Service order cancelled successfully")
+}
+
+// transition applies a status change to the order in the path and responds with the updated order
+func (h *ServiceOrderHandler) transition(c *gin.Context, apply func(id uint64) error, message string) {
+	order, err := h.serviceOrderRepo.FindByUUID(c.Param("uuid"))
+	if err != nil {
+		if errors.Is(err, repository.ErrServiceOrderNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Service order not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service order"})
+		return
+	}
+
+	if err := apply(order.ID); err != nil {
+		if errors.Is(err, repository.ErrServiceOrderNotPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Service order is not pending"})
+			return
+		}
+		if errors.Is(err, repository.ErrServiceOrderPaymentInProgress) {
+			c.JSON(http.StatusConflict, gin.H{"error": "Service order has a payment in progress"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update service order"})
+		return
+	}
+
+	updated, err := h.serviceOrderRepo.FindByUUID(order.UUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch service order"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"order":   updated,
+	})
+}
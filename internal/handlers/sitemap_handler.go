@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"autoelys_backend/internal/sitemap"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SitemapHandler struct {
+	generator *sitemap.Generator
+	baseURL   string
+	ttl       time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedSitemap
+}
+
+// cachedSitemap is a rendered sitemap kept until expiresAt
+type cachedSitemap struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+// NewSitemapHandler creates a sitemap handler. The index links the sitemap files under baseURL, and rendered
+// sitemaps are cached for ttl (0 generates them on every request).
+func NewSitemapHandler(generator *sitemap.Generator, baseURL string, ttl time.Duration) *SitemapHandler {
+	return &SitemapHandler{
+		generator: generator,
+		baseURL:   baseURL,
+		ttl:       ttl,
+		cache:     make(map[string]cachedSitemap),
+	}
+}
+
+// GetIndex godoc
+// @Summary Get the sitemap index (Public)
+// @Description Sitemap index listing the sitemap files of active vehicle pages, brand pages and model pages. Each file holds at most 50,000 URLs.
+// @Tags sitemaps
+// @Produce xml
+// @Success 200 {string} string "Sitemap index"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sitemap.xml [get]
+func (h *SitemapHandler) GetIndex(c *gin.Context) {
+	h.serve(c, sitemap.IndexFile, func() ([]byte, error) {
+		files, err := h.generator.Files()
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = sitemap.WriteIndex(&buf, h.baseURL, files)
+		return buf.Bytes(), err
+	})
+}
+
+// GetSitemap godoc
+// @Summary Get a sitemap file (Public)
+// @Description Sitemap file of a section as listed in the sitemap index: vehicles-N.xml with the active listings and their last update, brands-N.xml and models-N.xml with the catalog brand and model pages.
+// @Tags sitemaps
+// @Produce xml
+// @Param file path string true "Sitemap file name (e.g. vehicles-1.xml)"
+// @Success 200 {string} string "Sitemap"
+// @Failure 404 {object} map[string]string "Sitemap not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sitemaps/{file} [get]
+func (h *SitemapHandler) GetSitemap(c *gin.Context) {
+	name := c.Param("file")
+	h.serve(c, name, func() ([]byte, error) {
+		urls, err := h.generator.URLs(name)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = sitemap.WriteURLSet(&buf, urls)
+		return buf.Bytes(), err
+	})
+}
+
+// serve responds with the cached sitemap of the key, rendering it when missing or expired
+func (h *SitemapHandler) serve(c *gin.Context, key string, render func() ([]byte, error)) {
+	body, ok := h.cached(key)
+	if !ok {
+		var err error
+		body, err = render()
+		if errors.Is(err, sitemap.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Sitemap not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate sitemap"})
+			return
+		}
+		h.store(key, body)
+	}
+
+	if h.ttl > 0 {
+		c.Header("Cache-Control", "public, max-age="+strconv.Itoa(int(h.ttl.Seconds())))
+	}
+	c.Data(http.StatusOK, sitemap.ContentType, body)
+}
+
+func (h *SitemapHandler) cached(key string) ([]byte, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry, ok := h.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.body, true
+}
+
+func (h *SitemapHandler) store(key string, body []byte) {
+	if h.ttl <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Drop expired entries so requests for many file names do not grow the cache
+	now := time.Now()
+	for k, entry := range h.cache {
+		if now.After(entry.expiresAt) {
+			delete(h.cache, k)
+		}
+	}
+	h.cache[key] = cachedSitemap{body: body, expiresAt: now.Add(h.ttl)}
+}
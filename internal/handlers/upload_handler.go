@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/uploads"
+	"autoelys_backend/internal/utils"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxOpenUploads caps the unclaimed uploads a user can have at once
+	maxOpenUploads = 50
+	// uploadOffsetHeader carries the offset a chunk starts at, and the bytes received in responses
+	uploadOffsetHeader = "Upload-Offset"
+)
+
+type UploadHandler struct {
+	uploadRepo *repository.UploadRepository
+	store      *uploads.Store
+	ttl        time.Duration
+}
+
+// NewUploadHandler creates the upload handler; uploads not claimed by a vehicle within ttl expire
+func NewUploadHandler(uploadRepo *repository.UploadRepository, store *uploads.Store, ttl time.Duration) *UploadHandler {
+	return &UploadHandler{
+		uploadRepo: uploadRepo,
+		store:      store,
+		ttl:        ttl,
+	}
+}
+
+// CreateUploadRequest represents the upload slot payload
+// @Description Upload slot payload
+type CreateUploadRequest struct {
+	Filename string `json:"filename" binding:"required,max=255" example:"front.jpg"`
+	Size     int64  `json:"size" binding:"required,gt=0" example:"2483712"`
+}
+
+// CreateUpload godoc
+// @Summary Request an image upload slot
+// @Description Open an upload for a vehicle image of the given size (at most 10MB, jpg, jpeg, png or webp). Send the bytes with PATCH /api/user/uploads/{uuid}, in one or more chunks,
+// @Description then reference the upload UUID in upload_ids when creating or updating a vehicle. Uploads that are not claimed by a vehicle expire (24 hours by default).
+// @Tags Uploads
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateUploadRequest true "Image file name and size in bytes"
+// @Success 201 {object} map[string]interface{} "Upload created"
+// @Header 201 {string} Upload-Offset "Bytes received so far (0)"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 429 {object} map[string]string "Too many open uploads"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/uploads [post]
+func (h *UploadHandler) CreateUpload(c *gin.Context) {
+	var req CreateUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload, filename and size are required"})
+		return
+	}
+	if !utils.IsImageFilename(req.Filename) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file extension. Allowed: jpg, jpeg, png, webp"})
+		return
+	}
+	if req.Size > utils.MaxFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File exceeds maximum size of 10MB"})
+		return
+	}
+
+	userID := newVehicleActor(c).userID
+	open, err := h.uploadRepo.CountOpen(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload"})
+		return
+	}
+	if open >= maxOpenUploads {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many open uploads, use or delete some before starting new ones"})
+		return
+	}
+
+	upload := &models.Upload{
+		UserID:   userID,
+		Filename: req.Filename,
+		Size:     req.Size,
+	}
+	if err := h.uploadRepo.Create(upload, h.ttl); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload"})
+		return
+	}
+
+	c.Header(uploadOffsetHeader, "0")
+	c.JSON(http.StatusCreated, uploadResponse(upload))
+}
+
+// GetUpload godoc
+// @Summary Get an upload
+// @Description Get the status and progress of an upload. The offset is where an interrupted upload resumes.
+// @Tags Uploads
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Upload UUID"
+// @Success 200 {object} map[string]interface{} "Upload"
+// @Header 200 {string} Upload-Offset "Bytes received so far"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Upload not found or expired"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/uploads/{uuid} [get]
+func (h *UploadHandler) GetUpload(c *gin.Context) {
+	upload, ok := h.load(c)
+	if !ok {
+		return
+	}
+	c.Header(uploadOffsetHeader, strconv.FormatInt(upload.Received, 10))
+	c.JSON(http.StatusOK, uploadResponse(upload))
+}
+
+// UploadChunk godoc
+// @Summary Upload a chunk of an image
+// @Description Append the request body to an upload, starting at the offset in the Upload-Offset header, which must equal the bytes received so far.
+// @Description After an interruption, get the upload to find the offset to resume from. Once all bytes are received the image is checked and the upload is completed.
+// @Tags Uploads
+// @Accept application/offset+octet-stream
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Upload UUID"
+// @Param Upload-Offset header int true "Offset of the chunk, the bytes received so far"
+// @Param chunk body string true "Bytes of the image"
+// @Success 200 {object} map[string]interface{} "Chunk received, with the upload status and progress"
+// @Header 200 {string} Upload-Offset "Bytes received so far"
+// @Failure 400 {object} map[string]string "Missing Upload-Offset or file is not an image"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Upload not found or expired"
+// @Failure 409 {object} map[string]interface{} "Offset does not match the bytes received, or upload already completed"
+// @Failure 413 {object} map[string]interface{} "Chunk exceeds the size of the upload"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/uploads/{uuid} [patch]
+func (h *UploadHandler) UploadChunk(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.GetHeader(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Upload-Offset header must be the offset of the chunk"})
+		return
+	}
+
+	upload, ok := h.load(c)
+	if !ok {
+		return
+	}
+	if upload.Status == models.UploadStatusCompleted {
+		h.respondOffset(c, http.StatusConflict, upload, "Upload already completed")
+		return
+	}
+	if offset != upload.Received {
+		h.respondOffset(c, http.StatusConflict, upload, "Upload-Offset does not match the bytes received")
+		return
+	}
+
+	// The chunk is staged first and only appended to the part file by the request that moves the offset,
+	// so concurrent chunks sent at the same offset cannot mix their bytes
+	chunk, writeErr := h.store.Stage(upload.UUID, c.Request.Body, upload.Size-offset)
+	if chunk != nil {
+		defer func() {
+			if err := chunk.Remove(); err != nil {
+				log.Printf("uploads: failed to remove chunk of %s: %v", upload.UUID, err)
+			}
+		}()
+	}
+	if chunk != nil && chunk.Size > 0 {
+		err := h.uploadRepo.SetReceived(upload.ID, offset, offset+chunk.Size, func() error {
+			return h.store.Append(upload.UUID, offset, chunk)
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrUploadOffsetChanged) {
+				c.JSON(http.StatusConflict, gin.H{"error": "Another chunk was received at the same offset, get the upload to resume"})
+				return
+			}
+			log.Printf("uploads: failed to record chunk of %s: %v", upload.UUID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record chunk"})
+			return
+		}
+		upload.Received += chunk.Size
+	}
+	if writeErr != nil {
+		if errors.Is(writeErr, uploads.ErrChunkTooLarge) {
+			h.respondOffset(c, http.StatusRequestEntityTooLarge, upload, "Chunk exceeds the size of the upload")
+			return
+		}
+		log.Printf("uploads: failed to store chunk of %s: %v", upload.UUID, writeErr)
+		h.respondOffset(c, http.StatusInternalServerError, upload, "Failed to store chunk")
+		return
+	}
+
+	if upload.Received == upload.Size {
+		if !h.complete(c, upload) {
+			return
+		}
+	}
+
+	c.Header(uploadOffsetHeader, strconv.FormatInt(upload.Received, 10))
+	c.JSON(http.StatusOK, uploadResponse(upload))
+}
+
+// complete checks the received image and marks the upload completed, deleting it if it is not an image
+func (h *UploadHandler) complete(c *gin.Context, upload *models.Upload) bool {
+	imageURL, err := h.store.Finish(upload.UUID, upload.Size)
+	if errors.Is(err, uploads.ErrNotImage) {
+		_ = h.store.Remove(upload.UUID)
+		if _, err := h.uploadRepo.Delete(upload.ID); err != nil {
+			log.Printf("uploads: failed to delete rejected upload %s: %v", upload.UUID, err)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is not a jpeg, png or webp image, the upload was deleted"})
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save image"})
+		return false
+	}
+	if err := h.uploadRepo.Complete(upload.ID, imageURL); err != nil {
+		_ = utils.DeleteFile(imageURL)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete upload"})
+		return false
+	}
+
+	upload.Status = models.UploadStatusCompleted
+	upload.ImageURL = &imageURL
+	return true
+}
+
+// DeleteUpload godoc
+// @Summary Delete an upload
+// @Description Cancel an upload in progress or discard a completed upload that no vehicle claimed
+// @Tags Uploads
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Upload UUID"
+// @Success 200 {object} map[string]string "Upload deleted"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Upload not found or expired"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/uploads/{uuid} [delete]
+func (h *UploadHandler) DeleteUpload(c *gin.Context) {
+	upload, ok := h.load(c)
+	if !ok {
+		return
+	}
+	deleted, err := h.uploadRepo.Delete(upload.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete upload"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+		return
+	}
+	if err := h.store.Discard(upload); err != nil {
+		log.Printf("uploads: failed to delete files of %s: %v", upload.UUID, err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Upload deleted successfully"})
+}
+
+// load fetches the upload in the path, answering 404 unless it belongs to the user and has not expired
+func (h *UploadHandler) load(c *gin.Context) (*models.Upload, bool) {
+	upload, err := h.uploadRepo.FindByUUID(c.Param("uuid"))
+	if err != nil && !errors.Is(err, repository.ErrUploadNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch upload"})
+		return nil, false
+	}
+	if upload == nil || upload.UserID != newVehicleActor(c).userID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+		return nil, false
+	}
+	return upload, true
+}
+
+func (h *UploadHandler) respondOffset(c *gin.Context, status int, upload *models.Upload, message string) {
+	c.Header(uploadOffsetHeader, strconv.FormatInt(upload.Received, 10))
+	c.JSON(status, gin.H{
+		"error":  message,
+		"offset": upload.Received,
+	})
+}
+
+func uploadResponse(upload *models.Upload) gin.H {
+	return gin.H{
+		"upload":   upload,
+		"progress": upload.Received * 100 / upload.Size,
+	}
+}
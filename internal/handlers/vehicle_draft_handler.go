@@ -0,0 +1,729 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// draftSlug is the placeholder slug of a draft; the listing slug is generated from the title on publish
+func draftSlug(vehicleUUID string) string {
+	return "draft-" + vehicleUUID
+}
+
+// ownDraft loads the draft of the :uuid path parameter, which must belong to the authenticated user.
+// It responds and returns false otherwise.
+func (h *VehicleHandler) ownDraft(c *gin.Context) (*models.Vehicle, vehicleActor, bool) {
+	actor := newVehicleActor(c)
+
+	draft, err := h.vehicleRepo.GetByUUID(c.Param("uuid"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve draft",
+			"error":   err.Error(),
+		})
+		return nil, actor, false
+	}
+	if draft == nil || draft.Status != models.VehicleStatusDraft || draft.UserID != actor.userID {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Draft not found",
+		})
+		return nil, actor, false
+	}
+
+	return draft, actor, true
+}
+
+// bindDraftRequest binds and validates the fields of a draft. Only the values given are checked.
+func (h *VehicleHandler) bindDraftRequest(c *gin.Context) (*UpdateVehicleRequest, bool) {
+	var req UpdateVehicleRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request data",
+			"error":   err.Error(),
+		})
+		return nil, false
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Validation failed",
+			"errors":  utils.FormatValidationErrorsSimple(err.(validator.ValidationErrors)),
+		})
+		return nil, false
+	}
+	return &req, true
+}
+
+// uploadDraftImages saves the image files of the request for a draft, up to the image limit, and returns
+// their paths
+func (h *VehicleHandler) uploadDraftImages(c *gin.Context, draft *models.Vehicle) ([]string, *requestError) {
+	form, err := c.MultipartForm()
+	if err != nil || form == nil || len(form.File["images"]) == 0 {
+		return nil, nil
+	}
+
+	files := form.File["images"]
+	if len(draft.Images)+len(files) > utils.MaxImagesPerVehicle {
+		return nil, &requestError{status: http.StatusBadRequest, message: fmt.Sprintf("Maximum %d images allowed", utils.MaxImagesPerVehicle)}
+	}
+
+	imagePaths, err := utils.UploadVehicleImages(files, "./uploads/vehicles")
+	if err != nil {
+		deleteFiles(imagePaths)
+		return nil, &requestError{status: http.StatusBadRequest, message: "Failed to upload images", err: err}
+	}
+	return imagePaths, nil
+}
+
+// addDraftImagesTx attaches the uploaded images to a draft within tx. The first image becomes the featured
+// image of a draft without one.
+func (h *VehicleHandler) addDraftImagesTx(tx *repository.Tx, draft *models.Vehicle, imagePaths []string) error {
+	for _, imagePath := range imagePaths {
+		if err := h.vehicleRepo.CreateImageTx(tx, draft.ID, imagePath); err != nil {
+			return err
+		}
+	}
+	if len(imagePaths) > 0 && draft.FeaturedImage == nil {
+		return h.vehicleRepo.SetFeaturedImageTx(tx, draft.UUID, imagePaths[0])
+	}
+	return nil
+}
+
+// CreateDraft godoc
+// @Summary Create a draft listing
+// @Description Save an incomplete vehicle listing. All fields are optional, but the values given must be valid. Images can be sent now or uploaded later. Drafts are not listed until published and are deleted after a period without changes.
+// @Tags vehicle drafts
+// @Accept multipart/form-data
+// @Produce json
+// @Param title formData string false "Vehicle title"
+// @Param category formData string false "Vehicle category"
+// @Param description formData string false "Vehicle description"
+// @Param price formData number false "Price"
+// @Param currency formData string false "Currency (lei, euro, usd)"
+// @Param negotiable formData boolean false "Price negotiable"
+// @Param person_type formData string false "Person type (persoana_fizica, firma)"
+// @Param brand_id formData integer false "Catalog brand ID"
+// @Param brand formData string false "Brand name or slug from the catalog"
+// @Param automobile_id formData integer false "Catalog automobile ID"
+// @Param model formData string false "Model"
+// @Param vin formData string false "Vehicle identification number (17 characters)"
+// @Param engine_capacity formData integer false "Engine capacity in cm3"
+// @Param power_hp formData integer false "Power in HP"
+// @Param fuel_type formData string false "Fuel type"
+// @Param body_type formData string false "Body type"
+// @Param kilometers formData integer false "Kilometers"
+// @Param color formData string false "Color"
+// @Param year formData integer false "Year"
+// @Param number_of_keys formData integer false "Number of keys"
+// @Param condition formData string false "Condition (utilizat, nou)"
+// @Param transmission formData string false "Transmission (manuala, automata)"
+// @Param steering formData string false "Steering (stanga, dreapta)"
+// @Param registered formData boolean false "Registered"
+// @Param city formData string false "City"
+// @Param contact_name formData string false "Contact name"
+// @Param email formData string false "Email"
+// @Param phone formData string false "Phone"
+// @Param organization_uuid formData string false "UUID of a dealer organization the user is a member of, to list the vehicle for it"
+// @Param equipment formData []string false "Equipment names from GET /api/equipment (repeated or comma separated)" collectionFormat(multi)
+// @Param images formData file false "Vehicle images (max 8, jpeg/png/jpg)"
+// @Success 201 {object} map[string]interface{} "Draft created successfully"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Authentication required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts [post]
+// @Security BearerAuth
+func (h *VehicleHandler) CreateDraft(c *gin.Context) {
+	req, ok := h.bindDraftRequest(c)
+	if !ok {
+		return
+	}
+
+	actor := newVehicleActor(c)
+	draft := &models.Vehicle{
+		UserID: actor.userID,
+		Status: models.VehicleStatusDraft,
+		UUID:   uuid.New().String(),
+	}
+
+	equipment, reqErr := h.applyVehicleUpdate(draft, req.patch(), actor.userID)
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
+	}
+	draft.Slug = draftSlug(draft.UUID)
+
+	imagePaths, reqErr := h.uploadDraftImages(c, draft)
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
+	}
+
+	var savedDraft *models.Vehicle
+	err := h.transactor.WithTx(func(tx *repository.Tx) error {
+		tx.OnRollback(func() { deleteFiles(imagePaths) })
+
+		if _, err := h.vehicleRepo.CreateTx(tx, draft); err != nil {
+			return err
+		}
+		if err := h.addDraftImagesTx(tx, draft, imagePaths); err != nil {
+			return err
+		}
+		if len(equipment) > 0 {
+			if err := h.vehicleRepo.SetEquipmentTx(tx, draft.ID, equipment); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		savedDraft, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionCreate, nil, draft.UUID)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to create draft",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status":  "success",
+		"message": "Draft saved successfully",
+		"data":    savedDraft,
+	})
+}
+
+// GetDrafts godoc
+// @Summary Get the drafts of the authenticated user
+// @Description Retrieve the draft listings of the authenticated user
+// @Tags vehicle drafts
+// @Produce json
+// @Success 200 {object} map[string]interface{} "List of drafts"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts [get]
+// @Security BearerAuth
+func (h *VehicleHandler) GetDrafts(c *gin.Context) {
+	actor := newVehicleActor(c)
+
+	drafts, err := h.vehicleRepo.GetDraftsByUserID(actor.userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve drafts",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if drafts == nil {
+		drafts = []models.Vehicle{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   drafts,
+		"count":  len(drafts),
+	})
+}
+
+// GetDraft godoc
+// @Summary Get a draft
+// @Description Retrieve a draft listing of the authenticated user with its images and equipment
+// @Tags vehicle drafts
+// @Produce json
+// @Param uuid path string true "Draft UUID"
+// @Success 200 {object} map[string]interface{} "Draft details"
+// @Failure 404 {object} map[string]interface{} "Draft not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts/{uuid} [get]
+// @Security BearerAuth
+func (h *VehicleHandler) GetDraft(c *gin.Context) {
+	draft, _, ok := h.ownDraft(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   draft,
+	})
+}
+
+// UpdateDraft godoc
+// @Summary Update a draft
+// @Description Save more fields of a draft listing. Only the fields given are changed and checked. Images are added with POST /api/user/vehicles/drafts/{uuid}/images.
+// @Tags vehicle drafts
+// @Accept multipart/form-data
+// @Produce json
+// @Param uuid path string true "Draft UUID"
+// @Param title formData string false "Vehicle title"
+// @Param category formData string false "Vehicle category"
+// @Param description formData string false "Vehicle description"
+// @Param price formData number false "Price"
+// @Param currency formData string false "Currency (lei, euro, usd)"
+// @Param negotiable formData boolean false "Price negotiable"
+// @Param person_type formData string false "Person type (persoana_fizica, firma)"
+// @Param brand_id formData integer false "Catalog brand ID"
+// @Param brand formData string false "Brand name or slug from the catalog"
+// @Param automobile_id formData integer false "Catalog automobile ID"
+// @Param model formData string false "Model"
+// @Param vin formData string false "Vehicle identification number (17 characters)"
+// @Param engine_capacity formData integer false "Engine capacity in cm3"
+// @Param power_hp formData integer false "Power in HP"
+// @Param fuel_type formData string false "Fuel type"
+// @Param body_type formData string false "Body type"
+// @Param kilometers formData integer false "Kilometers"
+// @Param color formData string false "Color"
+// @Param year formData integer false "Year"
+// @Param number_of_keys formData integer false "Number of keys"
+// @Param condition formData string false "Condition (utilizat, nou)"
+// @Param transmission formData string false "Transmission (manuala, automata)"
+// @Param steering formData string false "Steering (stanga, dreapta)"
+// @Param registered formData boolean false "Registered"
+// @Param city formData string false "City"
+// @Param contact_name formData string false "Contact name"
+// @Param email formData string false "Email"
+// @Param phone formData string false "Phone"
+// @Param organization_uuid formData string false "UUID of a dealer organization the user is a member of"
+// @Param equipment formData []string false "Equipment names replacing the current equipment (send an empty value to remove all)" collectionFormat(multi)
+// @Success 200 {object} map[string]interface{} "Draft updated successfully"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 404 {object} map[string]interface{} "Draft not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts/{uuid} [put]
+// @Security BearerAuth
+func (h *VehicleHandler) UpdateDraft(c *gin.Context) {
+	draft, actor, ok := h.ownDraft(c)
+	if !ok {
+		return
+	}
+	before := vehicleAuditFields(draft)
+
+	req, ok := h.bindDraftRequest(c)
+	if !ok {
+		return
+	}
+
+	equipment, reqErr := h.applyVehicleUpdate(draft, req.patch(), actor.userID)
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
+	}
+	draft.Slug = draftSlug(draft.UUID)
+
+	var savedDraft *models.Vehicle
+	err := h.transactor.WithTx(func(tx *repository.Tx) error {
+		if err := h.vehicleRepo.UpdateTx(tx, draft.UUID, draft); err != nil {
+			return err
+		}
+		if equipment != nil {
+			if err := h.vehicleRepo.SetEquipmentTx(tx, draft.ID, equipment); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		savedDraft, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionUpdate, before, draft.UUID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{
+				"status":  "error",
+				"message": "The draft was modified by another request, try again",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to update draft",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Draft saved successfully",
+		"data":    savedDraft,
+	})
+}
+
+// DeleteDraft godoc
+// @Summary Delete a draft
+// @Description Delete a draft listing and its images
+// @Tags vehicle drafts
+// @Produce json
+// @Param uuid path string true "Draft UUID"
+// @Success 200 {object} map[string]interface{} "Draft deleted successfully"
+// @Failure 404 {object} map[string]interface{} "Draft not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts/{uuid} [delete]
+// @Security BearerAuth
+func (h *VehicleHandler) DeleteDraft(c *gin.Context) {
+	draft, _, ok := h.ownDraft(c)
+	if !ok {
+		return
+	}
+
+	imagePaths, deleted, err := h.vehicleRepo.DeleteDraft(draft.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to delete draft",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Draft not found",
+		})
+		return
+	}
+	for _, path := range imagePaths {
+		_ = utils.DeleteFile(path)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Draft deleted successfully",
+	})
+}
+
+// UploadDraftImages godoc
+// @Summary Add images to a draft
+// @Description Upload more images to a draft listing, up to 8 images in total. The first image of a draft without images becomes its featured image.
+// @Tags vehicle drafts
+// @Accept multipart/form-data
+// @Produce json
+// @Param uuid path string true "Draft UUID"
+// @Param images formData file true "Vehicle images (jpeg/png/jpg)"
+// @Success 200 {object} map[string]interface{} "Images uploaded successfully"
+// @Failure 400 {object} map[string]interface{} "No images or too many images"
+// @Failure 404 {object} map[string]interface{} "Draft not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts/{uuid}/images [post]
+// @Security BearerAuth
+func (h *VehicleHandler) UploadDraftImages(c *gin.Context) {
+	draft, actor, ok := h.ownDraft(c)
+	if !ok {
+		return
+	}
+	before := vehicleAuditFields(draft)
+
+	if form, err := c.MultipartForm(); err != nil || form == nil || len(form.File["images"]) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "At least one image is required",
+		})
+		return
+	}
+
+	imagePaths, reqErr := h.uploadDraftImages(c, draft)
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
+	}
+
+	var savedDraft *models.Vehicle
+	err := h.transactor.WithTx(func(tx *repository.Tx) error {
+		tx.OnRollback(func() { deleteFiles(imagePaths) })
+
+		if err := h.addDraftImagesTx(tx, draft, imagePaths); err != nil {
+			return err
+		}
+		// Image changes count as activity for the stale draft cleanup
+		if err := h.vehicleRepo.TouchTx(tx, draft.ID); err != nil {
+			return err
+		}
+
+		var err error
+		savedDraft, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionUpdate, before, draft.UUID)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to save images",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Images uploaded successfully",
+		"data":    savedDraft,
+	})
+}
+
+// DeleteDraftImage godoc
+// @Summary Remove an image from a draft
+// @Description Delete an image of a draft listing. When it was the featured image, the next image becomes featured.
+// @Tags vehicle drafts
+// @Produce json
+// @Param uuid path string true "Draft UUID"
+// @Param image_id path int true "Image ID"
+// @Success 200 {object} map[string]interface{} "Image deleted successfully"
+// @Failure 404 {object} map[string]interface{} "Draft or image not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts/{uuid}/images/{image_id} [delete]
+// @Security BearerAuth
+func (h *VehicleHandler) DeleteDraftImage(c *gin.Context) {
+	draft, actor, ok := h.ownDraft(c)
+	if !ok {
+		return
+	}
+	before := vehicleAuditFields(draft)
+
+	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Image not found",
+		})
+		return
+	}
+
+	// The file is deleted once the image is removed for good
+	var imagePath string
+	var savedDraft *models.Vehicle
+	err = h.transactor.WithTx(func(tx *repository.Tx) error {
+		var err error
+		if imagePath, err = h.vehicleRepo.DeleteImageTx(tx, draft.ID, imageID); err != nil || imagePath == "" {
+			return err
+		}
+
+		// Move the featured image to the next remaining image
+		if draft.FeaturedImage != nil && *draft.FeaturedImage == imagePath {
+			featuredImage := ""
+			for _, image := range draft.Images {
+				if image.ID != imageID {
+					featuredImage = image.ImageURL
+					break
+				}
+			}
+			if featuredImage != "" {
+				err = h.vehicleRepo.SetFeaturedImageTx(tx, draft.UUID, featuredImage)
+			} else {
+				err = h.vehicleRepo.ClearFeaturedImageTx(tx, draft.ID)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := h.vehicleRepo.TouchTx(tx, draft.ID); err != nil {
+			return err
+		}
+
+		if savedDraft, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionUpdate, before, draft.UUID); err != nil {
+			return err
+		}
+		tx.OnCommit(func() { _ = utils.DeleteFile(imagePath) })
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to delete image",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if imagePath == "" {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Image not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Image deleted successfully",
+		"data":    savedDraft,
+	})
+}
+
+// PublishDraft godoc
+// @Summary Publish a draft
+// @Description Validate a draft like a new listing (all fields required by POST /api/user/vehicles) and make it live. Validation errors are returned as for vehicle creation and the draft is kept.
+// @Tags vehicle drafts
+// @Produce json
+// @Param uuid path string true "Draft UUID"
+// @Success 200 {object} map[string]interface{} "Draft published successfully"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 403 {object} map[string]interface{} "No longer a member of the draft's organization"
+// @Failure 404 {object} map[string]interface{} "Draft not found"
+// @Failure 409 {object} map[string]interface{} "Draft modified by another request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/drafts/{uuid}/publish [post]
+// @Security BearerAuth
+func (h *VehicleHandler) PublishDraft(c *gin.Context) {
+	draft, actor, ok := h.ownDraft(c)
+	if !ok {
+		return
+	}
+	before := vehicleAuditFields(draft)
+
+	req := draftRequest(draft)
+	vehicle, reqErr := h.buildVehicle(&req)
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
+	}
+
+	slug, err := h.uniqueSlug(vehicle.Title)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to publish draft",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	vehicle.ID = draft.ID
+	vehicle.UserID = draft.UserID
+	vehicle.UUID = draft.UUID
+	vehicle.Slug = slug
+	vehicle.Version = draft.Version
+
+	// The owner may have left the draft's organization since saving it
+	if draft.OrganizationID != nil {
+		org, err := h.organizationRepo.FindByID(*draft.OrganizationID)
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "The organization of the draft no longer exists",
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Failed to retrieve organization",
+				"error":   err.Error(),
+			})
+			return
+		}
+		if vehicle.OrganizationID, reqErr = h.resolveOrganization(org.UUID, draft.UserID); reqErr != nil {
+			reqErr.respond(c)
+			return
+		}
+	}
+
+	var publishedVehicle *models.Vehicle
+	err = h.transactor.WithTx(func(tx *repository.Tx) error {
+		if err := h.vehicleRepo.UpdateTx(tx, draft.UUID, vehicle); err != nil {
+			return err
+		}
+		// UpdateTx bumps the version in the row but only in vehicle once the transaction commits
+		if err := h.vehicleRepo.UpdateStatusTx(tx, draft.ID, vehicle.Version+1, models.VehicleStatusDraft, models.VehicleStatusActive); err != nil {
+			return err
+		}
+
+		var err error
+		publishedVehicle, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionStatus, before, draft.UUID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{
+				"status":  "error",
+				"message": "The draft was modified by another request, try again",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to publish draft",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Draft published successfully",
+		"data":    publishedVehicle,
+	})
+}
+
+// draftRequest returns the values of a draft as a create vehicle request, to validate it for publishing
+func draftRequest(draft *models.Vehicle) CreateVehicleRequest {
+	req := CreateVehicleRequest{
+		Title:        draft.Title,
+		Category:     draft.Category,
+		Price:        draft.Price,
+		Currency:     draft.Currency,
+		Negotiable:   draft.Negotiable,
+		PersonType:   draft.PersonType,
+		Brand:        draft.Brand,
+		Model:        draft.Model,
+		FuelType:     draft.FuelType,
+		BodyType:     draft.BodyType,
+		Year:         draft.Year,
+		Condition:    draft.Condition,
+		Transmission: draft.Transmission,
+		Steering:     draft.Steering,
+		Registered:   draft.Registered,
+		City:         draft.City,
+		ContactName:  draft.ContactName,
+		Email:        draft.Email,
+		Equipment:    []string{},
+	}
+
+	if draft.BrandID != nil {
+		req.BrandID = *draft.BrandID
+	}
+	if draft.AutomobileID != nil {
+		req.AutomobileID = *draft.AutomobileID
+	}
+	if draft.VIN != nil {
+		req.VIN = *draft.VIN
+	}
+	if draft.Description != nil {
+		req.Description = *draft.Description
+	}
+	if draft.EngineCapacity != nil {
+		req.EngineCapacity = *draft.EngineCapacity
+	}
+	if draft.PowerHP != nil {
+		req.PowerHP = *draft.PowerHP
+	}
+	if draft.Kilometers != nil {
+		req.Kilometers = *draft.Kilometers
+	}
+	if draft.Color != nil {
+		req.Color = *draft.Color
+	}
+	if draft.NumberOfKeys != nil {
+		req.NumberOfKeys = *draft.NumberOfKeys
+	}
+	if draft.Phone != nil {
+		req.Phone = *draft.Phone
+	}
+	for _, item := range draft.Equipment {
+		req.Equipment = append(req.Equipment, item.Name)
+	}
+
+	return req
+}
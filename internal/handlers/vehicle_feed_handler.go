@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"autoelys_backend/internal/feeds"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// syndicationWriter writes a syndication feed document
+type syndicationWriter func(w io.Writer, channel feeds.Channel, vehicles []models.Vehicle, opts feeds.Options) error
+
+// GetVehiclesRSS godoc
+// @Summary RSS feed of recent vehicles (Public)
+// @Description RSS 2.0 feed of the most recent active listings. Accepts the same search and filter parameters as GET /api/vehicles, so any search can be followed in a feed reader. Items link to the listing page and carry the featured image, price and key specs. Responses are cacheable and support conditional requests with If-None-Match.
+// @Tags vehicles
+// @Produce xml
+// @Param search query string false "Search by title, brand, model, or description"
+// @Param brand query string false "Filter by catalog brand ID or slug (e.g. 12 or alfa-romeo)"
+// @Param model query string false "Filter by model name"
+// @Param fuel_type query string false "Filter by fuel type"
+// @Param body_type query string false "Filter by body type"
+// @Param transmission query string false "Filter by transmission (manuala, automata)"
+// @Param condition query string false "Filter by condition (utilizat, nou)"
+// @Param min_price query number false "Minimum price (in the currency param, default lei)"
+// @Param max_price query number false "Maximum price (in the currency param, default lei)"
+// @Param currency query string false "Currency for price filters and converted prices (lei, euro, usd)"
+// @Param sort query string false "Sort order (newest, price_asc, price_desc; default: newest)"
+// @Param min_year query int false "Minimum year"
+// @Param max_year query int false "Maximum year"
+// @Param city query string false "Filter by city"
+// @Param equipment query string false "Comma separated equipment names; only vehicles having all of them"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {string} string "RSS feed"
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/vehicles/feed.rss [get]
+func (h *VehicleHandler) GetVehiclesRSS(c *gin.Context) {
+	h.writeSyndication(c, feeds.RSSContentType, feeds.WriteRSS)
+}
+
+// GetVehiclesAtom godoc
+// @Summary Atom feed of recent vehicles (Public)
+// @Description Atom 1.0 feed of the most recent active listings. Accepts the same search and filter parameters as GET /api/vehicles, so any search can be followed in a feed reader. Entries link to the listing page and carry the featured image, price and key specs. Responses are cacheable and support conditional requests with If-None-Match.
+// @Tags vehicles
+// @Produce xml
+// @Param search query string false "Search by title, brand, model, or description"
+// @Param brand query string false "Filter by catalog brand ID or slug (e.g. 12 or alfa-romeo)"
+// @Param model query string false "Filter by model name"
+// @Param fuel_type query string false "Filter by fuel type"
+// @Param body_type query string false "Filter by body type"
+// @Param transmission query string false "Filter by transmission (manuala, automata)"
+// @Param condition query string false "Filter by condition (utilizat, nou)"
+// @Param min_price query number false "Minimum price (in the currency param, default lei)"
+// @Param max_price query number false "Maximum price (in the currency param, default lei)"
+// @Param currency query string false "Currency for price filters and converted prices (lei, euro, usd)"
+// @Param sort query string false "Sort order (newest, price_asc, price_desc; default: newest)"
+// @Param min_year query int false "Minimum year"
+// @Param max_year query int false "Maximum year"
+// @Param city query string false "Filter by city"
+// @Param equipment query string false "Comma separated equipment names; only vehicles having all of them"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {string} string "Atom feed"
+// @Success 304 {string} string "Not modified"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/vehicles/feed.atom [get]
+func (h *VehicleHandler) GetVehiclesAtom(c *gin.Context) {
+	h.writeSyndication(c, feeds.AtomContentType, feeds.WriteAtom)
+}
+
+// writeSyndication runs the public vehicle search and responds with the results as a feed
+func (h *VehicleHandler) writeSyndication(c *gin.Context, contentType string, write syndicationWriter) {
+	search, ok := h.parseVehicleSearch(c)
+	if !ok {
+		return
+	}
+	// Feeds are chronological, so promoted listings are not ranked first
+	if search.params.Sort == repository.VehicleSortNewest {
+		search.params.Sort = repository.VehicleSortCreated
+	}
+
+	vehicles, _, err := h.vehicleRepo.GetAll(search.params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicles",
+			"error":   err.Error(),
+		})
+		return
+	}
+	for i := range vehicles {
+		convertVehiclePrice(&vehicles[i], search.currency, search.rates)
+	}
+
+	options := h.feedOptions
+	options.GeneratedAt = time.Now()
+	channel := feeds.Channel{
+		Title:       syndicationTitle(search),
+		Description: "Latest vehicle listings on " + siteName,
+		Author:      siteName,
+		Link:        strings.TrimRight(options.SiteURL, "/") + "/vehicles",
+		SelfURL:     strings.TrimRight(options.MediaURL, "/") + c.Request.URL.RequestURI(),
+		Updated:     feeds.LastModified(vehicles),
+	}
+	if query := c.Request.URL.RawQuery; query != "" {
+		channel.Link += "?" + query
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf, channel, vehicles, options); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to generate feed",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// syndicationTitle names a feed after its brand, model and search filters
+func syndicationTitle(search *vehicleSearch) string {
+	var parts []string
+	for _, value := range []string{search.params.Brand, search.params.Model, search.params.Search} {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	if len(parts) == 0 {
+		return siteName + " - latest vehicles"
+	}
+	return siteName + " - " + strings.Join(parts, " ")
+}
@@ -1,13 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"autoelys_backend/internal/catalog"
+	"autoelys_backend/internal/currency"
+	"autoelys_backend/internal/feeds"
+	"autoelys_backend/internal/middleware"
 	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/patch"
+	"autoelys_backend/internal/recommend"
 	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/seo"
 	"autoelys_backend/internal/utils"
+	"autoelys_backend/internal/vin"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -15,68 +25,106 @@ import (
 )
 
 type VehicleHandler struct {
-	vehicleRepo *repository.VehicleRepository
-	validator   *validator.Validate
+	vehicleRepo      *repository.VehicleRepository
+	brandRepo        *repository.BrandRepository
+	automobileRepo   *repository.AutomobileRepository
+	exchangeRateRepo *repository.ExchangeRateRepository
+	organizationRepo *repository.OrganizationRepository
+	equipmentRepo    *repository.EquipmentRepository
+	vehicleAuditRepo *repository.VehicleAuditRepository
+	uploadRepo       *repository.UploadRepository
+	transactor       *repository.Transactor
+	feedOptions      feeds.Options
+	validator        *validator.Validate
 }
 
-func NewVehicleHandler(vehicleRepo *repository.VehicleRepository, validator *validator.Validate) *VehicleHandler {
+func NewVehicleHandler(vehicleRepo *repository.VehicleRepository, brandRepo *repository.BrandRepository, automobileRepo *repository.AutomobileRepository, exchangeRateRepo *repository.ExchangeRateRepository, organizationRepo *repository.OrganizationRepository, equipmentRepo *repository.EquipmentRepository, vehicleAuditRepo *repository.VehicleAuditRepository, uploadRepo *repository.UploadRepository, transactor *repository.Transactor, feedOptions feeds.Options, validator *validator.Validate) *VehicleHandler {
 	return &VehicleHandler{
-		vehicleRepo: vehicleRepo,
-		validator:   validator,
+		vehicleRepo:      vehicleRepo,
+		brandRepo:        brandRepo,
+		automobileRepo:   automobileRepo,
+		exchangeRateRepo: exchangeRateRepo,
+		organizationRepo: organizationRepo,
+		equipmentRepo:    equipmentRepo,
+		vehicleAuditRepo: vehicleAuditRepo,
+		uploadRepo:       uploadRepo,
+		transactor:       transactor,
+		feedOptions:      feedOptions,
+		validator:        validator,
 	}
 }
 
-// CreateVehicleRequest represents the vehicle creation request
+// CreateVehicleRequest represents the vehicle creation request, sent as a multipart form or as JSON
 type CreateVehicleRequest struct {
-	Title          string  `form:"title" validate:"required,min=5,max=255"`
-	Category       string  `form:"category" validate:"required"`
-	Description    string  `form:"description"`
-	Price          float64 `form:"price" validate:"required,gt=0"`
-	Currency       string  `form:"currency" validate:"required"`
-	Negotiable     bool    `form:"negotiable"`
-	PersonType     string  `form:"person_type" validate:"required,oneof=persoana_fizica firma"`
-	Brand          string  `form:"brand" validate:"required"`
-	Model          string  `form:"model" validate:"required"`
-	EngineCapacity int     `form:"engine_capacity"`
-	PowerHP        int     `form:"power_hp"`
-	FuelType       string  `form:"fuel_type" validate:"required,oneof=benzina motorina electric hibrid gpl hybrid_benzina hybrid_motorina"`
-	BodyType       string  `form:"body_type" validate:"required,oneof=sedan suv break coupe cabrio hatchback pickup van monovolum"`
-	Kilometers     int     `form:"kilometers"`
-	Color          string  `form:"color"`
-	Year           int     `form:"year" validate:"required,min=1970,max=2030"`
-	NumberOfKeys   int     `form:"number_of_keys"`
-	Condition      string  `form:"condition" validate:"required,oneof=utilizat nou"`
-	Transmission   string  `form:"transmission" validate:"required,oneof=manuala automata"`
-	Steering       string  `form:"steering" validate:"required,oneof=stanga dreapta"`
-	Registered     bool    `form:"registered"`
-	City           string  `form:"city" validate:"required"`
-	ContactName    string  `form:"contact_name" validate:"required"`
-	Email          string  `form:"email" validate:"required,email"`
-	Phone          string  `form:"phone"`
+	Title          string  `json:"title" form:"title" validate:"required,min=5,max=255"`
+	Category       string  `json:"category" form:"category" validate:"required"`
+	Description    string  `json:"description" form:"description"`
+	Price          float64 `json:"price" form:"price" validate:"required,gt=0"`
+	Currency       string  `json:"currency" form:"currency" validate:"required,currency"`
+	Negotiable     bool    `json:"negotiable" form:"negotiable"`
+	PersonType     string  `json:"person_type" form:"person_type" validate:"required,oneof=persoana_fizica firma"`
+	BrandID        uint64  `json:"brand_id" form:"brand_id"`
+	Brand          string  `json:"brand" form:"brand" validate:"required_without_all=VIN BrandID"`
+	AutomobileID   uint64  `json:"automobile_id" form:"automobile_id"`
+	Model          string  `json:"model" form:"model" validate:"required"`
+	VIN            string  `json:"vin" form:"vin" validate:"omitempty,vin"`
+	EngineCapacity int     `json:"engine_capacity" form:"engine_capacity"`
+	PowerHP        int     `json:"power_hp" form:"power_hp"`
+	FuelType       string  `json:"fuel_type" form:"fuel_type" validate:"required,oneof=benzina motorina electric hibrid gpl hybrid_benzina hybrid_motorina"`
+	BodyType       string  `json:"body_type" form:"body_type" validate:"required,oneof=sedan suv break coupe cabrio hatchback pickup van monovolum"`
+	Kilometers     int     `json:"kilometers" form:"kilometers"`
+	Color          string  `json:"color" form:"color"`
+	Year           int     `json:"year" form:"year" validate:"required_without=VIN,omitempty,min=1970,max=2030"`
+	NumberOfKeys   int     `json:"number_of_keys" form:"number_of_keys"`
+	Condition      string  `json:"condition" form:"condition" validate:"required,oneof=utilizat nou"`
+	Transmission   string  `json:"transmission" form:"transmission" validate:"required,oneof=manuala automata"`
+	Steering       string  `json:"steering" form:"steering" validate:"required,oneof=stanga dreapta"`
+	Registered     bool    `json:"registered" form:"registered"`
+	City           string  `json:"city" form:"city" validate:"required"`
+	ContactName    string  `json:"contact_name" form:"contact_name" validate:"required"`
+	Email          string  `json:"email" form:"email" validate:"required,email"`
+	Phone          string  `json:"phone" form:"phone"`
+
+	// List the vehicle for a dealer organization the user is a member of
+	OrganizationUUID string `json:"organization_uuid" form:"organization_uuid"`
+
+	// Equipment names from the equipment catalog, repeated or comma separated
+	Equipment []string `json:"equipment" form:"equipment"`
+
+	// Completed direct uploads to add as images, after the images in the form
+	UploadIDs []string `json:"upload_ids" form:"upload_ids" validate:"max=8,unique,dive,uuid"`
+
+	// Read from the JSON body; in multipart forms featured_image_index is parsed leniently
+	FeaturedImageIndex int `json:"featured_image_index" form:"-"`
 }
 
 // CreateVehicle godoc
 // @Summary Create a new vehicle listing (Authenticated users only)
 // @Description Add a new vehicle with images and all details. Only authenticated users can create vehicles. The vehicle will be automatically assigned to the authenticated user.
+// @Description Images are sent in the form, or uploaded beforehand with POST /api/user/uploads and referenced in upload_ids, which also allows a JSON body.
 // @Tags vehicles
 // @Accept multipart/form-data
+// @Accept json
 // @Produce json
 // @Param title formData string true "Vehicle title (min 5, max 255 characters)"
 // @Param category formData string true "Vehicle category"
 // @Param description formData string false "Vehicle description"
 // @Param price formData number true "Price (must be greater than 0)"
-// @Param currency formData string true "Currency (e.g., lei)"
+// @Param currency formData string true "Currency (lei, euro, usd)"
 // @Param negotiable formData boolean false "Price negotiable (default: false)"
 // @Param person_type formData string true "Person type (persoana_fizica, firma)"
-// @Param brand formData string true "Brand"
+// @Param brand_id formData integer false "Catalog brand ID (takes precedence over brand)"
+// @Param brand formData string false "Brand name or slug from the catalog (required unless brand_id is set or it can be decoded from the VIN)"
+// @Param automobile_id formData integer false "Catalog automobile ID (must belong to the brand)"
 // @Param model formData string true "Model"
+// @Param vin formData string false "Vehicle identification number (17 characters)"
 // @Param engine_capacity formData integer false "Engine capacity in cm3"
 // @Param power_hp formData integer false "Power in HP"
 // @Param fuel_type formData string true "Fuel type (benzina, motorina, electric, hibrid, gpl, hybrid_benzina, hybrid_motorina)"
 // @Param body_type formData string true "Body type (sedan, suv, break, coupe, cabrio, hatchback, pickup, van, monovolum)"
 // @Param kilometers formData integer false "Kilometers"
 // @Param color formData string false "Color"
-// @Param year formData integer true "Year (1970-2030)"
+// @Param year formData integer false "Year (1970-2030, required unless it can be decoded from the VIN)"
 // @Param number_of_keys formData integer false "Number of keys"
 // @Param condition formData string true "Condition (utilizat, nou)"
 // @Param transmission formData string true "Transmission (manuala, automata)"
@@ -86,8 +134,11 @@ type CreateVehicleRequest struct {
 // @Param contact_name formData string true "Contact name"
 // @Param email formData string true "Email address (valid email format)"
 // @Param phone formData string false "Phone number"
+// @Param organization_uuid formData string false "UUID of a dealer organization the user is a member of, to list the vehicle for it"
+// @Param equipment formData []string false "Equipment names from GET /api/equipment (repeated or comma separated, e.g. ac,navigation)" collectionFormat(multi)
 // @Param featured_image_index formData int false "Index of the image to use as featured (0-based, default: 0)"
 // @Param images formData file false "Vehicle images (max 8, jpeg/png/jpg)"
+// @Param upload_ids formData []string false "UUIDs of completed uploads from POST /api/user/uploads, added after the images (max 8 images in total). The vehicle can also be created with a JSON body of the same fields and upload_ids." collectionFormat(multi)
 // @Success 201 {object} map[string]interface{} "Vehicle created successfully"
 // @Failure 400 {object} map[string]interface{} "Validation error"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - Authentication required"
@@ -107,23 +158,33 @@ func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
 		return
 	}
 
-	// Validate request
-	if err := h.validator.Struct(req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	// Get authenticated user ID from context
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
 			"status":  "error",
-			"message": "Validation failed",
-			"errors":  utils.FormatValidationErrorsSimple(err.(validator.ValidationErrors)),
+			"message": "User not authenticated",
 		})
 		return
 	}
 
-	// Additional validation: year cannot be in the future
-	currentYear := time.Now().Year()
-	if req.Year > currentYear+1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Year cannot be more than one year in the future",
-		})
+	// Validate the request and build the vehicle model
+	vehicle, reqErr := h.buildVehicle(&req)
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
+	}
+	if req.OrganizationUUID != "" {
+		vehicle.OrganizationID, reqErr = h.resolveOrganization(req.OrganizationUUID, userID.(uint64))
+		if reqErr != nil {
+			reqErr.respond(c)
+			return
+		}
+	}
+
+	// Check the direct uploads before saving anything, they are claimed once the vehicle exists
+	if reqErr := h.checkUploads(userID.(uint64), req.UploadIDs); reqErr != nil {
+		reqErr.respond(c)
 		return
 	}
 
@@ -132,8 +193,18 @@ func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
 	var imagePaths []string
 	if err == nil && form != nil && form.File["images"] != nil {
 		files := form.File["images"]
+		if len(files)+len(req.UploadIDs) > utils.MaxImagesPerVehicle {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "Maximum " + strconv.Itoa(utils.MaxImagesPerVehicle) + " images allowed, including uploads",
+			})
+			return
+		}
 		imagePaths, err = utils.UploadVehicleImages(files, "./uploads/vehicles")
 		if err != nil {
+			for _, path := range imagePaths {
+				_ = utils.DeleteFile(path)
+			}
 			c.JSON(http.StatusBadRequest, gin.H{
 				"status":  "error",
 				"message": "Failed to upload images",
@@ -143,133 +214,71 @@ func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
 		}
 	}
 
-	// Look up IDs from reference tables
-	personTypeID, err := h.vehicleRepo.GetPersonTypeID(req.PersonType)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Invalid person_type value",
-		})
-		return
-	}
+	// Generate UUID and slug
+	vehicle.UserID = userID.(uint64)
+	vehicle.UUID = uuid.New().String()
+	vehicle.Slug = utils.GenerateSlug(req.Title)
 
-	fuelTypeID, err := h.vehicleRepo.GetFuelTypeID(req.FuelType)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Invalid fuel_type value",
-		})
-		return
+	// Featured image index over the form images followed by the uploads, default to the first image
+	imageCount := len(imagePaths) + len(req.UploadIDs)
+	featuredImageIndex := 0
+	if req.FeaturedImageIndex > 0 && req.FeaturedImageIndex < imageCount {
+		featuredImageIndex = req.FeaturedImageIndex
 	}
-
-	bodyTypeID, err := h.vehicleRepo.GetBodyTypeID(req.BodyType)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Invalid body_type value",
-		})
-		return
+	if featuredIndexStr := c.PostForm("featured_image_index"); featuredIndexStr != "" {
+		if idx, err := strconv.Atoi(featuredIndexStr); err == nil && idx >= 0 && idx < imageCount {
+			featuredImageIndex = idx
+		}
 	}
 
-	conditionID, err := h.vehicleRepo.GetConditionID(req.Condition)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Invalid condition value",
+	// Save the vehicle with its images, featured image, equipment and audit entry in one transaction, so a
+	// failure leaves nothing behind: the rows are rolled back and the images uploaded with the form deleted.
+	// Claimed uploads stay with their upload when it rolls back.
+	var createdVehicle, completeVehicle *models.Vehicle
+	err = h.transactor.WithTx(func(tx *repository.Tx) error {
+		tx.OnRollback(func() {
+			for _, path := range imagePaths {
+				_ = utils.DeleteFile(path)
+			}
 		})
-		return
-	}
 
-	transmissionID, err := h.vehicleRepo.GetTransmissionID(req.Transmission)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Invalid transmission value",
-		})
-		return
-	}
+		var err error
+		createdVehicle, err = h.vehicleRepo.CreateTx(tx, vehicle)
+		if err != nil {
+			return err
+		}
+		for _, imagePath := range imagePaths {
+			if err := h.vehicleRepo.CreateImageTx(tx, createdVehicle.ID, imagePath); err != nil {
+				return err
+			}
+		}
+		uploadPaths, err := h.uploadRepo.ClaimTx(tx, userID.(uint64), req.UploadIDs, createdVehicle.ID)
+		if err != nil {
+			return err
+		}
 
-	steeringID, err := h.vehicleRepo.GetSteeringID(req.Steering)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "error",
-			"message": "Invalid steering value",
-		})
-		return
-	}
+		if images := append(append([]string(nil), imagePaths...), uploadPaths...); len(images) > 0 {
+			if err := h.vehicleRepo.SetFeaturedImageTx(tx, createdVehicle.UUID, images[featuredImageIndex]); err != nil {
+				return err
+			}
+		}
+		if len(vehicle.Equipment) > 0 {
+			if err := h.vehicleRepo.SetEquipmentTx(tx, createdVehicle.ID, vehicle.Equipment); err != nil {
+				return err
+			}
+		}
 
-	// Get authenticated user ID from context
-	userID, exists := c.Get("user_id")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
+		completeVehicle, err = h.auditVehicleTx(tx, newVehicleActor(c), models.VehicleAuditActionCreate, nil, createdVehicle.UUID)
+		return err
+	})
+	if errors.Is(err, repository.ErrUploadNotClaimable) {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "error",
-			"message": "User not authenticated",
+			"message": "Uploads must be completed, unexpired uploads of the user",
 		})
 		return
 	}
-
-	// Generate UUID and slug
-	vehicleUUID := uuid.New().String()
-	slug := utils.GenerateSlug(req.Title)
-
-	// Create vehicle model
-	vehicle := &models.Vehicle{
-		UserID:         userID.(uint64),
-		UUID:           vehicleUUID,
-		Slug:           slug,
-		Title:          req.Title,
-		Category:       req.Category,
-		Price:          req.Price,
-		Currency:       req.Currency,
-		Negotiable:     req.Negotiable,
-		PersonTypeID:   personTypeID,
-		Brand:          req.Brand,
-		Model:          req.Model,
-		FuelTypeID:     fuelTypeID,
-		BodyTypeID:     bodyTypeID,
-		Year:           req.Year,
-		ConditionID:    conditionID,
-		TransmissionID: transmissionID,
-		SteeringID:     steeringID,
-		Registered:     req.Registered,
-		City:           req.City,
-		ContactName:    req.ContactName,
-		Email:          req.Email,
-	}
-
-	// Set optional string fields
-	if req.Description != "" {
-		vehicle.Description = &req.Description
-	}
-	if req.Color != "" {
-		vehicle.Color = &req.Color
-	}
-	if req.Phone != "" {
-		vehicle.Phone = &req.Phone
-	}
-
-	// Set optional int fields
-	if req.EngineCapacity > 0 {
-		vehicle.EngineCapacity = &req.EngineCapacity
-	}
-	if req.PowerHP > 0 {
-		vehicle.PowerHP = &req.PowerHP
-	}
-	if req.Kilometers > 0 {
-		vehicle.Kilometers = &req.Kilometers
-	}
-	if req.NumberOfKeys > 0 {
-		vehicle.NumberOfKeys = &req.NumberOfKeys
-	}
-
-	// Save vehicle to database
-	createdVehicle, err := h.vehicleRepo.Create(vehicle)
 	if err != nil {
-		// Cleanup uploaded images on failure
-		for _, path := range imagePaths {
-			_ = utils.DeleteFile(path)
-		}
-
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
 			"message": "Failed to create vehicle",
@@ -278,41 +287,6 @@ func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
 		return
 	}
 
-	// Save images to database and set featured image
-	var featuredImagePath string
-	featuredImageIndex := 0
-	if featuredIndexStr := c.PostForm("featured_image_index"); featuredIndexStr != "" {
-		if idx, err := strconv.Atoi(featuredIndexStr); err == nil && idx >= 0 && idx < len(imagePaths) {
-			featuredImageIndex = idx
-		}
-	}
-
-	for _, imagePath := range imagePaths {
-		if err := h.vehicleRepo.CreateImage(createdVehicle.ID, imagePath); err != nil {
-			// Log error but continue - vehicle is already created
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"status":  "error",
-				"message": "Vehicle created but failed to save some images",
-				"error":   err.Error(),
-			})
-			return
-		}
-	}
-
-	// Set featured image (default to first image if available)
-	if len(imagePaths) > 0 {
-		featuredImagePath = imagePaths[featuredImageIndex]
-		if err := h.vehicleRepo.SetFeaturedImage(createdVehicle.UUID, featuredImagePath); err != nil {
-			// Log error but continue - not critical
-		}
-	}
-
-	// Fetch complete vehicle with images
-	completeVehicle, err := h.vehicleRepo.GetByID(createdVehicle.ID)
-	if err != nil {
-		completeVehicle = createdVehicle // Fallback to basic vehicle data
-	}
-
 	c.JSON(http.StatusCreated, gin.H{
 		"status":     "success",
 		"message":    "Vehicle added successfully",
@@ -362,12 +336,17 @@ func (h *VehicleHandler) GetUserVehicles(c *gin.Context) {
 
 // GetRecommendedVehicles godoc
 // @Summary Get recommended vehicles (Public)
-// @Description Public endpoint to retrieve a curated list of recommended vehicles. Returns recent, high-quality vehicles with images. Perfect for homepage or featured sections. No authentication required.
+// @Description Public endpoint to retrieve recommended vehicles ranked by listing score (completeness, images, price versus market, recency and engagement; vehicles flagged as recommended are boosted). Perfect for homepage or featured sections. No authentication required.
 // @Tags vehicles
 // @Accept json
 // @Produce json
 // @Param limit query int false "Number of vehicles to return (default: 10, max: 50)"
+// @Param diverse query bool false "Spread results across brands (default: false)"
+// @Param max_per_brand query int false "Maximum vehicles per brand when diverse is set (default: 2)"
+// @Param currency query string false "Also show prices converted to this currency (lei, euro, usd)"
 // @Success 200 {object} map[string]interface{} "List of recommended vehicles"
+// @Success 304 {string} string "Not modified (If-None-Match matched)"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/vehicles/recommended [get]
 func (h *VehicleHandler) GetRecommendedVehicles(c *gin.Context) {
@@ -382,8 +361,25 @@ func (h *VehicleHandler) GetRecommendedVehicles(c *gin.Context) {
 		}
 	}
 
+	displayCurrency, rates, ok := parseDisplayCurrency(c, h.exchangeRateRepo)
+	if !ok {
+		return
+	}
+
+	diverse, _ := strconv.ParseBool(c.DefaultQuery("diverse", "false"))
+	maxPerBrand := 2
+	if val, err := strconv.Atoi(c.DefaultQuery("max_per_brand", "2")); err == nil && val > 0 {
+		maxPerBrand = val
+	}
+
+	// Diversity needs a wider pool to pick from
+	fetchLimit := limit
+	if diverse {
+		fetchLimit = limit * 5
+	}
+
 	// Get recommended vehicles from repository
-	vehicles, err := h.vehicleRepo.GetRecommended(limit)
+	vehicles, err := h.vehicleRepo.GetRecommended(fetchLimit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
@@ -393,6 +389,15 @@ func (h *VehicleHandler) GetRecommendedVehicles(c *gin.Context) {
 		return
 	}
 
+	if diverse {
+		vehicles = recommend.Diversify(vehicles, limit, maxPerBrand)
+	}
+
+	for i := range vehicles {
+		maskVehicleVIN(&vehicles[i])
+		convertVehiclePrice(&vehicles[i], displayCurrency, rates)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"count":  len(vehicles),
@@ -407,17 +412,20 @@ func (h *VehicleHandler) GetRecommendedVehicles(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param search query string false "Search by title, brand, model, or description"
-// @Param brand query string false "Filter by brand name"
+// @Param brand query string false "Filter by catalog brand ID or slug (e.g. 12 or alfa-romeo)"
 // @Param model query string false "Filter by model name"
 // @Param fuel_type query string false "Filter by fuel type (benzina, motorina, electric, hibrid, gpl, hybrid_benzina, hybrid_motorina)"
 // @Param body_type query string false "Filter by body type (sedan, suv, break, coupe, cabrio, hatchback, pickup, van, monovolum)"
 // @Param transmission query string false "Filter by transmission (manuala, automata)"
 // @Param condition query string false "Filter by condition (utilizat, nou)"
-// @Param min_price query number false "Minimum price"
-// @Param max_price query number false "Maximum price"
+// @Param min_price query number false "Minimum price (in the currency param, default lei)"
+// @Param max_price query number false "Maximum price (in the currency param, default lei)"
+// @Param currency query string false "Currency for price filters and converted prices (lei, euro, usd)"
+// @Param sort query string false "Sort order (newest, price_asc, price_desc; default: newest)"
 // @Param min_year query int false "Minimum year"
 // @Param max_year query int false "Maximum year"
 // @Param city query string false "Filter by city"
+// @Param equipment query string false "Comma separated equipment names; only vehicles having all of them (e.g. ac,navigation)"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
 // @Success 200 {object} map[string]interface{} "List of vehicles with pagination"
@@ -425,80 +433,12 @@ func (h *VehicleHandler) GetRecommendedVehicles(c *gin.Context) {
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/vehicles [get]
 func (h *VehicleHandler) GetAllVehicles(c *gin.Context) {
-	// Parse query parameters
-	search := c.DefaultQuery("search", "")
-	brand := c.DefaultQuery("brand", "")
-	model := c.DefaultQuery("model", "")
-	fuelType := c.DefaultQuery("fuel_type", "")
-	bodyType := c.DefaultQuery("body_type", "")
-	transmission := c.DefaultQuery("transmission", "")
-	condition := c.DefaultQuery("condition", "")
-	city := c.DefaultQuery("city", "")
-
-	var minPrice, maxPrice float64
-	if minPriceStr := c.Query("min_price"); minPriceStr != "" {
-		if val, err := strconv.ParseFloat(minPriceStr, 64); err == nil {
-			minPrice = val
-		}
-	}
-	if maxPriceStr := c.Query("max_price"); maxPriceStr != "" {
-		if val, err := strconv.ParseFloat(maxPriceStr, 64); err == nil {
-			maxPrice = val
-		}
-	}
-
-	var minYear, maxYear int
-	if minYearStr := c.Query("min_year"); minYearStr != "" {
-		if val, err := strconv.Atoi(minYearStr); err == nil {
-			minYear = val
-		}
-	}
-	if maxYearStr := c.Query("max_year"); maxYearStr != "" {
-		if val, err := strconv.Atoi(maxYearStr); err == nil {
-			maxYear = val
-		}
-	}
-
-	// Parse pagination parameters
-	page := 1
-	if pageStr := c.DefaultQuery("page", "1"); pageStr != "" {
-		if val, err := strconv.Atoi(pageStr); err == nil && val > 0 {
-			page = val
-		}
-	}
-
-	limit := 20
-	if limitStr := c.DefaultQuery("limit", "20"); limitStr != "" {
-		if val, err := strconv.Atoi(limitStr); err == nil && val > 0 {
-			limit = val
-			if limit > 100 {
-				limit = 100 // Max limit
-			}
-		}
-	}
-
-	offset := (page - 1) * limit
-
-	// Build search parameters
-	params := repository.VehicleSearchParams{
-		Search:       search,
-		Brand:        brand,
-		Model:        model,
-		FuelType:     fuelType,
-		BodyType:     bodyType,
-		Transmission: transmission,
-		Condition:    condition,
-		MinPrice:     minPrice,
-		MaxPrice:     maxPrice,
-		MinYear:      minYear,
-		MaxYear:      maxYear,
-		City:         city,
-		Limit:        limit,
-		Offset:       offset,
+	search, ok := h.parseVehicleSearch(c)
+	if !ok {
+		return
 	}
-
 	// Get vehicles from repository
-	vehicles, total, err := h.vehicleRepo.GetAll(params)
+	vehicles, total, err := h.vehicleRepo.GetAll(search.params)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
@@ -508,14 +448,20 @@ func (h *VehicleHandler) GetAllVehicles(c *gin.Context) {
 		return
 	}
 
+	for i := range vehicles {
+		maskVehicleVIN(&vehicles[i])
+		convertVehiclePrice(&vehicles[i], search.currency, search.rates)
+	}
+
 	// Calculate pagination info
+	limit := search.params.Limit
 	totalPages := (total + limit - 1) / limit
 
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   vehicles,
 		"pagination": gin.H{
-			"page":        page,
+			"page":        search.page,
 			"limit":       limit,
 			"total":       total,
 			"total_pages": totalPages,
@@ -530,13 +476,22 @@ func (h *VehicleHandler) GetAllVehicles(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param slug path string true "Vehicle slug (SEO-friendly URL identifier)"
+// @Param currency query string false "Also show the price converted to this currency (lei, euro, usd)"
+// @Param include query string false "Set to seo to also return the page metadata (JSON-LD, Open Graph, Twitter card, canonical URL) as seo"
 // @Success 200 {object} map[string]interface{} "Vehicle details with complete information"
+// @Success 304 {string} string "Not modified (If-None-Match matched)"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
 // @Failure 404 {object} map[string]interface{} "Vehicle not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/vehicles/{slug} [get]
 func (h *VehicleHandler) GetVehicle(c *gin.Context) {
 	slug := c.Param("slug")
 
+	displayCurrency, rates, ok := parseDisplayCurrency(c, h.exchangeRateRepo)
+	if !ok {
+		return
+	}
+
 	vehicle, err := h.vehicleRepo.GetBySlug(slug)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -555,20 +510,138 @@ func (h *VehicleHandler) GetVehicle(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	// Views feed the engagement part of the listing score. Conditional requests revalidate a page the
+	// client has already seen, so they are not counted again.
+	if vehicle.Status == models.VehicleStatusActive && !isConditionalRequest(c) {
+		h.vehicleRepo.IncrementViewCount(vehicle.ID)
+	}
+
+	maskVehicleVIN(vehicle)
+	convertVehiclePrice(vehicle, displayCurrency, rates)
+
+	response := gin.H{
 		"status": "success",
 		"data":   vehicle,
-	})
+	}
+	if includesSEO(c) {
+		response["seo"] = seo.ForVehicle(vehicle, h.seoOptions())
+	}
+	c.JSON(http.StatusOK, response)
 }
 
-// GetVehicleByUUID godoc
-// @Summary Get vehicle by UUID (Owner/Admin only)
-// @Description Retrieve a vehicle with all its details and images using UUID. Only accessible by vehicle owner or admin.
-// @Tags vehicles
-// @Accept json
-// @Produce json
-// @Param uuid path string true "Vehicle UUID"
+// isConditionalRequest reports whether the client is revalidating a response it has cached
+func isConditionalRequest(c *gin.Context) bool {
+	return c.GetHeader("If-None-Match") != ""
+}
+
+// similarCandidatePool is the number of recent matching listings scored for the similar vehicles endpoint
+const similarCandidatePool = 200
+
+// SimilarVehicle is a vehicle with its similarity score to the viewed vehicle
+type SimilarVehicle struct {
+	models.Vehicle
+	SimilarityScore float64 `json:"similarity_score"`
+}
+
+// GetSimilarVehicles godoc
+// @Summary Get vehicles similar to a vehicle (Public)
+// @Description Public endpoint returning other active listings similar to the vehicle with the given slug, scored by brand/model match, body type, fuel type, year, price and kilometer proximity and the same city. Ties are ordered by newest listing, then ID.
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Param slug path string true "Vehicle slug"
+// @Param limit query int false "Number of vehicles to return (default: 6, max: 20)"
+// @Param exclude_seller query bool false "Leave out other listings of the same seller (default: false)"
+// @Param currency query string false "Also show prices converted to this currency (lei, euro, usd)"
+// @Success 200 {object} map[string]interface{} "List of similar vehicles"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/vehicles/{slug}/similar [get]
+func (h *VehicleHandler) GetSimilarVehicles(c *gin.Context) {
+	limit := 6
+	if limitStr := c.DefaultQuery("limit", "6"); limitStr != "" {
+		if val, err := strconv.Atoi(limitStr); err == nil && val > 0 {
+			limit = val
+			if limit > 20 {
+				limit = 20 // Max limit
+			}
+		}
+	}
+	excludeSeller, _ := strconv.ParseBool(c.DefaultQuery("exclude_seller", "false"))
+
+	displayCurrency, rates, ok := parseDisplayCurrency(c, h.exchangeRateRepo)
+	if !ok {
+		return
+	}
+
+	vehicle, err := h.vehicleRepo.GetBySlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicle",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if vehicle == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Vehicle not found",
+		})
+		return
+	}
+
+	params := repository.SimilarCandidateParams{
+		ExcludeVehicleID: vehicle.ID,
+		BrandID:          vehicle.BrandID,
+		Brand:            vehicle.Brand,
+		AutomobileID:     vehicle.AutomobileID,
+		Model:            vehicle.Model,
+		BodyTypeID:       vehicle.BodyTypeID,
+		FuelTypeID:       vehicle.FuelTypeID,
+		Limit:            similarCandidatePool,
+	}
+	if excludeSeller {
+		params.ExcludeUserID = vehicle.UserID
+	}
+
+	candidates, err := h.vehicleRepo.GetSimilarCandidates(params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve similar vehicles",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	similar := make([]SimilarVehicle, 0, limit)
+	for _, scored := range recommend.Similar(vehicle, candidates, limit) {
+		maskVehicleVIN(&scored.Vehicle)
+		convertVehiclePrice(&scored.Vehicle, displayCurrency, rates)
+		similar = append(similar, SimilarVehicle{
+			Vehicle:         scored.Vehicle,
+			SimilarityScore: scored.Score,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"count":  len(similar),
+		"data":   similar,
+	})
+}
+
+// GetVehicleByUUID godoc
+// @Summary Get vehicle by UUID (Owner/Admin only)
+// @Description Retrieve a vehicle with all its details and images using UUID. Only accessible by vehicle owner or admin. The ETag header holds the version of the vehicle, to send as If-Match when updating it.
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Param uuid path string true "Vehicle UUID"
 // @Success 200 {object} map[string]interface{} "Vehicle details"
+// @Header 200 {string} ETag "Version of the vehicle"
 // @Failure 403 {object} map[string]interface{} "Forbidden - Not owner or admin"
 // @Failure 404 {object} map[string]interface{} "Vehicle not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -614,11 +687,18 @@ func (h *VehicleHandler) GetVehicleByUUID(c *gin.Context) {
 		return
 	}
 
-	// Authorization check: Only owner or admin can view
-	isOwner := vehicle.UserID == userID.(uint64)
-	isAdmin := roleID.(uint64) == 1 // Admin role ID is 1
+	// Authorization check: Only owner, admin or a member of the vehicle's organization can view
+	canView, _, err := h.vehiclePermissions(vehicle, userID.(uint64), roleID.(uint64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to check permissions",
+			"error":   err.Error(),
+		})
+		return
+	}
 
-	if !isOwner && !isAdmin {
+	if !canView {
 		c.JSON(http.StatusForbidden, gin.H{
 			"status":  "error",
 			"message": "You don't have permission to view this vehicle",
@@ -626,6 +706,7 @@ func (h *VehicleHandler) GetVehicleByUUID(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, vehicle.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   vehicle,
@@ -638,11 +719,14 @@ type UpdateVehicleRequest struct {
 	Category       string  `form:"category"`
 	Description    string  `form:"description"`
 	Price          float64 `form:"price" validate:"omitempty,gt=0"`
-	Currency       string  `form:"currency"`
+	Currency       string  `form:"currency" validate:"omitempty,currency"`
 	Negotiable     bool    `form:"negotiable"`
 	PersonType     string  `form:"person_type" validate:"omitempty,oneof=persoana_fizica firma"`
+	BrandID        uint64  `form:"brand_id"`
 	Brand          string  `form:"brand"`
+	AutomobileID   uint64  `form:"automobile_id"`
 	Model          string  `form:"model"`
+	VIN            string  `form:"vin" validate:"omitempty,vin"`
 	EngineCapacity int     `form:"engine_capacity"`
 	PowerHP        int     `form:"power_hp"`
 	FuelType       string  `form:"fuel_type" validate:"omitempty,oneof=benzina motorina electric hibrid gpl hybrid_benzina hybrid_motorina"`
@@ -659,11 +743,65 @@ type UpdateVehicleRequest struct {
 	ContactName    string  `form:"contact_name"`
 	Email          string  `form:"email" validate:"omitempty,email"`
 	Phone          string  `form:"phone"`
+
+	// Move the vehicle to a dealer organization the owner is a member of
+	OrganizationUUID string `form:"organization_uuid"`
+
+	// Replaces the equipment when sent; an empty value removes all equipment
+	Equipment []string `form:"equipment"`
+}
+
+// patch returns the changes of the request: empty and zero values are not provided, while negotiable
+// and registered are always set since a form cannot tell a false checkbox from a missing one
+func (req *UpdateVehicleRequest) patch() *PatchVehicleRequest {
+	p := &PatchVehicleRequest{
+		Title:            patch.NonZero(req.Title),
+		Category:         patch.NonZero(req.Category),
+		Description:      patch.NonZero(req.Description),
+		Price:            positive(req.Price),
+		Currency:         patch.NonZero(req.Currency),
+		Negotiable:       patch.Value(req.Negotiable),
+		PersonType:       patch.NonZero(req.PersonType),
+		BrandID:          patch.NonZero(req.BrandID),
+		Brand:            patch.NonZero(req.Brand),
+		AutomobileID:     patch.NonZero(req.AutomobileID),
+		Model:            patch.NonZero(req.Model),
+		VIN:              patch.NonZero(req.VIN),
+		EngineCapacity:   positive(req.EngineCapacity),
+		PowerHP:          positive(req.PowerHP),
+		FuelType:         patch.NonZero(req.FuelType),
+		BodyType:         patch.NonZero(req.BodyType),
+		Kilometers:       positive(req.Kilometers),
+		Color:            patch.NonZero(req.Color),
+		Year:             positive(req.Year),
+		NumberOfKeys:     positive(req.NumberOfKeys),
+		Condition:        patch.NonZero(req.Condition),
+		Transmission:     patch.NonZero(req.Transmission),
+		Steering:         patch.NonZero(req.Steering),
+		Registered:       patch.Value(req.Registered),
+		City:             patch.NonZero(req.City),
+		ContactName:      patch.NonZero(req.ContactName),
+		Email:            patch.NonZero(req.Email),
+		Phone:            patch.NonZero(req.Phone),
+		OrganizationUUID: patch.NonZero(req.OrganizationUUID),
+	}
+	if req.Equipment != nil {
+		p.Equipment = patch.Value(req.Equipment)
+	}
+	return p
+}
+
+// positive returns a field set to v when it is greater than zero
+func positive[T int | float64](v T) patch.Field[T] {
+	if v > 0 {
+		return patch.Value(v)
+	}
+	return patch.Field[T]{}
 }
 
 // UpdateVehicle godoc
 // @Summary Update vehicle by UUID
-// @Description Update a vehicle listing using UUID
+// @Description Update a vehicle listing using UUID. Requires the ETag of the version being updated in If-Match; an update of a vehicle modified since that version is rejected with 412.
 // @Tags vehicles
 // @Accept multipart/form-data
 // @Produce json
@@ -672,11 +810,14 @@ type UpdateVehicleRequest struct {
 // @Param category formData string false "Vehicle category"
 // @Param description formData string false "Vehicle description"
 // @Param price formData number false "Price"
-// @Param currency formData string false "Currency (e.g., lei)"
+// @Param currency formData string false "Currency (lei, euro, usd)"
 // @Param negotiable formData boolean false "Price negotiable"
 // @Param person_type formData string false "Person type (persoana_fizica, firma)"
-// @Param brand formData string false "Brand"
+// @Param brand_id formData integer false "Catalog brand ID (takes precedence over brand)"
+// @Param brand formData string false "Brand name or slug from the catalog"
+// @Param automobile_id formData integer false "Catalog automobile ID (must belong to the brand)"
 // @Param model formData string false "Model"
+// @Param vin formData string false "Vehicle identification number (17 characters)"
 // @Param engine_capacity formData integer false "Engine capacity (cm3)"
 // @Param power_hp formData integer false "Power (HP)"
 // @Param fuel_type formData string false "Fuel type"
@@ -693,14 +834,43 @@ type UpdateVehicleRequest struct {
 // @Param contact_name formData string false "Contact name"
 // @Param email formData string false "Email"
 // @Param phone formData string false "Phone"
+// @Param organization_uuid formData string false "UUID of a dealer organization the owner is a member of, to move the vehicle to it"
+// @Param equipment formData []string false "Equipment names replacing the current equipment (repeated or comma separated; send an empty value to remove all)" collectionFormat(multi)
+// @Param If-Match header string true "ETag of the vehicle version being updated"
 // @Success 200 {object} map[string]interface{} "Vehicle updated successfully"
+// @Header 200 {string} ETag "New version of the vehicle"
 // @Failure 400 {object} map[string]interface{} "Validation error"
 // @Failure 403 {object} map[string]interface{} "Forbidden - Not owner or admin"
 // @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 412 {object} map[string]interface{} "Vehicle modified since it was read, with current_version and current_etag"
+// @Failure 428 {object} map[string]interface{} "If-Match header missing"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/user/vehicles/{uuid} [put]
 // @Security BearerAuth
 func (h *VehicleHandler) UpdateVehicle(c *gin.Context) {
+	h.updateVehicle(c, func(c *gin.Context) (*PatchVehicleRequest, *requestError) {
+		var req UpdateVehicleRequest
+
+		// Bind form data
+		if err := c.ShouldBind(&req); err != nil {
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid request data", err: err}
+		}
+
+		// Validate request
+		if err := h.validator.Struct(req); err != nil {
+			return nil, &requestError{
+				status:  http.StatusBadRequest,
+				message: "Validation failed",
+				errors:  utils.FormatValidationErrorsSimple(err.(validator.ValidationErrors)),
+			}
+		}
+		return req.patch(), nil
+	})
+}
+
+// updateVehicle updates a published vehicle of the authenticated user with the changes returned by bind,
+// which is only called once the user is allowed to edit the vehicle
+func (h *VehicleHandler) updateVehicle(c *gin.Context, bind func(c *gin.Context) (*PatchVehicleRequest, *requestError)) {
 	vehicleUUID := c.Param("uuid")
 
 	// Get authenticated user info from context
@@ -741,11 +911,18 @@ func (h *VehicleHandler) UpdateVehicle(c *gin.Context) {
 		return
 	}
 
-	// Authorization check: Only owner or admin can update
-	isOwner := existingVehicle.UserID == userID.(uint64)
-	isAdmin := roleID.(uint64) == 1 // Admin role ID is 1
+	// Authorization check: Only owner, admin or an organization owner/manager can update
+	_, canEdit, err := h.vehiclePermissions(existingVehicle, userID.(uint64), roleID.(uint64))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to check permissions",
+			"error":   err.Error(),
+		})
+		return
+	}
 
-	if !isOwner && !isAdmin {
+	if !canEdit {
 		c.JSON(http.StatusForbidden, gin.H{
 			"status":  "error",
 			"message": "You don't have permission to update this vehicle",
@@ -753,188 +930,841 @@ func (h *VehicleHandler) UpdateVehicle(c *gin.Context) {
 		return
 	}
 
-	var req UpdateVehicleRequest
-
-	// Bind form data
-	if err := c.ShouldBind(&req); err != nil {
+	if existingVehicle.Status == models.VehicleStatusDraft {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "error",
-			"message": "Invalid request data",
-			"error":   err.Error(),
+			"message": "This vehicle is a draft, update it with PUT /api/user/vehicles/drafts/{uuid}",
 		})
 		return
 	}
 
-	// Validate request
-	if err := h.validator.Struct(req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	if precondition := checkIfMatch(c, "vehicle", existingVehicle.Version); precondition != nil {
+		precondition.respond(c, gin.H{
 			"status":  "error",
-			"message": "Validation failed",
-			"errors":  utils.FormatValidationErrorsSimple(err.(validator.ValidationErrors)),
+			"message": precondition.message,
 		})
 		return
 	}
 
-	// Update only provided fields
-	if req.Title != "" {
-		existingVehicle.Title = req.Title
-		existingVehicle.Slug = utils.GenerateSlug(req.Title)
-	}
-	if req.Category != "" {
-		existingVehicle.Category = req.Category
-	}
-	if req.Description != "" {
-		existingVehicle.Description = &req.Description
-	}
-	if req.Price > 0 {
-		existingVehicle.Price = req.Price
+	before := vehicleAuditFields(existingVehicle)
+
+	req, reqErr := bind(c)
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
 	}
-	if req.Currency != "" {
-		existingVehicle.Currency = req.Currency
+
+	// Update only provided fields
+	equipment, reqErr := h.applyVehicleUpdate(existingVehicle, req, userID.(uint64))
+	if reqErr != nil {
+		reqErr.respond(c)
+		return
 	}
-	existingVehicle.Negotiable = req.Negotiable
 
-	if req.PersonType != "" {
-		personTypeID, err := h.vehicleRepo.GetPersonTypeID(req.PersonType)
-		if err != nil {
+	if len(req.UploadIDs.Value) > 0 {
+		if len(existingVehicle.Images)+len(req.UploadIDs.Value) > utils.MaxImagesPerVehicle {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"status":  "error",
-				"message": "Invalid person_type value",
+				"message": "Maximum " + strconv.Itoa(utils.MaxImagesPerVehicle) + " images allowed, including uploads",
 			})
 			return
 		}
-		existingVehicle.PersonTypeID = personTypeID
+		if reqErr := h.checkUploads(userID.(uint64), req.UploadIDs.Value); reqErr != nil {
+			reqErr.respond(c)
+			return
+		}
+	}
+
+	// Update the vehicle, its equipment and the uploaded images together with the audit entry
+	var updatedVehicle *models.Vehicle
+	err = h.transactor.WithTx(func(tx *repository.Tx) error {
+		if err := h.vehicleRepo.UpdateTx(tx, vehicleUUID, existingVehicle); err != nil {
+			return err
+		}
+		if equipment != nil {
+			if err := h.vehicleRepo.SetEquipmentTx(tx, existingVehicle.ID, equipment); err != nil {
+				return err
+			}
+		}
+		if len(req.UploadIDs.Value) > 0 {
+			uploadPaths, err := h.uploadRepo.ClaimTx(tx, userID.(uint64), req.UploadIDs.Value, existingVehicle.ID)
+			if err != nil {
+				return err
+			}
+			if existingVehicle.FeaturedImage == nil {
+				if err := h.vehicleRepo.SetFeaturedImageTx(tx, vehicleUUID, uploadPaths[0]); err != nil {
+					return err
+				}
+			}
+		}
+
+		var err error
+		updatedVehicle, err = h.auditVehicleTx(tx, newVehicleActor(c), models.VehicleAuditActionUpdate, before, vehicleUUID)
+		return err
+	})
+	if precondition := versionConflict("vehicle", err); precondition != nil {
+		precondition.respond(c, gin.H{
+			"status":  "error",
+			"message": precondition.message,
+		})
+		return
+	}
+	if errors.Is(err, repository.ErrUploadNotClaimable) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Uploads must be completed, unexpired uploads of the user",
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to update vehicle",
+			"error":   err.Error(),
+		})
+		return
 	}
 
-	if req.Brand != "" {
-		existingVehicle.Brand = req.Brand
+	setVersionETag(c, updatedVehicle.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Vehicle updated successfully",
+		"data":    updatedVehicle,
+	})
+}
+
+// applyVehicleUpdate applies the fields set in a patch to a vehicle, validating lookup values, the catalog
+// link, the VIN and the organization. Null clears nullable fields. It returns the resolved equipment, nil
+// when the patch leaves the equipment unchanged.
+func (h *VehicleHandler) applyVehicleUpdate(vehicle *models.Vehicle, req *PatchVehicleRequest, userID uint64) ([]models.Equipment, *requestError) {
+	if req.Title.Set {
+		vehicle.Title = req.Title.Value
+		vehicle.Slug = utils.GenerateSlug(req.Title.Value)
 	}
-	if req.Model != "" {
-		existingVehicle.Model = req.Model
+	if req.Category.Set {
+		vehicle.Category = req.Category.Value
 	}
-	if req.EngineCapacity > 0 {
-		existingVehicle.EngineCapacity = &req.EngineCapacity
+	if req.Description.Set {
+		vehicle.Description = req.Description.Ptr()
 	}
-	if req.PowerHP > 0 {
-		existingVehicle.PowerHP = &req.PowerHP
+	if req.Price.Set {
+		vehicle.Price = req.Price.Value
+	}
+	if req.Currency.Set {
+		vehicle.Currency = models.NormalizeCurrency(req.Currency.Value)
+	}
+	if req.Negotiable.Set {
+		vehicle.Negotiable = req.Negotiable.Value
 	}
 
-	if req.FuelType != "" {
-		fuelTypeID, err := h.vehicleRepo.GetFuelTypeID(req.FuelType)
+	if req.PersonType.Set {
+		personTypeID, err := h.vehicleRepo.GetPersonTypeID(req.PersonType.Value)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": "Invalid fuel_type value",
-			})
-			return
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid person_type value"}
 		}
-		existingVehicle.FuelTypeID = fuelTypeID
+		vehicle.PersonTypeID = personTypeID
 	}
 
-	if req.BodyType != "" {
-		bodyTypeID, err := h.vehicleRepo.GetBodyTypeID(req.BodyType)
+	// Re-validate the catalog link when brand or model change
+	if req.BrandID.Set || req.Brand.Set || req.AutomobileID.Set || req.Model.Set {
+		brandName := vehicle.Brand
+		if req.Brand.Set {
+			brandName = req.Brand.Value
+		}
+		brandID := req.BrandID.Value
+		if brandID == 0 && !req.Brand.Set && vehicle.BrandID != nil {
+			brandID = *vehicle.BrandID
+		}
+		if req.Model.Set {
+			vehicle.Model = req.Model.Value
+		}
+		year := vehicle.Year
+		if req.Year.Set {
+			year = req.Year.Value
+		}
+
+		link, errMsg, err := h.resolveCatalog(brandID, brandName, req.AutomobileID.Value, vehicle.Model, year)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": "Invalid body_type value",
-			})
-			return
+			return nil, &requestError{status: http.StatusInternalServerError, message: "Failed to validate brand and model", err: err}
 		}
-		existingVehicle.BodyTypeID = bodyTypeID
+		if errMsg != "" {
+			return nil, &requestError{status: http.StatusBadRequest, message: errMsg}
+		}
+		vehicle.BrandID = link.brandID
+		vehicle.AutomobileID = link.automobileID
+		vehicle.Brand = link.brandName
+	}
+	if req.EngineCapacity.Set {
+		vehicle.EngineCapacity = req.EngineCapacity.Ptr()
+	}
+	if req.PowerHP.Set {
+		vehicle.PowerHP = req.PowerHP.Ptr()
 	}
 
-	if req.Kilometers > 0 {
-		existingVehicle.Kilometers = &req.Kilometers
+	if req.FuelType.Set {
+		fuelTypeID, err := h.vehicleRepo.GetFuelTypeID(req.FuelType.Value)
+		if err != nil {
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid fuel_type value"}
+		}
+		vehicle.FuelTypeID = fuelTypeID
 	}
-	if req.Color != "" {
-		existingVehicle.Color = &req.Color
+
+	if req.BodyType.Set {
+		bodyTypeID, err := h.vehicleRepo.GetBodyTypeID(req.BodyType.Value)
+		if err != nil {
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid body_type value"}
+		}
+		vehicle.BodyTypeID = bodyTypeID
+	}
+
+	if req.Kilometers.Set {
+		vehicle.Kilometers = req.Kilometers.Ptr()
+	}
+	if req.Color.Set {
+		vehicle.Color = req.Color.Ptr()
 	}
-	if req.Year > 0 {
+	if req.Year.Set {
 		// Validate year
 		currentYear := time.Now().Year()
-		if req.Year > currentYear+1 {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": "Year cannot be more than one year in the future",
-			})
-			return
+		if req.Year.Value > currentYear+1 {
+			return nil, &requestError{status: http.StatusBadRequest, message: "Year cannot be more than one year in the future"}
 		}
-		existingVehicle.Year = req.Year
+		vehicle.Year = req.Year.Value
 	}
-	if req.NumberOfKeys > 0 {
-		existingVehicle.NumberOfKeys = &req.NumberOfKeys
+	if req.NumberOfKeys.Set {
+		vehicle.NumberOfKeys = req.NumberOfKeys.Ptr()
 	}
 
-	if req.Condition != "" {
-		conditionID, err := h.vehicleRepo.GetConditionID(req.Condition)
+	if req.Condition.Set {
+		conditionID, err := h.vehicleRepo.GetConditionID(req.Condition.Value)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": "Invalid condition value",
-			})
-			return
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid condition value"}
 		}
-		existingVehicle.ConditionID = conditionID
+		vehicle.ConditionID = conditionID
 	}
 
-	if req.Transmission != "" {
-		transmissionID, err := h.vehicleRepo.GetTransmissionID(req.Transmission)
+	if req.Transmission.Set {
+		transmissionID, err := h.vehicleRepo.GetTransmissionID(req.Transmission.Value)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": "Invalid transmission value",
-			})
-			return
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid transmission value"}
 		}
-		existingVehicle.TransmissionID = transmissionID
+		vehicle.TransmissionID = transmissionID
 	}
 
-	if req.Steering != "" {
-		steeringID, err := h.vehicleRepo.GetSteeringID(req.Steering)
+	if req.Steering.Set {
+		steeringID, err := h.vehicleRepo.GetSteeringID(req.Steering.Value)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"status":  "error",
-				"message": "Invalid steering value",
-			})
-			return
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid steering value"}
 		}
-		existingVehicle.SteeringID = steeringID
+		vehicle.SteeringID = steeringID
 	}
 
-	existingVehicle.Registered = req.Registered
+	if req.Registered.Set {
+		vehicle.Registered = req.Registered.Value
+	}
+
+	if req.City.Set {
+		vehicle.City = req.City.Value
+	}
+	if req.ContactName.Set {
+		vehicle.ContactName = req.ContactName.Value
+	}
+	if req.Email.Set {
+		vehicle.Email = req.Email.Value
+	}
+	if req.Phone.Set {
+		vehicle.Phone = req.Phone.Ptr()
+	}
 
-	if req.City != "" {
-		existingVehicle.City = req.City
+	// Only the vehicle owner can move it to one of their organizations, or back out of it with null
+	if req.OrganizationUUID.Set {
+		if vehicle.UserID != userID {
+			return nil, &requestError{status: http.StatusForbidden, message: "Only the vehicle owner can move it to an organization"}
+		}
+		vehicle.OrganizationID = nil
+		if !req.OrganizationUUID.Null {
+			organizationID, reqErr := h.resolveOrganization(req.OrganizationUUID.Value, userID)
+			if reqErr != nil {
+				return nil, reqErr
+			}
+			vehicle.OrganizationID = organizationID
+		}
+	}
+
+	// Replace the equipment when sent
+	var equipment []models.Equipment
+	if req.Equipment.Set {
+		var reqErr *requestError
+		if equipment, reqErr = h.resolveEquipment(req.Equipment.Value); reqErr != nil {
+			return nil, reqErr
+		}
+	}
+
+	// Cross-check VIN against the (possibly updated) brand and year
+	if req.VIN.Set {
+		vehicle.VIN = nil
+		if !req.VIN.Null {
+			normalizedVIN, errMsg := applyVIN(req.VIN.Value, &vehicle.Brand, &vehicle.Year)
+			if errMsg != "" {
+				return nil, &requestError{status: http.StatusBadRequest, message: errMsg}
+			}
+			vehicle.VIN = &normalizedVIN
+		}
+	}
+
+	return equipment, nil
+}
+
+// requestError is a failed request with the status and error body to respond with
+type requestError struct {
+	status  int
+	message string
+	errors  map[string]string
+	err     error
+}
+
+func (e *requestError) respond(c *gin.Context) {
+	body := gin.H{
+		"status":  "error",
+		"message": e.message,
+	}
+	if e.errors != nil {
+		body["errors"] = e.errors
 	}
-	if req.ContactName != "" {
-		existingVehicle.ContactName = req.ContactName
+	if e.err != nil {
+		body["error"] = e.err.Error()
+	}
+	c.JSON(e.status, body)
+}
+
+// checkUploads verifies that the direct uploads can be claimed by the user as vehicle images
+func (h *VehicleHandler) checkUploads(userID uint64, uploadIDs []string) *requestError {
+	if len(uploadIDs) == 0 {
+		return nil
+	}
+	uploads, err := h.uploadRepo.GetClaimable(userID, uploadIDs)
+	if err != nil {
+		return &requestError{status: http.StatusInternalServerError, message: "Failed to retrieve uploads", err: err}
+	}
+	if len(uploads) != len(uploadIDs) {
+		return &requestError{status: http.StatusBadRequest, message: "Uploads must be completed, unexpired uploads of the user"}
+	}
+	return nil
+}
+
+// buildVehicle validates a create vehicle request (validator rules, VIN, catalog link and lookup values)
+// and returns the vehicle to store. Owner, UUID, slug and images are left to the caller.
+func (h *VehicleHandler) buildVehicle(req *CreateVehicleRequest) (*models.Vehicle, *requestError) {
+	// Validate request
+	if err := h.validator.Struct(req); err != nil {
+		return nil, &requestError{
+			status:  http.StatusBadRequest,
+			message: "Validation failed",
+			errors:  utils.FormatValidationErrorsSimple(err.(validator.ValidationErrors)),
+		}
+	}
+
+	// A catalog brand id takes precedence over the free-text brand
+	if req.BrandID > 0 {
+		brand, err := h.brandRepo.FindByID(req.BrandID)
+		if err != nil {
+			return nil, &requestError{status: http.StatusInternalServerError, message: "Failed to retrieve brand", err: err}
+		}
+		if brand == nil {
+			return nil, &requestError{status: http.StatusBadRequest, message: "Invalid brand_id value"}
+		}
+		req.Brand = brand.Name
+	}
+
+	// Decode VIN to fill in or cross-check brand and model year
+	var vehicleVIN *string
+	if req.VIN != "" {
+		normalizedVIN, errMsg := applyVIN(req.VIN, &req.Brand, &req.Year)
+		if errMsg != "" {
+			return nil, &requestError{status: http.StatusBadRequest, message: errMsg}
+		}
+		vehicleVIN = &normalizedVIN
+	}
+
+	if req.Brand == "" || req.Year == 0 {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Brand and year are required when they cannot be decoded from the VIN"}
+	}
+
+	// Additional validation: year cannot be in the future
+	currentYear := time.Now().Year()
+	if req.Year > currentYear+1 {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Year cannot be more than one year in the future"}
+	}
+
+	// Validate brand and model against the catalog
+	link, errMsg, err := h.resolveCatalog(req.BrandID, req.Brand, req.AutomobileID, req.Model, req.Year)
+	if err != nil {
+		return nil, &requestError{status: http.StatusInternalServerError, message: "Failed to validate brand and model", err: err}
 	}
-	if req.Email != "" {
-		existingVehicle.Email = req.Email
+	if errMsg != "" {
+		return nil, &requestError{status: http.StatusBadRequest, message: errMsg}
+	}
+
+	// Look up IDs from reference tables
+	personTypeID, err := h.vehicleRepo.GetPersonTypeID(req.PersonType)
+	if err != nil {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid person_type value"}
+	}
+
+	fuelTypeID, err := h.vehicleRepo.GetFuelTypeID(req.FuelType)
+	if err != nil {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid fuel_type value"}
+	}
+
+	bodyTypeID, err := h.vehicleRepo.GetBodyTypeID(req.BodyType)
+	if err != nil {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid body_type value"}
+	}
+
+	conditionID, err := h.vehicleRepo.GetConditionID(req.Condition)
+	if err != nil {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid condition value"}
+	}
+
+	transmissionID, err := h.vehicleRepo.GetTransmissionID(req.Transmission)
+	if err != nil {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid transmission value"}
+	}
+
+	steeringID, err := h.vehicleRepo.GetSteeringID(req.Steering)
+	if err != nil {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid steering value"}
+	}
+
+	var equipment []models.Equipment
+	if req.Equipment != nil {
+		var reqErr *requestError
+		if equipment, reqErr = h.resolveEquipment(req.Equipment); reqErr != nil {
+			return nil, reqErr
+		}
+	}
+
+	vehicle := &models.Vehicle{
+		Title:          req.Title,
+		Category:       req.Category,
+		Price:          req.Price,
+		Currency:       models.NormalizeCurrency(req.Currency),
+		Negotiable:     req.Negotiable,
+		PersonTypeID:   personTypeID,
+		BrandID:        link.brandID,
+		AutomobileID:   link.automobileID,
+		Brand:          link.brandName,
+		Model:          req.Model,
+		VIN:            vehicleVIN,
+		FuelTypeID:     fuelTypeID,
+		BodyTypeID:     bodyTypeID,
+		Year:           req.Year,
+		ConditionID:    conditionID,
+		TransmissionID: transmissionID,
+		SteeringID:     steeringID,
+		Registered:     req.Registered,
+		City:           req.City,
+		ContactName:    req.ContactName,
+		Email:          req.Email,
+		Equipment:      equipment,
+	}
+
+	// Set optional string fields
+	if req.Description != "" {
+		vehicle.Description = &req.Description
+	}
+	if req.Color != "" {
+		vehicle.Color = &req.Color
 	}
 	if req.Phone != "" {
-		existingVehicle.Phone = &req.Phone
+		vehicle.Phone = &req.Phone
 	}
 
-	// Update vehicle in database
-	if err := h.vehicleRepo.Update(vehicleUUID, existingVehicle); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+	// Set optional int fields
+	if req.EngineCapacity > 0 {
+		vehicle.EngineCapacity = &req.EngineCapacity
+	}
+	if req.PowerHP > 0 {
+		vehicle.PowerHP = &req.PowerHP
+	}
+	if req.Kilometers > 0 {
+		vehicle.Kilometers = &req.Kilometers
+	}
+	if req.NumberOfKeys > 0 {
+		vehicle.NumberOfKeys = &req.NumberOfKeys
+	}
+
+	return vehicle, nil
+}
+
+// resolveEquipment looks up equipment by name. Values may hold several comma separated names; the result is
+// empty, not nil, when no names are given so callers can tell a cleared list from an omitted one.
+func (h *VehicleHandler) resolveEquipment(values []string) ([]models.Equipment, *requestError) {
+	names := parseEquipmentNames(values)
+	if len(names) == 0 {
+		return []models.Equipment{}, nil
+	}
+
+	equipment, unknown, err := h.equipmentRepo.FindByNames(names)
+	if err != nil {
+		return nil, &requestError{status: http.StatusInternalServerError, message: "Failed to retrieve equipment", err: err}
+	}
+	if len(unknown) > 0 {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Unknown equipment: " + strings.Join(unknown, ", ")}
+	}
+	return equipment, nil
+}
+
+// parseEquipmentNames splits repeated and comma, semicolon or pipe separated equipment names, lowercased and deduplicated
+func parseEquipmentNames(values []string) []string {
+	names := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, name := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '|' }) {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// vehiclePermissions reports whether a user may view and edit a vehicle, see vehicleAccess
+func (h *VehicleHandler) vehiclePermissions(vehicle *models.Vehicle, userID, roleID uint64) (bool, bool, error) {
+	return vehicleAccess(h.organizationRepo, vehicle, userID, roleID)
+}
+
+// vehicleAccess reports whether a user may view and edit a vehicle: its owner and admins can do both,
+// members of the vehicle's organization can view it and owners and managers can also edit it
+func vehicleAccess(organizationRepo *repository.OrganizationRepository, vehicle *models.Vehicle, userID, roleID uint64) (bool, bool, error) {
+	if vehicle.UserID == userID || roleID == middleware.AdminRoleID {
+		return true, true, nil
+	}
+	if vehicle.OrganizationID == nil {
+		return false, false, nil
+	}
+
+	role, err := organizationRepo.GetMemberRole(*vehicle.OrganizationID, userID)
+	if err != nil {
+		return false, false, err
+	}
+	return role != "", models.CanManageOrganization(role), nil
+}
+
+// resolveOrganization returns the ID of the organization with the given UUID, which the user must be a member of
+func (h *VehicleHandler) resolveOrganization(organizationUUID string, userID uint64) (*uint64, *requestError) {
+	org, err := h.organizationRepo.FindByUUID(organizationUUID)
+	if errors.Is(err, repository.ErrOrganizationNotFound) {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid organization_uuid value"}
+	}
+	if err != nil {
+		return nil, &requestError{status: http.StatusInternalServerError, message: "Failed to retrieve organization", err: err}
+	}
+
+	role, err := h.organizationRepo.GetMemberRole(org.ID, userID)
+	if err != nil {
+		return nil, &requestError{status: http.StatusInternalServerError, message: "Failed to retrieve organization", err: err}
+	}
+	if role == "" {
+		return nil, &requestError{status: http.StatusForbidden, message: "You are not a member of this organization"}
+	}
+	return &org.ID, nil
+}
+
+// applyVIN decodes a VIN and uses it to fill in a missing brand or year, or to cross-check
+// the values supplied by the seller. It returns the normalized VIN or a client error message.
+func applyVIN(value string, brand *string, year *int) (string, string) {
+	normalized := vin.Normalize(value)
+	decoded := vin.Decode(normalized)
+
+	if decoded.Brand != "" {
+		if *brand == "" {
+			*brand = decoded.Brand
+		} else if !sameBrand(*brand, decoded.Brand) {
+			return "", "VIN does not match brand: it belongs to a " + decoded.Brand
+		}
+	}
+
+	if decoded.ModelYear > 0 {
+		if *year == 0 {
+			*year = decoded.ModelYear
+		} else if decoded.CheckDigit && vin.RequiresCheckDigit(normalized) {
+			// Model year and production year may differ by one, only reject obvious mismatches
+			diff := *year - decoded.ModelYear
+			if diff > 1 || diff < -1 {
+				return "", "VIN does not match year: it encodes model year " + strconv.Itoa(decoded.ModelYear)
+			}
+		}
+	}
+
+	return normalized, ""
+}
+
+// sameBrand compares brand names loosely so that "Mercedes" matches "Mercedes-Benz" and "VW" matches "Volkswagen"
+func sameBrand(a, b string) bool {
+	slugA := catalog.BrandSlug(a)
+	slugB := catalog.BrandSlug(b)
+	return slugA == slugB || strings.HasPrefix(slugA, slugB) || strings.HasPrefix(slugB, slugA)
+}
+
+// maskVehicleVIN hides the serial part of the VIN before a vehicle is returned on a public endpoint
+func maskVehicleVIN(vehicle *models.Vehicle) {
+	if vehicle.VIN != nil {
+		masked := vin.Mask(*vehicle.VIN)
+		vehicle.VIN = &masked
+	}
+}
+
+// catalogLink is the result of validating a brand/model pair against the catalog
+type catalogLink struct {
+	brandID      *uint64
+	automobileID *uint64
+	brandName    string
+}
+
+// resolveCatalog validates brand and model input against the brand and automobile catalog.
+// The brand must exist (by id, slug or known alias); an explicit automobile id must belong to it.
+// A free-text model is linked when it matches a catalog automobile and kept as-is otherwise.
+// It returns a client error message for invalid input and an error for database failures.
+func (h *VehicleHandler) resolveCatalog(brandID uint64, brandName string, automobileID uint64, model string, year int) (*catalogLink, string, error) {
+	var brand *models.Brand
+	var err error
+	if brandID > 0 {
+		brand, err = h.brandRepo.FindByID(brandID)
+	} else {
+		brand, err = h.brandRepo.FindBySlug(catalog.BrandSlug(brandName))
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if brand == nil {
+		if brandID > 0 {
+			return nil, "Invalid brand_id value", nil
+		}
+		return nil, "Unknown brand: " + brandName, nil
+	}
+
+	link := &catalogLink{
+		brandID:   &brand.ID,
+		brandName: brand.Name,
+	}
+
+	if automobileID > 0 {
+		automobile, err := h.automobileRepo.FindByID(automobileID)
+		if err != nil {
+			return nil, "", err
+		}
+		if automobile == nil || automobile.BrandID != brand.ID {
+			return nil, "Invalid automobile_id value for brand " + brand.Name, nil
+		}
+		link.automobileID = &automobile.ID
+		return link, "", nil
+	}
+
+	automobiles, err := h.automobileRepo.GetByBrandID(brand.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if automobile, ok := catalog.MatchAutomobile(automobiles, brand.Name, model, year); ok {
+		link.automobileID = &automobile.ID
+	}
+
+	return link, "", nil
+}
+
+// vehicleSearch is a parsed public vehicle search
+type vehicleSearch struct {
+	params   repository.VehicleSearchParams
+	page     int
+	currency string // display currency, empty when no conversion was requested
+	rates    currency.Rates
+}
+
+// parseVehicleSearch reads the search, filter, sort and pagination query params shared by the public listing
+// endpoints. On invalid input it writes the error response and returns false.
+func (h *VehicleHandler) parseVehicleSearch(c *gin.Context) (*vehicleSearch, bool) {
+	// Parse query parameters
+	search := c.DefaultQuery("search", "")
+	brand := c.DefaultQuery("brand", "")
+	model := c.DefaultQuery("model", "")
+	fuelType := c.DefaultQuery("fuel_type", "")
+	bodyType := c.DefaultQuery("body_type", "")
+	transmission := c.DefaultQuery("transmission", "")
+	condition := c.DefaultQuery("condition", "")
+	city := c.DefaultQuery("city", "")
+
+	var minPrice, maxPrice float64
+	if minPriceStr := c.Query("min_price"); minPriceStr != "" {
+		if val, err := strconv.ParseFloat(minPriceStr, 64); err == nil {
+			minPrice = val
+		}
+	}
+	if maxPriceStr := c.Query("max_price"); maxPriceStr != "" {
+		if val, err := strconv.ParseFloat(maxPriceStr, 64); err == nil {
+			maxPrice = val
+		}
+	}
+
+	var minYear, maxYear int
+	if minYearStr := c.Query("min_year"); minYearStr != "" {
+		if val, err := strconv.Atoi(minYearStr); err == nil {
+			minYear = val
+		}
+	}
+	if maxYearStr := c.Query("max_year"); maxYearStr != "" {
+		if val, err := strconv.Atoi(maxYearStr); err == nil {
+			maxYear = val
+		}
+	}
+
+	// Parse pagination parameters
+	page := 1
+	if pageStr := c.DefaultQuery("page", "1"); pageStr != "" {
+		if val, err := strconv.Atoi(pageStr); err == nil && val > 0 {
+			page = val
+		}
+	}
+
+	limit := 20
+	if limitStr := c.DefaultQuery("limit", "20"); limitStr != "" {
+		if val, err := strconv.Atoi(limitStr); err == nil && val > 0 {
+			limit = val
+			if limit > 100 {
+				limit = 100 // Max limit
+			}
+		}
+	}
+
+	offset := (page - 1) * limit
+
+	sort := c.DefaultQuery("sort", repository.VehicleSortNewest)
+	if sort != repository.VehicleSortNewest && sort != repository.VehicleSortPriceAsc && sort != repository.VehicleSortPriceDesc {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "error",
-			"message": "Failed to update vehicle",
-			"error":   err.Error(),
+			"message": "Invalid sort value, must be one of: newest, price_asc, price_desc",
 		})
-		return
+		return nil, false
+	}
+
+	// Price filters are given in the requested currency and compared in the base currency
+	displayCurrency, rates, ok := parseDisplayCurrency(c, h.exchangeRateRepo)
+	if !ok {
+		return nil, false
+	}
+	if displayCurrency != "" {
+		minPrice, _ = rates.ToBase(minPrice, displayCurrency)
+		maxPrice, _ = rates.ToBase(maxPrice, displayCurrency)
+	}
+
+	// Resolve brand filter by catalog id or slug
+	var brandID uint64
+	if brand != "" {
+		var catalogBrand *models.Brand
+		var err error
+		if id, parseErr := strconv.ParseUint(brand, 10, 64); parseErr == nil {
+			catalogBrand, err = h.brandRepo.FindByID(id)
+		} else {
+			catalogBrand, err = h.brandRepo.FindBySlug(catalog.BrandSlug(brand))
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Failed to retrieve brand",
+				"error":   err.Error(),
+			})
+			return nil, false
+		}
+		if catalogBrand != nil {
+			brandID = catalogBrand.ID
+			brand = catalogBrand.Name
+		}
+	}
+
+	// Resolve equipment filter; vehicles must have every selected item
+	var equipmentIDs []uint16
+	if value := c.Query("equipment"); value != "" {
+		equipment, reqErr := h.resolveEquipment([]string{value})
+		if reqErr != nil {
+			reqErr.respond(c)
+			return nil, false
+		}
+		for _, item := range equipment {
+			equipmentIDs = append(equipmentIDs, item.ID)
+		}
+	}
+
+	return &vehicleSearch{
+		params: repository.VehicleSearchParams{
+			Search:       search,
+			BrandID:      brandID,
+			Brand:        brand,
+			Model:        model,
+			FuelType:     fuelType,
+			BodyType:     bodyType,
+			Transmission: transmission,
+			Condition:    condition,
+			MinPrice:     minPrice,
+			MaxPrice:     maxPrice,
+			MinYear:      minYear,
+			MaxYear:      maxYear,
+			City:         city,
+			Sort:         sort,
+			Limit:        limit,
+			Offset:       offset,
+			EquipmentIDs: equipmentIDs,
+		},
+		page:     page,
+		currency: displayCurrency,
+		rates:    rates,
+	}, true
+}
+
+// parseDisplayCurrency reads the optional currency query param and loads the exchange rates to convert into it.
+// On invalid input it writes the error response and returns false.
+func parseDisplayCurrency(c *gin.Context, exchangeRateRepo *repository.ExchangeRateRepository) (string, currency.Rates, bool) {
+	code := c.Query("currency")
+	if code == "" {
+		return "", nil, true
 	}
 
-	// Fetch updated vehicle
-	updatedVehicle, err := h.vehicleRepo.GetByUUID(vehicleUUID)
+	code = models.NormalizeCurrency(code)
+	if !models.IsSupportedCurrency(code) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid currency value, must be one of: " + strings.Join(models.SupportedCurrencies, ", "),
+		})
+		return "", nil, false
+	}
+
+	rates, err := exchangeRateRepo.GetRates()
 	if err != nil {
-		updatedVehicle = existingVehicle // Fallback
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve exchange rates",
+			"error":   err.Error(),
+		})
+		return "", nil, false
+	}
+	if _, ok := rates[code]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "No exchange rate available for " + code,
+		})
+		return "", nil, false
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "success",
-		"message": "Vehicle updated successfully",
-		"data":    updatedVehicle,
-	})
+	return code, rates, true
+}
+
+// convertVehiclePrice fills the converted price when a display currency was requested and the rate is known
+func convertVehiclePrice(vehicle *models.Vehicle, target string, rates currency.Rates) {
+	if target == "" {
+		return
+	}
+	if converted, ok := rates.Convert(vehicle.Price, vehicle.Currency, target); ok {
+		vehicle.ConvertedPrice = &converted
+		vehicle.ConvertedCurrency = target
+	}
 }
@@ -0,0 +1,36 @@
+package handlers
+
+import "testing"
+
+func TestApplyVINBrand(t *testing.T) {
+	tests := []struct {
+		vin     string
+		brand   string
+		wantErr bool
+	}{
+		{"WVWZZZ1JZYW000001", "Volkswagen", false},
+		{"WVWZZZ1JZYW000001", "VW", false},
+		{"1G1ZD5ST1JF000001", "Chevy", false},
+		{"1G1ZD5ST1JF000001", "chevrolet", false},
+		{"WDD2050071F000001", "Mercedes", false},
+		{"WVWZZZ1JZYW000001", "BMW", true},
+		{"1G1ZD5ST1JF000001", "Ford", true},
+	}
+	for _, tt := range tests {
+		brand, year := tt.brand, 0
+		_, msg := applyVIN(tt.vin, &brand, &year)
+		if (msg != "") != tt.wantErr {
+			t.Errorf("applyVIN(%s) with brand %q: error %q, want error %v", tt.vin, tt.brand, msg, tt.wantErr)
+		}
+	}
+}
+
+func TestApplyVINFillsBrand(t *testing.T) {
+	brand, year := "", 0
+	if _, msg := applyVIN("1G1ZD5ST1JF000001", &brand, &year); msg != "" {
+		t.Fatalf("applyVIN: unexpected error %q", msg)
+	}
+	if brand != "Chevrolet" || year != 2018 {
+		t.Errorf("applyVIN filled brand %q and year %d, want Chevrolet and 2018", brand, year)
+	}
+}
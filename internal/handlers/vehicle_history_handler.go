@@ -0,0 +1,415 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"autoelys_backend/internal/audit"
+	"autoelys_backend/internal/middleware"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// UpdateVehicleStatusRequest represents the vehicle status change payload
+// @Description Vehicle status change
+type UpdateVehicleStatusRequest struct {
+	Status string `form:"status" json:"status" validate:"required,oneof=active inactive banned" example:"inactive"`
+}
+
+// VehicleHistoryEntry is an audit log entry as shown to the vehicle owner, without the identity and IP of the actor
+// @Description Vehicle history entry
+type VehicleHistoryEntry struct {
+	Action    string         `json:"action" example:"update"`
+	ActorRole string         `json:"actor_role" example:"owner"`
+	Changes   []audit.Change `json:"changes"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// vehicleAuditIgnored are the vehicle fields left out of the audit log: identifiers, the version, computed values, promotion windows and
+// lookup IDs, which are audited through their names
+var vehicleAuditIgnored = []string{
+	"id", "user_id", "uuid", "status_name", "score", "created_at", "updated_at", "version", "converted_price", "converted_currency",
+	"person_type_id", "brand_id", "automobile_id", "fuel_type_id", "body_type_id", "condition_id", "transmission_id", "steering_id",
+	"images", "equipment", "promoted_until", "highlighted_until",
+}
+
+// vehicleAuditFields returns the audited fields of a vehicle, with the status, images and equipment by name
+func vehicleAuditFields(vehicle *models.Vehicle) map[string]interface{} {
+	fields := audit.Fields(vehicle, vehicleAuditIgnored...)
+	fields["status"] = models.GetStatusName(vehicle.Status)
+
+	images := make([]string, 0, len(vehicle.Images))
+	for _, image := range vehicle.Images {
+		images = append(images, image.ImageURL)
+	}
+	fields["images"] = images
+
+	equipment := make([]string, 0, len(vehicle.Equipment))
+	for _, item := range vehicle.Equipment {
+		equipment = append(equipment, item.Name)
+	}
+	fields["equipment"] = equipment
+
+	return fields
+}
+
+// vehicleActor is the user changing a vehicle, as recorded in its audit log
+type vehicleActor struct {
+	userID uint64
+	roleID uint64
+	ip     string
+}
+
+func newVehicleActor(c *gin.Context) vehicleActor {
+	actor := vehicleActor{ip: c.ClientIP()}
+	if userID, exists := c.Get("user_id"); exists {
+		actor.userID = userID.(uint64)
+	}
+	if roleID, exists := c.Get("role_id"); exists {
+		actor.roleID = roleID.(uint64)
+	}
+	return actor
+}
+
+// role returns how the actor relates to the vehicle
+func (a vehicleActor) role(vehicle *models.Vehicle) string {
+	switch {
+	case vehicle.UserID == a.userID:
+		return models.VehicleAuditActorOwner
+	case a.roleID == middleware.AdminRoleID:
+		return models.VehicleAuditActorAdmin
+	default:
+		return models.VehicleAuditActorMember
+	}
+}
+
+// auditVehicleTx records the changes between the audited fields of a vehicle before the change (nil for a
+// new vehicle) and the vehicle as saved by tx, the transaction making the change, so the change is rolled
+// back when it cannot be audited. It returns the saved vehicle with its lookup names, images and equipment.
+func (h *VehicleHandler) auditVehicleTx(tx *repository.Tx, actor vehicleActor, action string, before map[string]interface{}, vehicleUUID string) (*models.Vehicle, error) {
+	vehicle, err := h.vehicleRepo.GetByUUIDTx(tx, vehicleUUID)
+	if err != nil {
+		return nil, err
+	}
+	if vehicle == nil {
+		return nil, repository.ErrVehicleNotFound
+	}
+
+	changes := audit.Diff(before, vehicleAuditFields(vehicle))
+	if len(changes) == 0 {
+		return vehicle, nil
+	}
+
+	entry := &models.VehicleAuditLog{
+		VehicleID: vehicle.ID,
+		ActorRole: actor.role(vehicle),
+		Action:    action,
+		Changes:   changes,
+	}
+	if actor.userID > 0 {
+		entry.ActorID = &actor.userID
+	}
+	if actor.ip != "" {
+		entry.IPAddress = &actor.ip
+	}
+
+	if err := h.vehicleAuditRepo.CreateTx(tx, entry); err != nil {
+		return nil, err
+	}
+	return vehicle, nil
+}
+
+// editableVehicle loads the vehicle of the :uuid path parameter and checks that the authenticated user may
+// edit it. It responds and returns false otherwise.
+func (h *VehicleHandler) editableVehicle(c *gin.Context) (*models.Vehicle, vehicleActor, bool) {
+	actor := newVehicleActor(c)
+
+	vehicle, err := h.vehicleRepo.GetByUUID(c.Param("uuid"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicle",
+			"error":   err.Error(),
+		})
+		return nil, actor, false
+	}
+	if vehicle == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Vehicle not found",
+		})
+		return nil, actor, false
+	}
+
+	_, canEdit, err := h.vehiclePermissions(vehicle, actor.userID, actor.roleID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to check permissions",
+			"error":   err.Error(),
+		})
+		return nil, actor, false
+	}
+	if !canEdit {
+		c.JSON(http.StatusForbidden, gin.H{
+			"status":  "error",
+			"message": "You don't have permission to update this vehicle",
+		})
+		return nil, actor, false
+	}
+
+	return vehicle, actor, true
+}
+
+// UpdateVehicleStatus godoc
+// @Summary Change the status of a vehicle (Owner/Admin only)
+// @Description Activate or deactivate a listing. Only admins can ban a listing or change the status of a banned one. Requires the ETag of the vehicle version in If-Match. The change is recorded in the vehicle history.
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Param uuid path string true "Vehicle UUID"
+// @Param request body UpdateVehicleStatusRequest true "New status (active, inactive, banned)"
+// @Param If-Match header string true "ETag of the vehicle version being updated"
+// @Success 200 {object} map[string]interface{} "Vehicle status updated successfully"
+// @Failure 400 {object} map[string]interface{} "Validation error"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Not owner or admin, or the vehicle is banned"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 412 {object} map[string]interface{} "Vehicle modified since it was read, with current_version and current_etag"
+// @Failure 428 {object} map[string]interface{} "If-Match header missing"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/{uuid}/status [put]
+// @Security BearerAuth
+func (h *VehicleHandler) UpdateVehicleStatus(c *gin.Context) {
+	var req UpdateVehicleStatusRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid request data",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Validation failed",
+			"errors":  utils.FormatValidationErrorsSimple(err.(validator.ValidationErrors)),
+		})
+		return
+	}
+	status, _ := models.ParseVehicleStatus(req.Status)
+
+	vehicle, actor, ok := h.editableVehicle(c)
+	if !ok {
+		return
+	}
+
+	if vehicle.Status == models.VehicleStatusDraft {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "This vehicle is a draft, publish it with POST /api/user/vehicles/drafts/{uuid}/publish",
+		})
+		return
+	}
+
+	if actor.roleID != middleware.AdminRoleID && (status == models.VehicleStatusBanned || vehicle.Status == models.VehicleStatusBanned) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"status":  "error",
+			"message": "Only administrators can ban a vehicle or change the status of a banned vehicle",
+		})
+		return
+	}
+
+	if precondition := checkIfMatch(c, "vehicle", vehicle.Version); precondition != nil {
+		precondition.respond(c, gin.H{
+			"status":  "error",
+			"message": precondition.message,
+		})
+		return
+	}
+
+	if vehicle.Status != status {
+		before := vehicleAuditFields(vehicle)
+		err := h.transactor.WithTx(func(tx *repository.Tx) error {
+			if err := h.vehicleRepo.UpdateStatusTx(tx, vehicle.ID, vehicle.Version, vehicle.Status, status); err != nil {
+				return err
+			}
+			var err error
+			vehicle, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionStatus, before, vehicle.UUID)
+			return err
+		})
+		if precondition := versionConflict("vehicle", err); precondition != nil {
+			precondition.respond(c, gin.H{
+				"status":  "error",
+				"message": precondition.message,
+			})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Failed to update vehicle status",
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	setVersionETag(c, vehicle.Version)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Vehicle status updated successfully",
+		"data":    vehicle,
+	})
+}
+
+// GetVehicleHistory godoc
+// @Summary Get the change history of a vehicle (Owner/Admin only)
+// @Description Get the recorded creation, edits and status changes of a vehicle, newest first, with the old and new value of every changed field. Whether a change was made by the owner, an organization member or an admin is shown, but not who made it or from where.
+// @Tags vehicles
+// @Produce json
+// @Param uuid path string true "Vehicle UUID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Vehicle history"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Not owner or admin"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/{uuid}/history [get]
+// @Security BearerAuth
+func (h *VehicleHandler) GetVehicleHistory(c *gin.Context) {
+	vehicle, _, ok := h.editableVehicle(c)
+	if !ok {
+		return
+	}
+
+	page, limit := parsePagination(c)
+	entries, total, err := h.vehicleAuditRepo.GetAll(repository.VehicleAuditSearchParams{
+		VehicleID: vehicle.ID,
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicle history",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	history := make([]VehicleHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		history = append(history, VehicleHistoryEntry{
+			Action:    entry.Action,
+			ActorRole: entry.ActorRole,
+			Changes:   entry.Changes,
+			CreatedAt: entry.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   history,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// GetVehicleAuditLogs godoc
+// @Summary Get the vehicle audit log (Admin only)
+// @Description Get recorded vehicle creations, edits and status changes, newest first, with the acting user, their IP and the old and new value of every changed field. Filter by vehicle, actor or IP to investigate a listing or a user.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle_uuid query string false "Only changes of this vehicle"
+// @Param actor_id query int false "Only changes made by this user ID"
+// @Param ip query string false "Only changes made from this IP address"
+// @Param action query string false "Only this action (create, update, status)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Audit log entries"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/admin/vehicle-audit-logs [get]
+func (h *VehicleHandler) GetVehicleAuditLogs(c *gin.Context) {
+	page, limit := parsePagination(c)
+	params := repository.VehicleAuditSearchParams{
+		IPAddress: c.Query("ip"),
+		Action:    c.Query("action"),
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+	}
+
+	if params.Action != "" && !models.IsValidVehicleAuditAction(params.Action) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Invalid action. Allowed: create, update, status",
+		})
+		return
+	}
+
+	if actorID := c.Query("actor_id"); actorID != "" {
+		id, err := strconv.ParseUint(actorID, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "Invalid actor_id value",
+			})
+			return
+		}
+		params.ActorID = id
+	}
+
+	if vehicleUUID := c.Query("vehicle_uuid"); vehicleUUID != "" {
+		vehicle, err := h.vehicleRepo.GetByUUID(vehicleUUID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "Failed to retrieve vehicle",
+				"error":   err.Error(),
+			})
+			return
+		}
+		if vehicle == nil {
+			c.JSON(http.StatusNotFound, gin.H{
+				"status":  "error",
+				"message": "Vehicle not found",
+			})
+			return
+		}
+		params.VehicleID = vehicle.ID
+	}
+
+	entries, total, err := h.vehicleAuditRepo.GetAll(params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve audit logs",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   entries,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"autoelys_backend/internal/importer"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// Import row outcomes
+const (
+	ImportActionCreated = "created"
+	ImportActionUpdated = "updated"
+	ImportActionFailed  = "failed"
+)
+
+// ImportRowResult is the outcome of one listing of a bulk import
+type ImportRowResult struct {
+	Line        int               `json:"line"`
+	ExternalID  string            `json:"external_id,omitempty"`
+	Action      string            `json:"action"`
+	VehicleUUID string            `json:"vehicle_uuid,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// ImportVehicles godoc
+// @Summary Bulk import vehicle listings (Authenticated users only)
+// @Description Import up to 500 listings from a CSV or XML feed. Columns (or XML elements) are named like the create vehicle form fields, e.g. external_id, title, brand, model, price, currency, fuel_type, body_type, year; common aliases such as make, mileage or gearbox are accepted. Every listing needs a dealer-supplied external_id: re-importing a listing with the same external_id updates it instead of creating a duplicate. Images are given in the images column as http(s) URLs or as file names from the uploaded ZIP archive, separated by "|"; when images are given for an existing listing they replace its images. At most 100 image URLs are downloaded per import, and rows not reached within the one minute time limit are reported failed, to be imported again. Equipment names (see GET /api/equipment) go in the equipment column, separated by "|". person_type defaults to firma. Each row is validated like a single vehicle creation and the response reports the outcome of every row.
+// @Tags vehicles
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XML feed"
+// @Param format formData string false "Feed format (csv, xml), detected from the file extension by default"
+// @Param images_zip formData file false "ZIP archive with the images referenced by the feed"
+// @Param dry_run formData boolean false "Validate the feed without saving anything (default: false)"
+// @Param organization_uuid formData string false "UUID of a dealer organization the user is a member of, to list the vehicles for it"
+// @Success 200 {object} map[string]interface{} "Import report"
+// @Failure 400 {object} map[string]interface{} "Invalid feed"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Authentication required"
+// @Router /api/user/vehicles/import [post]
+// @Security BearerAuth
+func (h *VehicleHandler) ImportVehicles(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "A CSV or XML file is required",
+		})
+		return
+	}
+
+	format := importer.Format(strings.ToLower(c.PostForm("format")))
+	if format == "" {
+		format = importer.Format(strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), "."))
+	}
+	if format != importer.FormatCSV && format != importer.FormatXML {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Unsupported file format. Allowed: csv, xml",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Failed to read the uploaded file",
+			"error":   err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	rows, err := importer.Parse(format, file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Failed to parse the uploaded file",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Optional ZIP archive with the images referenced by file name
+	var archive *zip.Reader
+	if zipHeader, err := c.FormFile("images_zip"); err == nil {
+		zipFile, err := zipHeader.Open()
+		if err == nil {
+			defer zipFile.Close()
+			archive, err = zip.NewReader(zipFile, zipHeader.Size)
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "Invalid images ZIP archive",
+				"error":   err.Error(),
+			})
+			return
+		}
+	}
+
+	dryRun, _ := strconv.ParseBool(c.PostForm("dry_run"))
+
+	var organizationID *uint64
+	if organizationUUID := c.PostForm("organization_uuid"); organizationUUID != "" {
+		var reqErr *requestError
+		organizationID, reqErr = h.resolveOrganization(organizationUUID, userID.(uint64))
+		if reqErr != nil {
+			reqErr.respond(c)
+			return
+		}
+	}
+
+	// Rows left when the time limit is reached are reported failed; importing the feed again picks them up
+	// by their external_id
+	ctx, cancel := context.WithTimeout(c.Request.Context(), importer.Timeout)
+	defer cancel()
+
+	images := importer.NewImages(ctx, archive, "./uploads/vehicles")
+	actor := newVehicleActor(c)
+
+	results := make([]ImportRowResult, 0, len(rows))
+	counts := map[string]int{ImportActionCreated: 0, ImportActionUpdated: 0, ImportActionFailed: 0}
+	seen := make(map[string]int)
+	for _, row := range rows {
+		result := ImportRowResult{Line: row.Line, ExternalID: row.Get("external_id")}
+		if line, ok := seen[result.ExternalID]; ok && result.ExternalID != "" {
+			result.Action = ImportActionFailed
+			result.Message = fmt.Sprintf("Duplicate external_id, already used on line %d", line)
+		} else if ctx.Err() != nil {
+			result.Action = ImportActionFailed
+			result.Message = "Not imported, the import time limit was reached"
+		} else {
+			seen[result.ExternalID] = row.Line
+			h.importRow(actor, organizationID, row, images, dryRun, &result)
+		}
+
+		counts[result.Action]++
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Import processed",
+		"data": gin.H{
+			"dry_run": dryRun,
+			"total":   len(rows),
+			"created": counts[ImportActionCreated],
+			"updated": counts[ImportActionUpdated],
+			"failed":  counts[ImportActionFailed],
+			"rows":    results,
+		},
+	})
+}
+
+// importRow validates one import row like CreateVehicle and creates the listing, or updates the user's
+// listing with the same external ID. The outcome is recorded in result.
+func (h *VehicleHandler) importRow(actor vehicleActor, organizationID *uint64, row importer.Row, images *importer.Images, dryRun bool, result *ImportRowResult) {
+	result.Action = ImportActionFailed
+
+	if result.ExternalID == "" {
+		result.Message = "external_id is required"
+		return
+	}
+	if len(result.ExternalID) > 100 {
+		result.Message = "external_id must be at most 100 characters"
+		return
+	}
+
+	var req CreateVehicleRequest
+	if err := binding.MapFormWithTag(&req, row.Values, "form"); err != nil {
+		result.Message = "Invalid row data: " + err.Error()
+		return
+	}
+	if req.PersonType == "" {
+		req.PersonType = "firma"
+	}
+
+	vehicle, reqErr := h.buildVehicle(&req)
+	if reqErr != nil {
+		result.Message = reqErr.message
+		result.Errors = reqErr.errors
+		return
+	}
+	vehicle.OrganizationID = organizationID
+
+	existingUUID, err := h.vehicleRepo.FindUUIDByExternalID(actor.userID, result.ExternalID)
+	if err != nil {
+		result.Message = "Failed to look up the existing listing"
+		return
+	}
+	action := ImportActionCreated
+	if existingUUID != "" {
+		action = ImportActionUpdated
+	}
+
+	refs := row.ImageRefs()
+	if len(refs) > utils.MaxImagesPerVehicle {
+		result.Message = fmt.Sprintf("Maximum %d images allowed", utils.MaxImagesPerVehicle)
+		return
+	}
+
+	if dryRun {
+		result.Action = action
+		result.VehicleUUID = existingUUID
+		return
+	}
+
+	imagePaths, err := images.Save(refs)
+	if err != nil {
+		result.Message = "Failed to import images: " + err.Error()
+		return
+	}
+
+	// The new images are deleted when the transaction rolls back, or here when it could not start
+	started := false
+	err = h.transactor.WithTx(func(tx *repository.Tx) error {
+		started = true
+		tx.OnRollback(func() { deleteFiles(imagePaths) })
+		if existingUUID != "" {
+			return h.updateImportedVehicle(tx, actor, existingUUID, vehicle, imagePaths)
+		}
+		return h.createImportedVehicle(tx, actor, result.ExternalID, vehicle, imagePaths)
+	})
+	if err != nil {
+		if !started {
+			deleteFiles(imagePaths)
+		}
+		result.Message = "Failed to save the listing: " + err.Error()
+		return
+	}
+
+	result.Action = action
+	result.VehicleUUID = vehicle.UUID
+}
+
+// createImportedVehicle creates a listing with its images and equipment within tx and audits the creation
+func (h *VehicleHandler) createImportedVehicle(tx *repository.Tx, actor vehicleActor, externalID string, vehicle *models.Vehicle, imagePaths []string) error {
+	slug, err := h.uniqueSlug(vehicle.Title)
+	if err != nil {
+		return err
+	}
+
+	vehicle.UserID = actor.userID
+	vehicle.ExternalID = &externalID
+	vehicle.UUID = uuid.New().String()
+	vehicle.Slug = slug
+
+	createdVehicle, err := h.vehicleRepo.CreateTx(tx, vehicle)
+	if err != nil {
+		return err
+	}
+
+	for _, imagePath := range imagePaths {
+		if err := h.vehicleRepo.CreateImageTx(tx, createdVehicle.ID, imagePath); err != nil {
+			return err
+		}
+	}
+	if len(imagePaths) > 0 {
+		if err := h.vehicleRepo.SetFeaturedImageTx(tx, createdVehicle.UUID, imagePaths[0]); err != nil {
+			return err
+		}
+	}
+	if len(vehicle.Equipment) > 0 {
+		if err := h.vehicleRepo.SetEquipmentTx(tx, createdVehicle.ID, vehicle.Equipment); err != nil {
+			return err
+		}
+	}
+
+	_, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionCreate, nil, createdVehicle.UUID)
+	return err
+}
+
+// updateImportedVehicle overwrites an existing listing with the imported values within tx. When images are
+// given they replace the listing's images, and the old files are deleted once tx commits.
+func (h *VehicleHandler) updateImportedVehicle(tx *repository.Tx, actor vehicleActor, vehicleUUID string, vehicle *models.Vehicle, imagePaths []string) error {
+	existingVehicle, err := h.vehicleRepo.GetByUUID(vehicleUUID)
+	if err != nil {
+		return err
+	}
+	if existingVehicle == nil {
+		return fmt.Errorf("vehicle %s not found", vehicleUUID)
+	}
+	before := vehicleAuditFields(existingVehicle)
+
+	vehicle.ID = existingVehicle.ID
+	if vehicle.OrganizationID == nil {
+		vehicle.OrganizationID = existingVehicle.OrganizationID
+	}
+	vehicle.UUID = existingVehicle.UUID
+	vehicle.Slug = existingVehicle.Slug
+	vehicle.Version = existingVehicle.Version
+	if vehicle.Title != existingVehicle.Title {
+		if vehicle.Slug, err = h.uniqueSlug(vehicle.Title); err != nil {
+			return err
+		}
+	}
+
+	if err := h.vehicleRepo.UpdateTx(tx, vehicleUUID, vehicle); err != nil {
+		return err
+	}
+	if vehicle.Equipment != nil {
+		if err := h.vehicleRepo.SetEquipmentTx(tx, vehicle.ID, vehicle.Equipment); err != nil {
+			return err
+		}
+	}
+	if len(imagePaths) > 0 {
+		oldImages, err := h.vehicleRepo.DeleteImagesByVehicleIDTx(tx, vehicle.ID)
+		if err != nil {
+			return err
+		}
+		for _, imagePath := range imagePaths {
+			if err := h.vehicleRepo.CreateImageTx(tx, vehicle.ID, imagePath); err != nil {
+				return err
+			}
+		}
+		if err := h.vehicleRepo.SetFeaturedImageTx(tx, vehicleUUID, imagePaths[0]); err != nil {
+			return err
+		}
+		tx.OnCommit(func() { deleteFiles(oldImages) })
+	}
+
+	_, err = h.auditVehicleTx(tx, actor, models.VehicleAuditActionUpdate, before, vehicleUUID)
+	return err
+}
+
+// deleteFiles removes uploaded files, ignoring the ones already gone
+func deleteFiles(paths []string) {
+	for _, path := range paths {
+		_ = utils.DeleteFile(path)
+	}
+}
+
+// uniqueSlug generates a slug from the title, suffixed with -2, -3, ... when it is already taken
+func (h *VehicleHandler) uniqueSlug(title string) (string, error) {
+	base := utils.GenerateSlug(title)
+	slug := base
+	for n := 2; ; n++ {
+		exists, err := h.vehicleRepo.SlugExists(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
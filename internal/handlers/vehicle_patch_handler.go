@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"autoelys_backend/internal/patch"
+	"autoelys_backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// PatchVehicleRequest is a JSON Merge Patch of a vehicle. Members left out are unchanged, null clears the
+// fields tagged nullable and is rejected for the others, and the rules of each member sent are checked.
+// UploadIDs adds completed direct uploads to the images of the vehicle.
+type PatchVehicleRequest struct {
+	Title            patch.Field[string]   `json:"title" validate:"omitnil,required,min=5,max=255"`
+	Category         patch.Field[string]   `json:"category" validate:"omitnil,required,max=100"`
+	Description      patch.Field[string]   `json:"description" patch:"nullable"`
+	Price            patch.Field[float64]  `json:"price" validate:"omitnil,gt=0"`
+	Currency         patch.Field[string]   `json:"currency" validate:"omitnil,required,currency"`
+	Negotiable       patch.Field[bool]     `json:"negotiable"`
+	PersonType       patch.Field[string]   `json:"person_type" validate:"omitnil,oneof=persoana_fizica firma"`
+	BrandID          patch.Field[uint64]   `json:"brand_id" validate:"omitnil,gt=0"`
+	Brand            patch.Field[string]   `json:"brand" validate:"omitnil,required,max=100"`
+	AutomobileID     patch.Field[uint64]   `json:"automobile_id" validate:"omitnil,gt=0"`
+	Model            patch.Field[string]   `json:"model" validate:"omitnil,required,max=100"`
+	VIN              patch.Field[string]   `json:"vin" patch:"nullable" validate:"omitnil,vin"`
+	EngineCapacity   patch.Field[int]      `json:"engine_capacity" patch:"nullable" validate:"omitnil,gt=0"`
+	PowerHP          patch.Field[int]      `json:"power_hp" patch:"nullable" validate:"omitnil,gt=0"`
+	FuelType         patch.Field[string]   `json:"fuel_type" validate:"omitnil,oneof=benzina motorina electric hibrid gpl hybrid_benzina hybrid_motorina"`
+	BodyType         patch.Field[string]   `json:"body_type" validate:"omitnil,oneof=sedan suv break coupe cabrio hatchback pickup van monovolum"`
+	Kilometers       patch.Field[int]      `json:"kilometers" patch:"nullable" validate:"omitnil,min=0"`
+	Color            patch.Field[string]   `json:"color" patch:"nullable" validate:"omitnil,max=50"`
+	Year             patch.Field[int]      `json:"year" validate:"omitnil,min=1970,max=2030"`
+	NumberOfKeys     patch.Field[int]      `json:"number_of_keys" patch:"nullable" validate:"omitnil,min=0"`
+	Condition        patch.Field[string]   `json:"condition" validate:"omitnil,oneof=utilizat nou"`
+	Transmission     patch.Field[string]   `json:"transmission" validate:"omitnil,oneof=manuala automata"`
+	Steering         patch.Field[string]   `json:"steering" validate:"omitnil,oneof=stanga dreapta"`
+	Registered       patch.Field[bool]     `json:"registered"`
+	City             patch.Field[string]   `json:"city" validate:"omitnil,required,max=100"`
+	ContactName      patch.Field[string]   `json:"contact_name" validate:"omitnil,required,max=255"`
+	Email            patch.Field[string]   `json:"email" validate:"omitnil,required,email"`
+	Phone            patch.Field[string]   `json:"phone" patch:"nullable" validate:"omitnil,max=20"`
+	OrganizationUUID patch.Field[string]   `json:"organization_uuid" patch:"nullable" validate:"omitnil,required"`
+	Equipment        patch.Field[[]string] `json:"equipment" patch:"nullable"`
+	UploadIDs        patch.Field[[]string] `json:"upload_ids" validate:"omitnil,max=8,unique,dive,uuid"`
+}
+
+// PatchVehicle godoc
+// @Summary Partially update vehicle by UUID
+// @Description Update a vehicle listing with a JSON Merge Patch (RFC 7396): only the members sent are changed, and null clears description, vin, engine_capacity, power_hp, kilometers, color, number_of_keys, phone, organization_uuid (moving the vehicle out of its organization) and equipment. Other members cannot be null. The members are the fields of PUT /api/user/vehicles/{uuid}, with booleans and numbers as JSON values and equipment as an array of names. upload_ids adds completed uploads from POST /api/user/uploads to the vehicle images (max 8 images in total). Requires the ETag of the version being updated in If-Match, like PUT.
+// @Tags vehicles
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param uuid path string true "Vehicle UUID"
+// @Param patch body map[string]interface{} true "Merge patch, e.g. {\"kilometers\": 0, \"color\": null, \"negotiable\": true}"
+// @Param If-Match header string true "ETag of the vehicle version being updated"
+// @Success 200 {object} map[string]interface{} "Vehicle updated successfully"
+// @Header 200 {string} ETag "New version of the vehicle"
+// @Failure 400 {object} map[string]interface{} "Invalid merge patch or validation error"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Not owner or admin"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 412 {object} map[string]interface{} "Vehicle modified since it was read, with current_version and current_etag"
+// @Failure 415 {object} map[string]interface{} "Unsupported content type"
+// @Failure 428 {object} map[string]interface{} "If-Match header missing"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/user/vehicles/{uuid} [patch]
+// @Security BearerAuth
+func (h *VehicleHandler) PatchVehicle(c *gin.Context) {
+	h.updateVehicle(c, h.bindVehiclePatch)
+}
+
+// bindVehiclePatch decodes and validates the merge patch in the request body
+func (h *VehicleHandler) bindVehiclePatch(c *gin.Context) (*PatchVehicleRequest, *requestError) {
+	if contentType := c.ContentType(); contentType != patch.ContentType && contentType != gin.MIMEJSON {
+		return nil, &requestError{
+			status:  http.StatusUnsupportedMediaType,
+			message: "Content-Type must be " + patch.ContentType + " or " + gin.MIMEJSON,
+		}
+	}
+
+	var req PatchVehicleRequest
+	if err := patch.Decode(c.Request.Body, &req); err != nil {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Invalid merge patch", err: err}
+	}
+
+	fieldErrors := make(map[string]string)
+	if err := h.validator.Struct(req); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return nil, &requestError{status: http.StatusInternalServerError, message: "Failed to validate merge patch", err: err}
+		}
+		fieldErrors = utils.FormatValidationErrorsSimple(validationErrors)
+	}
+	for _, field := range patch.NullFields(&req) {
+		fieldErrors[field] = field + " cannot be null"
+	}
+	if len(fieldErrors) > 0 {
+		return nil, &requestError{status: http.StatusBadRequest, message: "Validation failed", errors: fieldErrors}
+	}
+	return &req, nil
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"autoelys_backend/internal/seo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// siteName is the public site name used in page titles and feeds
+const siteName = "AutoElys"
+
+// seoOptions returns the options of the listing page metadata
+func (h *VehicleHandler) seoOptions() seo.Options {
+	return seo.Options{
+		SiteURL:  h.feedOptions.SiteURL,
+		MediaURL: h.feedOptions.MediaURL,
+		SiteName: siteName,
+		Locale:   "ro_RO",
+	}
+}
+
+// includesSEO reports whether the comma separated include query param asks for the page metadata
+func includesSEO(c *gin.Context) bool {
+	for _, value := range strings.Split(c.Query("include"), ",") {
+		if strings.TrimSpace(value) == "seo" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetVehicleSEO godoc
+// @Summary Get the page metadata of a vehicle (Public)
+// @Description Page metadata of a listing derived from the vehicle: the canonical URL, title and description, schema.org Car JSON-LD with an Offer, and Open Graph and Twitter card meta tags. Also returned by GET /api/vehicles/{slug} with include=seo.
+// @Tags vehicles
+// @Produce json
+// @Param slug path string true "Vehicle slug (SEO-friendly URL identifier)"
+// @Success 200 {object} map[string]interface{} "Page metadata"
+// @Failure 404 {object} map[string]interface{} "Vehicle not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/vehicles/{slug}/seo [get]
+func (h *VehicleHandler) GetVehicleSEO(c *gin.Context) {
+	vehicle, err := h.vehicleRepo.GetBySlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to retrieve vehicle",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if vehicle == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Vehicle not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   seo.ForVehicle(vehicle, h.seoOptions()),
+	})
+}
@@ -0,0 +1,618 @@
+package handlers
+
+import (
+	"autoelys_backend/internal/calendar"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/services"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxViewingSlots       = 5
+	viewingHorizon        = 60 * 24 * time.Hour
+	viewingTimeFormat     = "Monday, 2 January 2006 15:04"
+	viewingCalendarDomain = "autoelys"
+)
+
+type ViewingRequestHandler struct {
+	viewingRequestRepo *repository.ViewingRequestRepository
+	vehicleRepo        *repository.VehicleRepository
+	organizationRepo   *repository.OrganizationRepository
+	emailService       *services.EmailService
+	location           *time.Location
+}
+
+// NewViewingRequestHandler creates the viewing request handler; times in emails are shown in location
+func NewViewingRequestHandler(viewingRequestRepo *repository.ViewingRequestRepository, vehicleRepo *repository.VehicleRepository, organizationRepo *repository.OrganizationRepository, emailService *services.EmailService, location *time.Location) *ViewingRequestHandler {
+	return &ViewingRequestHandler{
+		viewingRequestRepo: viewingRequestRepo,
+		vehicleRepo:        vehicleRepo,
+		organizationRepo:   organizationRepo,
+		emailService:       emailService,
+		location:           location,
+	}
+}
+
+// CreateViewingRequest represents the viewing request payload
+// @Description Viewing or test drive request payload
+type CreateViewingRequest struct {
+	Kind    string      `json:"kind" example:"test_drive" enums:"viewing,test_drive"`
+	Message *string     `json:"message" example:"Could I also see the service history?"`
+	Slots   []time.Time `json:"slots" binding:"required,min=1,max=5" example:"2026-11-02T10:30:00+02:00"`
+}
+
+// AcceptViewingRequest represents the accept viewing request payload
+// @Description Accept viewing request payload
+type AcceptViewingRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required" example:"2026-11-02T10:30:00+02:00"`
+	Message  *string   `json:"message" example:"See you at the dealership"`
+}
+
+// CounterViewingRequest represents the counter-proposal payload
+// @Description Counter-proposal payload
+type CounterViewingRequest struct {
+	Slots   []time.Time `json:"slots" binding:"required,min=1,max=5" example:"2026-11-03T17:00:00+02:00"`
+	Message *string     `json:"message" example:"I am only available in the evening"`
+}
+
+// CloseViewingRequest represents the decline or cancel payload
+// @Description Decline or cancel payload
+type CloseViewingRequest struct {
+	Message *string `json:"message" example:"The car has been sold"`
+}
+
+// RequestViewing godoc
+// @Summary Request a viewing or test drive
+// @Description Ask the seller of an active listing for a viewing or test drive at one of up to 5 proposed times.
+// @Description The seller is notified by email; contact details are not shared.
+// @Tags Viewing requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param slug path string true "Vehicle slug"
+// @Param request body CreateViewingRequest true "Proposed times"
+// @Success 201 {object} map[string]interface{} "Viewing request created"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Vehicle not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/vehicles/{slug}/viewing-requests [post]
+func (h *ViewingRequestHandler) RequestViewing(c *gin.Context) {
+	var req CreateViewingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload, propose between 1 and 5 slots"})
+		return
+	}
+	if req.Kind == "" {
+		req.Kind = models.ViewingKindViewing
+	}
+	duration, ok := models.ViewingDuration[req.Kind]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid kind, must be one of: viewing, test_drive"})
+		return
+	}
+	slots, message := h.normalizeSlots(req.Slots)
+	if message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
+	vehicle, err := h.vehicleRepo.GetBySlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vehicle"})
+		return
+	}
+	if vehicle == nil || vehicle.Status != models.VehicleStatusActive {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Vehicle not found"})
+		return
+	}
+
+	actor := newVehicleActor(c)
+	if vehicle.UserID == actor.userID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot request a viewing of your own vehicle"})
+		return
+	}
+
+	viewing := &models.ViewingRequest{
+		VehicleID:       vehicle.ID,
+		BuyerID:         actor.userID,
+		Kind:            req.Kind,
+		Message:         req.Message,
+		DurationMinutes: duration,
+	}
+	if err := h.viewingRequestRepo.Create(viewing, slots); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create viewing request"})
+		return
+	}
+
+	created, ok := h.reload(c, viewing.UUID)
+	if !ok {
+		return
+	}
+	h.notify(created, models.ViewingPartySeller, "New "+kindName(created)+" request for "+created.VehicleTitle,
+		created.BuyerName+" would like to arrange a "+kindName(created)+" at one of these times:", h.formatSlots(created.OpenSlots()), created.Message, nil)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":         "Viewing request sent successfully",
+		"viewing_request": created,
+	})
+}
+
+// GetViewingInbox godoc
+// @Summary Get received viewing requests
+// @Description Get the viewing and test drive requests for the user's vehicles and the vehicles of the organizations they manage
+// @Tags Viewing requests
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status" Enums(pending, countered, accepted, declined, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Viewing requests"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests [get]
+func (h *ViewingRequestHandler) GetViewingInbox(c *gin.Context) {
+	h.list(c, h.viewingRequestRepo.GetInbox)
+}
+
+// GetSentViewingRequests godoc
+// @Summary Get sent viewing requests
+// @Description Get the viewing and test drive requests the user made
+// @Tags Viewing requests
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status" Enums(pending, countered, accepted, declined, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20) maximum(100)
+// @Success 200 {object} map[string]interface{} "Viewing requests"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests/sent [get]
+func (h *ViewingRequestHandler) GetSentViewingRequests(c *gin.Context) {
+	h.list(c, h.viewingRequestRepo.GetSent)
+}
+
+func (h *ViewingRequestHandler) list(c *gin.Context, fetch func(userID uint64, status string, limit, offset int) ([]models.ViewingRequest, int, error)) {
+	page, limit := parsePagination(c)
+	requests, total, err := fetch(newVehicleActor(c).userID, c.Query("status"), limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch viewing requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": requests,
+		"pagination": PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	})
+}
+
+// GetViewingRequest godoc
+// @Summary Get a viewing request
+// @Description Get a viewing request with all proposed times, for its buyer or seller
+// @Tags Viewing requests
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Viewing request UUID"
+// @Success 200 {object} models.ViewingRequest "Viewing request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Viewing request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests/{uuid} [get]
+func (h *ViewingRequestHandler) GetViewingRequest(c *gin.Context) {
+	viewing, _, ok := h.participant(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, viewing)
+}
+
+// AcceptViewingRequest godoc
+// @Summary Accept a viewing request
+// @Description Accept one of the times proposed by the other party. Both parties receive a confirmation with an
+// @Description iCalendar attachment.
+// @Tags Viewing requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Viewing request UUID"
+// @Param request body AcceptViewingRequest true "Accepted time"
+// @Success 200 {object} map[string]interface{} "Viewing request accepted"
+// @Failure 400 {object} map[string]string "Time was not proposed"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Viewing request not found"
+// @Failure 409 {object} map[string]string "Not awaiting your response"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests/{uuid}/accept [post]
+func (h *ViewingRequestHandler) AcceptViewingRequest(c *gin.Context) {
+	var req AcceptViewingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+
+	viewing, _, ok := h.awaiting(c)
+	if !ok {
+		return
+	}
+
+	var accepted time.Time
+	for _, slot := range viewing.OpenSlots() {
+		if slot.StartsAt.Equal(req.StartsAt) && slot.StartsAt.After(time.Now()) {
+			accepted = slot.StartsAt
+			break
+		}
+	}
+	if accepted.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "starts_at must be one of the proposed times that has not passed"})
+		return
+	}
+
+	err := h.viewingRequestRepo.Accept(viewing.ID, viewing.Status, accepted, req.Message)
+	if !h.updated(c, err) {
+		return
+	}
+
+	updated, ok := h.reload(c, viewing.UUID)
+	if !ok {
+		return
+	}
+	ics := calendar.ICS(h.calendarEvent(updated))
+	attachment := services.EmailAttachment{Filename: "viewing.ics", ContentType: calendar.ContentType, Data: ics}
+	times := h.formatSlots([]models.ViewingSlot{{StartsAt: *updated.ScheduledAt}})
+	summary := "Your " + kindName(updated) + " is confirmed for:"
+	for _, party := range []string{models.ViewingPartyBuyer, models.ViewingPartySeller} {
+		h.notify(updated, party, "Confirmed: "+kindName(updated)+" of "+updated.VehicleTitle, summary, times, updated.ResponseMessage, []services.EmailAttachment{attachment})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Viewing request accepted",
+		"viewing_request": updated,
+	})
+}
+
+// CounterViewingRequest godoc
+// @Summary Counter-propose a viewing request
+// @Description Propose other times instead of the ones offered by the other party, who is notified by email
+// @Tags Viewing requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Viewing request UUID"
+// @Param request body CounterViewingRequest true "Proposed times"
+// @Success 200 {object} map[string]interface{} "Counter-proposal sent"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Viewing request not found"
+// @Failure 409 {object} map[string]string "Not awaiting your response"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests/{uuid}/counter [post]
+func (h *ViewingRequestHandler) CounterViewingRequest(c *gin.Context) {
+	var req CounterViewingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload, propose between 1 and 5 slots"})
+		return
+	}
+	slots, message := h.normalizeSlots(req.Slots)
+	if message != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return
+	}
+
+	viewing, party, ok := h.awaiting(c)
+	if !ok {
+		return
+	}
+
+	err := h.viewingRequestRepo.Counter(viewing.ID, viewing.Status, party, slots, req.Message)
+	if !h.updated(c, err) {
+		return
+	}
+
+	updated, ok := h.reload(c, viewing.UUID)
+	if !ok {
+		return
+	}
+	h.notify(updated, otherParty(party), "New times proposed for "+updated.VehicleTitle,
+		"New times have been proposed for your "+kindName(updated)+":", h.formatSlots(updated.OpenSlots()), req.Message, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Counter-proposal sent",
+		"viewing_request": updated,
+	})
+}
+
+// DeclineViewingRequest godoc
+// @Summary Decline a viewing request
+// @Description Decline the times proposed by the other party, closing the request
+// @Tags Viewing requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Viewing request UUID"
+// @Param request body CloseViewingRequest false "Reason"
+// @Success 200 {object} map[string]interface{} "Viewing request declined"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Viewing request not found"
+// @Failure 409 {object} map[string]string "Not awaiting your response"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests/{uuid}/decline [post]
+func (h *ViewingRequestHandler) DeclineViewingRequest(c *gin.Context) {
+	var req CloseViewingRequest
+	_ = c.ShouldBindJSON(&req)
+
+	viewing, party, ok := h.awaiting(c)
+	if !ok {
+		return
+	}
+
+	err := h.viewingRequestRepo.Close(viewing.ID, viewing.Status, models.ViewingStatusDeclined, req.Message)
+	if !h.updated(c, err) {
+		return
+	}
+
+	updated, ok := h.reload(c, viewing.UUID)
+	if !ok {
+		return
+	}
+	h.notify(updated, otherParty(party), "Declined: "+kindName(updated)+" of "+updated.VehicleTitle,
+		"The "+kindName(updated)+" request has been declined.", nil, req.Message, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Viewing request declined",
+		"viewing_request": updated,
+	})
+}
+
+// CancelViewingRequest godoc
+// @Summary Cancel a viewing request
+// @Description Cancel an open or accepted request made by the user; the seller is notified
+// @Tags Viewing requests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param uuid path string true "Viewing request UUID"
+// @Param request body CloseViewingRequest false "Reason"
+// @Success 200 {object} map[string]interface{} "Viewing request cancelled"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Only the buyer can cancel"
+// @Failure 404 {object} map[string]string "Viewing request not found"
+// @Failure 409 {object} map[string]string "Viewing request is closed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests/{uuid}/cancel [post]
+func (h *ViewingRequestHandler) CancelViewingRequest(c *gin.Context) {
+	var req CloseViewingRequest
+	_ = c.ShouldBindJSON(&req)
+
+	viewing, party, ok := h.participant(c)
+	if !ok {
+		return
+	}
+	if party != models.ViewingPartyBuyer {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only the buyer can cancel a viewing request, decline it instead"})
+		return
+	}
+	if viewing.Status == models.ViewingStatusDeclined || viewing.Status == models.ViewingStatusCancelled {
+		c.JSON(http.StatusConflict, gin.H{"error": "Viewing request is closed"})
+		return
+	}
+
+	err := h.viewingRequestRepo.Close(viewing.ID, viewing.Status, models.ViewingStatusCancelled, req.Message)
+	if !h.updated(c, err) {
+		return
+	}
+
+	updated, ok := h.reload(c, viewing.UUID)
+	if !ok {
+		return
+	}
+	h.notify(updated, models.ViewingPartySeller, "Cancelled: "+kindName(updated)+" of "+updated.VehicleTitle,
+		updated.BuyerName+" has cancelled the "+kindName(updated)+" request.", nil, req.Message, nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "Viewing request cancelled",
+		"viewing_request": updated,
+	})
+}
+
+// GetViewingCalendar godoc
+// @Summary Download an accepted viewing as iCalendar
+// @Description Download the accepted appointment as an .ics file for calendar applications
+// @Tags Viewing requests
+// @Produce text/calendar
+// @Security BearerAuth
+// @Param uuid path string true "Viewing request UUID"
+// @Success 200 {file} file "iCalendar file"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Viewing request not found"
+// @Failure 409 {object} map[string]string "Viewing request is not accepted"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/user/viewing-requests/{uuid}/calendar.ics [get]
+func (h *ViewingRequestHandler) GetViewingCalendar(c *gin.Context) {
+	viewing, _, ok := h.participant(c)
+	if !ok {
+		return
+	}
+	if viewing.Status != models.ViewingStatusAccepted || viewing.ScheduledAt == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Viewing request is not accepted"})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="viewing-`+viewing.UUID+`.ics"`)
+	c.Data(http.StatusOK, calendar.ContentType, calendar.ICS(h.calendarEvent(viewing)))
+}
+
+// participant loads the request in the path and returns the party the user is: its buyer, or a seller
+// who can edit the vehicle
+func (h *ViewingRequestHandler) participant(c *gin.Context) (*models.ViewingRequest, string, bool) {
+	viewing, err := h.viewingRequestRepo.FindByUUID(c.Param("uuid"))
+	if err != nil {
+		if errors.Is(err, repository.ErrViewingRequestNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Viewing request not found"})
+			return nil, "", false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch viewing request"})
+		return nil, "", false
+	}
+
+	actor := newVehicleActor(c)
+	if viewing.BuyerID == actor.userID {
+		return viewing, models.ViewingPartyBuyer, true
+	}
+
+	vehicle, err := h.vehicleRepo.GetByUUID(viewing.VehicleUUID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch vehicle"})
+		return nil, "", false
+	}
+	if vehicle != nil {
+		_, canEdit, err := vehicleAccess(h.organizationRepo, vehicle, actor.userID, actor.roleID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			return nil, "", false
+		}
+		if canEdit {
+			return viewing, models.ViewingPartySeller, true
+		}
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "Viewing request not found"})
+	return nil, "", false
+}
+
+// awaiting loads the request in the path, which must be awaiting a response from the user's party
+func (h *ViewingRequestHandler) awaiting(c *gin.Context) (*models.ViewingRequest, string, bool) {
+	viewing, party, ok := h.participant(c)
+	if !ok {
+		return nil, "", false
+	}
+	if viewing.AwaitingParty() != party {
+		c.JSON(http.StatusConflict, gin.H{"error": "Viewing request is not awaiting your response"})
+		return nil, "", false
+	}
+	return viewing, party, true
+}
+
+// updated responds to a failed update, reporting requests the other party changed meanwhile as conflicts
+func (h *ViewingRequestHandler) updated(c *gin.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, repository.ErrViewingRequestChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Viewing request has changed, reload it and try again"})
+		return false
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update viewing request"})
+	return false
+}
+
+func (h *ViewingRequestHandler) reload(c *gin.Context, uuid string) (*models.ViewingRequest, bool) {
+	viewing, err := h.viewingRequestRepo.FindByUUID(uuid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch viewing request"})
+		return nil, false
+	}
+	return viewing, true
+}
+
+// normalizeSlots checks proposed times: in the future, within the horizon and without duplicates
+func (h *ViewingRequestHandler) normalizeSlots(slots []time.Time) ([]time.Time, string) {
+	if len(slots) == 0 || len(slots) > maxViewingSlots {
+		return nil, "Propose between 1 and 5 slots"
+	}
+
+	now := time.Now()
+	seen := make(map[int64]bool)
+	normalized := make([]time.Time, 0, len(slots))
+	for _, slot := range slots {
+		slot = slot.Truncate(time.Minute)
+		if !slot.After(now) || slot.After(now.Add(viewingHorizon)) {
+			return nil, "Proposed slots must be in the next 60 days"
+		}
+		if seen[slot.Unix()] {
+			continue
+		}
+		seen[slot.Unix()] = true
+		normalized = append(normalized, slot)
+	}
+	return normalized, ""
+}
+
+func (h *ViewingRequestHandler) formatSlots(slots []models.ViewingSlot) []string {
+	times := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		times = append(times, slot.StartsAt.In(h.location).Format(viewingTimeFormat)+" ("+h.location.String()+")")
+	}
+	return times
+}
+
+func (h *ViewingRequestHandler) calendarEvent(viewing *models.ViewingRequest) calendar.Event {
+	var description string
+	if viewing.Message != nil {
+		description = *viewing.Message
+	}
+	return calendar.Event{
+		UID:         "viewing-" + viewing.UUID + "@" + viewingCalendarDomain,
+		Start:       *viewing.ScheduledAt,
+		End:         viewing.ScheduledAt.Add(time.Duration(viewing.DurationMinutes) * time.Minute),
+		Summary:     capitalize(kindName(viewing)) + ": " + viewing.VehicleTitle,
+		Description: description,
+		Location:    viewing.VehicleCity,
+		Created:     viewing.UpdatedAt,
+	}
+}
+
+// notify emails a party about a change of the request; failures are only logged
+func (h *ViewingRequestHandler) notify(viewing *models.ViewingRequest, party, subject, summary string, times []string, message *string, attachments []services.EmailAttachment) {
+	to, name := viewing.SellerEmail, viewing.SellerName
+	if party == models.ViewingPartyBuyer {
+		to, name = viewing.BuyerEmail, viewing.BuyerName
+	}
+	email := services.ViewingEmail{
+		FirstName:   firstName(name),
+		Subject:     subject,
+		Summary:     summary,
+		Vehicle:     viewing.VehicleTitle,
+		Times:       times,
+		Path:        "/account/viewing-requests/" + viewing.UUID,
+		Attachments: attachments,
+	}
+	if message != nil {
+		email.Message = *message
+	}
+	if err := h.emailService.SendViewingRequestEmail(to, email); err != nil {
+		log.Printf("Viewing request %s: failed to email the %s: %v", viewing.UUID, party, err)
+	}
+}
+
+func otherParty(party string) string {
+	if party == models.ViewingPartyBuyer {
+		return models.ViewingPartySeller
+	}
+	return models.ViewingPartyBuyer
+}
+
+func kindName(viewing *models.ViewingRequest) string {
+	if viewing.Kind == models.ViewingKindTestDrive {
+		return "test drive"
+	}
+	return "viewing"
+}
+
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
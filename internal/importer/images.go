@@ -0,0 +1,179 @@
+package importer
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"autoelys_backend/internal/utils"
+)
+
+// downloadTimeout bounds the download of a single image URL
+const downloadTimeout = 20 * time.Second
+
+// Timeout bounds a whole import, so downloading images cannot hold the request past proxy and client timeouts
+const Timeout = time.Minute
+
+// MaxDownloads is the maximum number of image URLs downloaded in one import
+const MaxDownloads = 100
+
+// ErrTimeout is returned for images saved after the import ran out of time
+var ErrTimeout = errors.New("the import time limit was reached")
+
+// Images stores the images referenced by import rows. A reference is either an http(s) URL,
+// downloaded by the server, or the name of a file in the ZIP archive uploaded with the feed.
+type Images struct {
+	ctx       context.Context
+	archive   map[string]*zip.File
+	client    *http.Client
+	uploadDir string
+	downloads int
+}
+
+// NewImages creates an image store saving into uploadDir. archive may be nil when no ZIP was uploaded.
+// Images are no longer saved once ctx is done, and at most MaxDownloads URLs are downloaded.
+func NewImages(ctx context.Context, archive *zip.Reader, uploadDir string) *Images {
+	images := &Images{
+		ctx:       ctx,
+		archive:   make(map[string]*zip.File),
+		client:    newDownloadClient(),
+		uploadDir: uploadDir,
+	}
+	if archive != nil {
+		for _, file := range archive.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			// Files are matched by base name, wherever they sit in the archive
+			images.archive[strings.ToLower(path.Base(file.Name))] = file
+		}
+	}
+	return images
+}
+
+// Save stores the referenced images (at most utils.MaxImagesPerVehicle) and returns their relative paths.
+// On error, images already stored for these references are deleted.
+func (i *Images) Save(refs []string) ([]string, error) {
+	if len(refs) > utils.MaxImagesPerVehicle {
+		return nil, fmt.Errorf("maximum %d images allowed", utils.MaxImagesPerVehicle)
+	}
+
+	downloads := 0
+	for _, ref := range refs {
+		if isURL(ref) {
+			downloads++
+		}
+	}
+	if i.downloads+downloads > MaxDownloads {
+		return nil, fmt.Errorf("the import is limited to %d downloaded images", MaxDownloads)
+	}
+	i.downloads += downloads
+
+	var paths []string
+	for _, ref := range refs {
+		imagePath, err := i.save(ref)
+		if err != nil {
+			for _, saved := range paths {
+				_ = utils.DeleteFile(saved)
+			}
+			return nil, fmt.Errorf("image %s: %w", ref, err)
+		}
+		paths = append(paths, imagePath)
+	}
+	return paths, nil
+}
+
+func isURL(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+func (i *Images) save(ref string) (string, error) {
+	if i.ctx.Err() != nil {
+		return "", ErrTimeout
+	}
+	if isURL(ref) {
+		return i.download(ref)
+	}
+
+	file, ok := i.archive[strings.ToLower(path.Base(ref))]
+	if !ok {
+		return "", errors.New("not found in the uploaded ZIP archive")
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	return utils.SaveImage(reader, file.Name, i.uploadDir)
+}
+
+func (i *Images) download(rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.New("invalid URL")
+	}
+
+	req, err := http.NewRequestWithContext(i.ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return "", errors.New("invalid URL")
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		if i.ctx.Err() != nil {
+			return "", ErrTimeout
+		}
+		return "", errors.New("download failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+
+	// The image type is detected from the content, whatever the URL or the Content-Type header claim
+	name := path.Base(parsed.Path)
+	return utils.SaveImage(resp.Body, name, i.uploadDir)
+}
+
+// newDownloadClient returns an HTTP client that refuses to connect to loopback, private and link-local
+// addresses, so feed URLs cannot be used to reach internal services
+func newDownloadClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+				ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+				return fmt.Errorf("address %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+
+	transport := &http.Transport{
+		Proxy: nil,
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		},
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	}
+
+	return &http.Client{
+		Timeout:   downloadTimeout,
+		Transport: transport,
+	}
+}
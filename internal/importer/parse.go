@@ -0,0 +1,221 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// MaxRows is the maximum number of listings accepted in one import
+const MaxRows = 500
+
+// Format is the format of an import feed
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	FormatXML Format = "xml"
+)
+
+// Row is one listing of an import feed. Values are keyed by vehicle form field name
+// (e.g. "brand", "kilometers") so they can be bound to the create vehicle request.
+type Row struct {
+	Line   int // CSV line number, or the position of the listing in an XML feed
+	Values map[string][]string
+}
+
+// Get returns the first value of a field, or an empty string
+func (r Row) Get(field string) string {
+	if values := r.Values[field]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ImageRefs returns the image URLs or ZIP file names of the row, in order.
+// CSV cells may hold several references separated by "|", ";" or whitespace.
+func (r Row) ImageRefs() []string {
+	var refs []string
+	for _, value := range r.Values["images"] {
+		for _, ref := range imageRefSeparator.Split(value, -1) {
+			if ref = strings.TrimSpace(ref); ref != "" {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+var (
+	imageRefSeparator = regexp.MustCompile(`[|;\s]+`)
+	columnSeparator   = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// columnAliases maps common dealer feed column names to vehicle form field names
+var columnAliases = map[string]string{
+	"id":             "external_id",
+	"stock_id":       "external_id",
+	"stock_number":   "external_id",
+	"reference":      "external_id",
+	"make":           "brand",
+	"manufacturer":   "brand",
+	"mileage":        "kilometers",
+	"km":             "kilometers",
+	"fuel":           "fuel_type",
+	"gearbox":        "transmission",
+	"body":           "body_type",
+	"engine":         "engine_capacity",
+	"cc":             "engine_capacity",
+	"hp":             "power_hp",
+	"power":          "power_hp",
+	"keys":           "number_of_keys",
+	"image":          "images",
+	"image_urls":     "images",
+	"photos":         "images",
+	"pictures":       "images",
+	"contact":        "contact_name",
+	"contact_email":  "email",
+	"contact_phone":  "phone",
+	"seller_type":    "person_type",
+	"price_currency": "currency",
+	"features":       "equipment",
+	"options":        "equipment",
+}
+
+// normalizeColumn turns a feed column or element name into a vehicle form field name
+func normalizeColumn(name string) string {
+	name = strings.Trim(columnSeparator.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "_"), "_")
+	if alias, ok := columnAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// Parse reads an import feed in the given format
+func Parse(format Format, r io.Reader) ([]Row, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatXML:
+		return ParseXML(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+// ParseCSV reads a CSV feed whose first line holds the column names. Comma and semicolon
+// separated files are accepted.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimPrefix(string(data), "\ufeff") // UTF-8 byte order mark written by spreadsheet apps
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := strings.Cut(content, "\n"); strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = normalizeColumn(name)
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV on line %d: %w", line, err)
+		}
+
+		row := Row{Line: line, Values: make(map[string][]string)}
+		empty := true
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if i >= len(columns) || columns[i] == "" || value == "" {
+				continue
+			}
+			row.Values[columns[i]] = append(row.Values[columns[i]], value)
+			empty = false
+		}
+		if empty {
+			continue
+		}
+
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("the import is limited to %d listings", MaxRows)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// xmlNode is a generic XML element
+type xmlNode struct {
+	XMLName xml.Name
+	Content string    `xml:",chardata"`
+	Nodes   []xmlNode `xml:",any"`
+}
+
+// ParseXML reads an XML feed where every child of the root element is a listing, e.g.
+//
+//	<vehicles>
+//	  <vehicle>
+//	    <external_id>A-102</external_id>
+//	    <brand>Skoda</brand>
+//	    <images><image>https://dealer.example/a-102-1.jpg</image></images>
+//	  </vehicle>
+//	</vehicles>
+//
+// Leaf elements map to fields by name; the leaves of a nested element (like images) are collected under its name.
+func ParseXML(r io.Reader) ([]Row, error) {
+	var root xmlNode
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid XML: %w", err)
+	}
+	if len(root.Nodes) > MaxRows {
+		return nil, fmt.Errorf("the import is limited to %d listings", MaxRows)
+	}
+
+	rows := make([]Row, 0, len(root.Nodes))
+	for i, listing := range root.Nodes {
+		row := Row{Line: i + 1, Values: make(map[string][]string)}
+		for _, field := range listing.Nodes {
+			name := normalizeColumn(field.XMLName.Local)
+			if len(field.Nodes) == 0 {
+				if value := strings.TrimSpace(field.Content); value != "" {
+					row.Values[name] = append(row.Values[name], value)
+				}
+				continue
+			}
+			for _, item := range field.Nodes {
+				if value := strings.TrimSpace(item.Content); value != "" {
+					row.Values[name] = append(row.Values[name], value)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
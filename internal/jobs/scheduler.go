@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Every runs fn once right away and then at every interval in a background goroutine.
+// Errors and panics are logged and do not stop the job. The returned function stops it.
+func Every(name string, interval time.Duration, fn func() error) (stop func()) {
+	done := make(chan struct{})
+	var once sync.Once
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run(name, fn)
+			select {
+			case <-ticker.C:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+func run(name string, fn func() error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %s panicked: %v", name, r)
+		}
+	}()
+
+	start := time.Now()
+	if err := fn(); err != nil {
+		log.Printf("Job %s failed: %v", name, err)
+		return
+	}
+	log.Printf("Job %s finished in %s", name, time.Since(start).Round(time.Millisecond))
+}
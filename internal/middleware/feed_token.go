@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedTokenRequired protects the outbound feeds. Consumers send one of the configured tokens in the
+// X-Feed-Token header or the token query parameter; with no tokens configured the feeds are closed.
+func FeedTokenRequired(tokens []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("X-Feed-Token")
+		if token == "" {
+			token = c.Query("token")
+		}
+		if token == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Feed token required"})
+			c.Abort()
+			return
+		}
+
+		for _, allowed := range tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(allowed)) == 1 {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid feed token"})
+		c.Abort()
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"log"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WarnUntrustedForwarding logs once when X-Forwarded-For arrives from a peer that is not in TRUSTED_PROXIES.
+// Gin then ignores the header and the client IP is the peer's address, so behind an unconfigured reverse
+// proxy every client shares one rate limit bucket and the audit logs record the proxy's address.
+func WarnUntrustedForwarding() gin.HandlerFunc {
+	var once sync.Once
+	return func(c *gin.Context) {
+		if c.GetHeader("X-Forwarded-For") != "" && c.ClientIP() == c.RemoteIP() {
+			once.Do(func() {
+				log.Printf("Warning: ignoring X-Forwarded-For from %s, which is not in TRUSTED_PROXIES; "+
+					"all clients behind it share its rate limits until TRUSTED_PROXIES lists the proxy", c.RemoteIP())
+			})
+		}
+		c.Next()
+	}
+}
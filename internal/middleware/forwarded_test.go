@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newForwardingRouter(t *testing.T, trustedProxies []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		t.Fatal(err)
+	}
+	router.Use(WarnUntrustedForwarding())
+	router.GET("/resource", func(c *gin.Context) {
+		c.String(http.StatusOK, c.ClientIP())
+	})
+	return router
+}
+
+func TestWarnUntrustedForwarding(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	tests := []struct {
+		name     string
+		trusted  []string
+		headers  map[string]string
+		clientIP string
+		warned   bool
+	}{
+		{"no header", nil, nil, "192.0.2.1", false},
+		{"trusted proxy", []string{"192.0.2.1"}, map[string]string{"X-Forwarded-For": "203.0.113.7"}, "203.0.113.7", false},
+		{"untrusted peer", nil, map[string]string{"X-Forwarded-For": "203.0.113.7"}, "192.0.2.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			router := newForwardingRouter(t, tt.trusted)
+
+			// httptest requests come from 192.0.2.1; the warning is only logged once per router
+			for i := 0; i < 2; i++ {
+				w := serve(router, "/resource", tt.headers)
+				if w.Body.String() != tt.clientIP {
+					t.Errorf("client IP = %q, want %q", w.Body.String(), tt.clientIP)
+				}
+			}
+
+			warnings := strings.Count(logs.String(), "Warning:")
+			if tt.warned && warnings != 1 {
+				t.Errorf("logged %d warnings, want 1:\n%s", warnings, logs.String())
+			}
+			if !tt.warned && warnings != 0 {
+				t.Errorf("unexpected warning:\n%s", logs.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPCache makes successful GET responses of a route cacheable. It buffers the response to compute a strong
+// ETag from the body, answers a matching If-None-Match with 304 Not Modified, and sets
+// Cache-Control: public with maxAge for anonymous requests. Responses to requests carrying credentials are
+// marked private and must be revalidated, so shared caches never store them.
+func HTTPCache(maxAge time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.status != http.StatusOK {
+			writer.flush()
+			return
+		}
+
+		sum := sha256.Sum256(writer.body.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		header := c.Writer.Header()
+		header.Set("ETag", etag)
+
+		_, authenticated := c.Get("user_id")
+		if authenticated || c.GetHeader("Authorization") != "" {
+			header.Set("Cache-Control", "private, no-cache")
+		} else if maxAge > 0 {
+			header.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(maxAge.Seconds())))
+		} else {
+			header.Set("Cache-Control", "public, no-cache")
+		}
+
+		if notModified(c.Request, etag) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			c.Writer.WriteHeader(http.StatusNotModified)
+			c.Writer.WriteHeaderNow()
+			return
+		}
+		writer.flush()
+	}
+}
+
+// notModified reports whether If-None-Match lists the ETag of the response
+func notModified(r *http.Request, etag string) bool {
+	match := r.Header.Get("If-None-Match")
+	if match == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(match, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedWriter holds back the status and body of a response until HTTPCache has evaluated it
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+// flush writes the buffered response to the underlying writer
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.WriteHeaderNow()
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
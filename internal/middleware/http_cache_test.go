@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCacheRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/resource", HTTPCache(time.Minute), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"name": "resource"})
+	})
+	router.GET("/missing", HTTPCache(time.Minute), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	})
+	return router
+}
+
+func serve(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHTTPCache(t *testing.T) {
+	router := newCacheRouter()
+
+	first := serve(router, "/resource", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || first.Body.String() != `{"name":"resource"}` {
+		t.Fatalf("first response = %d %q", first.Code, first.Body.String())
+	}
+	if etag == "" || first.Header().Get("Cache-Control") != "public, max-age=60" {
+		t.Errorf("ETag = %q, Cache-Control = %q", etag, first.Header().Get("Cache-Control"))
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"matching etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"other etag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := serve(router, "/resource", tt.headers)
+		if w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, w.Code, tt.want)
+		}
+		if tt.want == http.StatusNotModified && w.Body.Len() != 0 {
+			t.Errorf("%s: 304 with body %q", tt.name, w.Body.String())
+		}
+	}
+}
+
+func TestHTTPCacheAuthenticated(t *testing.T) {
+	w := serve(newCacheRouter(), "/resource", map[string]string{"Authorization": "Bearer token"})
+	if got := w.Header().Get("Cache-Control"); got != "private, no-cache" {
+		t.Errorf("Cache-Control = %q, want private", got)
+	}
+}
+
+func TestHTTPCacheErrorsPassThrough(t *testing.T) {
+	w := serve(newCacheRouter(), "/missing", nil)
+	if w.Code != http.StatusNotFound || w.Header().Get("ETag") != "" || w.Header().Get("Cache-Control") != "" {
+		t.Errorf("error response = %d, ETag %q, Cache-Control %q", w.Code, w.Header().Get("ETag"), w.Header().Get("Cache-Control"))
+	}
+	if w.Body.String() != `{"error":"not found"}` {
+		t.Errorf("body = %q", w.Body.String())
+	}
+}
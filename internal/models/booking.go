@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Booking statuses
+const (
+	BookingStatusConfirmed = "confirmed"
+	BookingStatusCancelled = "cancelled"
+)
+
+// Booking is an appointment of a user for a service
+type Booking struct {
+	ID           uint64    `json:"id"`
+	UUID         string    `json:"uuid"`
+	UserID       uint64    `json:"user_id"`
+	UserName     string    `json:"user_name"`
+	UserEmail    string    `json:"user_email"`
+	ServiceID    uint64    `json:"service_id"`
+	ServiceUUID  string    `json:"service_uuid"`
+	ServiceTitle string    `json:"service_title"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	Status       string    `json:"status"`
+	Notes        *string   `json:"notes,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// BookingHours are weekly working hours: appointments take place on Weekday (0 = Sunday) between
+// OpensAt and ClosesAt (HH:MM), at most Capacity at the same time
+type BookingHours struct {
+	ID       uint   `json:"id"`
+	Weekday  uint8  `json:"weekday"`
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+	Capacity uint   `json:"capacity"`
+}
+
+// BookingException replaces the working hours of a date (YYYY-MM-DD); without hours the day is closed
+type BookingException struct {
+	ID        uint      `json:"id"`
+	Date      string    `json:"date"`
+	OpensAt   *string   `json:"opens_at,omitempty"`
+	ClosesAt  *string   `json:"closes_at,omitempty"`
+	Capacity  *uint     `json:"capacity,omitempty"`
+	Note      *string   `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Currency constants
+const (
+	CurrencyLei  = "lei"
+	CurrencyEuro = "euro"
+	CurrencyUSD  = "usd"
+)
+
+// BaseCurrency is the currency exchange rates and normalized prices are expressed in
+const BaseCurrency = CurrencyLei
+
+// SupportedCurrencies lists the currencies accepted for vehicle and service prices
+var SupportedCurrencies = []string{CurrencyLei, CurrencyEuro, CurrencyUSD}
+
+// currencyAliases maps ISO codes and common spellings to the supported currency codes
+var currencyAliases = map[string]string{
+	"ron": CurrencyLei,
+	"eur": CurrencyEuro,
+	"€":   CurrencyEuro,
+	"$":   CurrencyUSD,
+}
+
+// NormalizeCurrency lowercases a currency and resolves known aliases (e.g. EUR -> euro)
+func NormalizeCurrency(currency string) string {
+	currency = strings.ToLower(strings.TrimSpace(currency))
+	if canonical, ok := currencyAliases[currency]; ok {
+		return canonical
+	}
+	return currency
+}
+
+// IsSupportedCurrency reports whether a currency (after normalization) is on the whitelist
+func IsSupportedCurrency(currency string) bool {
+	currency = NormalizeCurrency(currency)
+	for _, supported := range SupportedCurrencies {
+		if currency == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// isoCurrencies maps the supported currency codes to ISO 4217 codes
+var isoCurrencies = map[string]string{
+	CurrencyLei:  "RON",
+	CurrencyEuro: "EUR",
+	CurrencyUSD:  "USD",
+}
+
+// ISOCurrency returns the ISO 4217 code of a currency, or the uppercased code when unknown
+func ISOCurrency(currency string) string {
+	if code, ok := isoCurrencies[currency]; ok {
+		return code
+	}
+	return strings.ToUpper(currency)
+}
+
+// ExchangeRate holds the value of one unit of a currency expressed in the base currency
+type ExchangeRate struct {
+	Currency  string    `json:"currency"`
+	Rate      float64   `json:"rate"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+// EquipmentCategory groups equipment items (comfort, safety, ...)
+type EquipmentCategory struct {
+	ID          uint16      `json:"id"`
+	Name        string      `json:"name"`
+	DisplayName string      `json:"display_name"`
+	SortOrder   int         `json:"sort_order"`
+	Equipment   []Equipment `json:"equipment,omitempty"`
+}
+
+// Equipment is a feature a vehicle can have, like air conditioning or parking sensors
+type Equipment struct {
+	ID          uint16 `json:"id"`
+	CategoryID  uint16 `json:"category_id"`
+	Category    string `json:"category,omitempty"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	SortOrder   int    `json:"sort_order"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Organization member roles
+const (
+	OrganizationRoleOwner   = "owner"   // full control, including members and deleting the organization
+	OrganizationRoleManager = "manager" // edits the profile and all listings, manages agents
+	OrganizationRoleAgent   = "agent"   // lists vehicles for the organization and edits their own listings
+)
+
+// IsValidOrganizationRole reports whether role is a known organization member role
+func IsValidOrganizationRole(role string) bool {
+	switch role {
+	case OrganizationRoleOwner, OrganizationRoleManager, OrganizationRoleAgent:
+		return true
+	}
+	return false
+}
+
+// CanManageOrganization reports whether the role may edit the organization profile and all its listings
+func CanManageOrganization(role string) bool {
+	return role == OrganizationRoleOwner || role == OrganizationRoleManager
+}
+
+// Organization is a dealership whose members list vehicles on its behalf
+type Organization struct {
+	ID          uint64    `json:"id"`
+	UUID        string    `json:"uuid"`
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Logo        *string   `json:"logo,omitempty"`
+	Email       *string   `json:"email,omitempty"`
+	Phone       *string   `json:"phone,omitempty"`
+	Website     *string   `json:"website,omitempty"`
+	Address     *string   `json:"address,omitempty"`
+	City        *string   `json:"city,omitempty"`
+	County      *string   `json:"county,omitempty"`
+	PostalCode  *string   `json:"postal_code,omitempty"`
+	VATCode     *string   `json:"vat_code,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OrganizationMember is a user belonging to an organization
+type OrganizationMember struct {
+	UserID    uint64    `json:"-"`
+	UserUUID  string    `json:"user_uuid"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrganizationMembership is an organization together with the role of the current user
+type OrganizationMembership struct {
+	Organization
+	Role string `json:"role"`
+}
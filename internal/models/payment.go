@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Payment event sources: who caused a payment status change
+const (
+	PaymentSourceUser    = "user"
+	PaymentSourceAdmin   = "admin"
+	PaymentSourceWebhook = "webhook"
+	PaymentSourceSystem  = "system"
+)
+
+// Payment is a payment for a service order through a payment provider. Status is one of the
+// payments package statuses; a succeeded payment activates its order.
+type Payment struct {
+	ID               uint64         `json:"id"`
+	UUID             string         `json:"uuid"`
+	UserID           uint64         `json:"user_id"`
+	ServiceOrderID   uint64         `json:"service_order_id"`
+	ServiceOrderUUID string         `json:"service_order_uuid"`
+	Provider         string         `json:"provider"`
+	ProviderIntentID *string        `json:"provider_intent_id,omitempty"`
+	IdempotencyKey   string         `json:"-"`
+	Amount           float64        `json:"amount"`
+	Currency         string         `json:"currency"`
+	Status           string         `json:"status"`
+	FailureReason    *string        `json:"failure_reason,omitempty"`
+	Events           []PaymentEvent `json:"events,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// PaymentEvent is one recorded status change of a payment
+type PaymentEvent struct {
+	ID              uint64    `json:"id"`
+	FromStatus      *string   `json:"from_status,omitempty"`
+	ToStatus        string    `json:"to_status"`
+	Source          string    `json:"source"`
+	ProviderEventID *string   `json:"provider_event_id,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
@@ -13,6 +13,10 @@ type Service struct {
 	Currency        string    `json:"currency"`
 	DurationMinutes *uint     `json:"duration_minutes,omitempty"`
 	Active          bool      `json:"active"`
+	Version         uint64    `json:"version"` // incremented by every update, sent as the ETag
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+
+	// The listing promotion granted for DurationMinutes when the service is ordered for a vehicle, nil for other services
+	Promotion *string `json:"promotion,omitempty"`
 }
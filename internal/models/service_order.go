@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Listing promotions granted by services
+const (
+	PromotionTop       = "top"       // ranked first in the vehicle listings
+	PromotionHighlight = "highlight" // ranked first in the homepage recommendations
+)
+
+// Promotions lists the supported listing promotions
+var Promotions = []string{PromotionTop, PromotionHighlight}
+
+// IsValidPromotion reports whether promotion is a supported listing promotion
+func IsValidPromotion(promotion string) bool {
+	for _, p := range Promotions {
+		if p == promotion {
+			return true
+		}
+	}
+	return false
+}
+
+// Service order statuses. Active orders past their end are reported as expired.
+const (
+	ServiceOrderStatusPending   = "pending"
+	ServiceOrderStatusActive    = "active"
+	ServiceOrderStatusExpired   = "expired"
+	ServiceOrderStatusCancelled = "cancelled"
+)
+
+// ServiceOrder is a promotion service bought for a vehicle. Price, currency and duration are
+// copied from the service when ordering.
+type ServiceOrder struct {
+	ID              uint64     `json:"id"`
+	UUID            string     `json:"uuid"`
+	UserID          uint64     `json:"user_id"`
+	VehicleID       uint64     `json:"vehicle_id"`
+	VehicleUUID     string     `json:"vehicle_uuid"`
+	VehicleTitle    string     `json:"vehicle_title"`
+	ServiceID       uint64     `json:"service_id"`
+	ServiceUUID     string     `json:"service_uuid"`
+	ServiceTitle    string     `json:"service_title"`
+	Promotion       string     `json:"promotion"`
+	Price           float64    `json:"price"`
+	Currency        string     `json:"currency"`
+	DurationMinutes uint       `json:"duration_minutes"`
+	Status          string     `json:"status"`
+	StartsAt        *time.Time `json:"starts_at,omitempty"`
+	EndsAt          *time.Time `json:"ends_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Upload statuses
+const (
+	UploadStatusPending   = "pending"   // receiving chunks
+	UploadStatusCompleted = "completed" // received and validated, waiting to be claimed by a vehicle
+)
+
+// Upload is an image uploaded in chunks ahead of the vehicle it belongs to. Creating or updating
+// a vehicle claims completed uploads by UUID, turning them into vehicle images.
+type Upload struct {
+	ID        uint64    `json:"-"`
+	UUID      string    `json:"uuid"`
+	UserID    uint64    `json:"-"`
+	Filename  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	Received  int64     `json:"offset"` // bytes received so far, the offset to resume from
+	Status    string    `json:"status"`
+	ImageURL  *string   `json:"image_url,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
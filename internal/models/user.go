@@ -14,6 +14,7 @@ type User struct {
 	Phone           *string    `json:"phone,omitempty"`
 	PasswordHash    string     `json:"-"`
 	Active          bool       `json:"active"`
+	Version         uint64     `json:"version"` // incremented by every profile update, sent as the ETag
 	AcceptedTermsAt *time.Time `json:"accepted_terms_at,omitempty"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
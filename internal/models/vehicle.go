@@ -44,6 +44,7 @@ const (
 	VehicleStatusActive   uint8 = 1
 	VehicleStatusInactive uint8 = 2
 	VehicleStatusBanned   uint8 = 3
+	VehicleStatusDraft    uint8 = 4 // saved incomplete, not listed until published
 )
 
 // GetStatusName returns the string representation of a vehicle status
@@ -55,18 +56,37 @@ func GetStatusName(status uint8) string {
 		return "inactive"
 	case VehicleStatusBanned:
 		return "banned"
+	case VehicleStatusDraft:
+		return "draft"
 	default:
 		return "unknown"
 	}
 }
 
+// ParseVehicleStatus returns the vehicle status with the given name
+func ParseVehicleStatus(name string) (uint8, bool) {
+	switch name {
+	case "active":
+		return VehicleStatusActive, true
+	case "inactive":
+		return VehicleStatusInactive, true
+	case "banned":
+		return VehicleStatusBanned, true
+	default:
+		return 0, false
+	}
+}
+
 // Vehicle model
 type Vehicle struct {
 	ID             uint64    `json:"id,omitempty"`
 	UserID         uint64    `json:"user_id,omitempty"`
-	Status         uint8     `json:"status"` // 1=active, 2=inactive, 3=banned
+	OrganizationID *uint64   `json:"organization_id,omitempty"`
+	ExternalID     *string   `json:"external_id,omitempty"`
+	Status         uint8     `json:"status"` // 1=active, 2=inactive, 3=banned, 4=draft
 	StatusName     string    `json:"status_name,omitempty"`
 	Recommended    bool      `json:"recommended"`
+	Score          float64   `json:"score,omitempty"`
 	FeaturedImage  *string   `json:"featured_image,omitempty"`
 	UUID           string    `json:"uuid"`
 	Slug           string    `json:"slug"`
@@ -78,8 +98,11 @@ type Vehicle struct {
 	Negotiable     bool      `json:"negotiable"`
 	PersonTypeID   uint8     `json:"person_type_id"`
 	PersonType     string    `json:"person_type"`
+	BrandID        *uint64   `json:"brand_id,omitempty"`
+	AutomobileID   *uint64   `json:"automobile_id,omitempty"`
 	Brand          string    `json:"brand"`
 	Model          string    `json:"model"`
+	VIN            *string   `json:"vin,omitempty"`
 	EngineCapacity *int      `json:"engine_capacity,omitempty"`
 	PowerHP        *int      `json:"power_hp,omitempty"`
 	FuelTypeID     uint8     `json:"fuel_type_id"`
@@ -101,11 +124,24 @@ type Vehicle struct {
 	ContactName    string    `json:"contact_name"`
 	Email          string    `json:"email"`
 	Phone          *string   `json:"phone,omitempty"`
+	Version        uint64    `json:"version"` // incremented by every update, sent as the ETag
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 
-	// Relationship
-	Images []VehicleImage `json:"images,omitempty"`
+	// Price in the base currency (used for filtering and ranking, not exposed)
+	// and in the currency requested by the viewer (computed, not stored)
+	PriceNormalized   *float64 `json:"-"`
+	ConvertedPrice    *float64 `json:"converted_price,omitempty"`
+	ConvertedCurrency string   `json:"converted_currency,omitempty"`
+
+	// Running paid promotions (see ServiceOrder): ranked first in listings until PromotedUntil
+	// and on the homepage until HighlightedUntil; nil when none is running
+	PromotedUntil    *time.Time `json:"promoted_until,omitempty"`
+	HighlightedUntil *time.Time `json:"highlighted_until,omitempty"`
+
+	// Relationships
+	Images    []VehicleImage `json:"images,omitempty"`
+	Equipment []Equipment    `json:"equipment,omitempty"`
 }
 
 type VehicleImage struct {
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"autoelys_backend/internal/audit"
+)
+
+// Vehicle audit log actions
+const (
+	VehicleAuditActionCreate = "create"
+	VehicleAuditActionUpdate = "update"
+	VehicleAuditActionStatus = "status"
+)
+
+// Vehicle audit log actor roles: how the user who made the change relates to the vehicle
+const (
+	VehicleAuditActorOwner  = "owner"
+	VehicleAuditActorMember = "member" // owner or manager of the vehicle's organization
+	VehicleAuditActorAdmin  = "admin"
+)
+
+// IsValidVehicleAuditAction reports whether action is a known vehicle audit log action
+func IsValidVehicleAuditAction(action string) bool {
+	switch action {
+	case VehicleAuditActionCreate, VehicleAuditActionUpdate, VehicleAuditActionStatus:
+		return true
+	}
+	return false
+}
+
+// VehicleAuditLog is one recorded change of a vehicle with the values of the changed fields
+type VehicleAuditLog struct {
+	ID          uint64         `json:"id"`
+	VehicleID   uint64         `json:"vehicle_id"`
+	VehicleUUID *string        `json:"vehicle_uuid,omitempty"`
+	ActorID     *uint64        `json:"actor_id,omitempty"`
+	ActorName   *string        `json:"actor_name,omitempty"`
+	ActorEmail  *string        `json:"actor_email,omitempty"`
+	ActorRole   string         `json:"actor_role"`
+	Action      string         `json:"action"`
+	IPAddress   *string        `json:"ip_address,omitempty"`
+	Changes     []audit.Change `json:"changes"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
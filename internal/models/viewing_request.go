@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+// Viewing request kinds
+const (
+	ViewingKindViewing   = "viewing"
+	ViewingKindTestDrive = "test_drive"
+)
+
+// ViewingDuration is how long each kind of appointment is expected to take
+var ViewingDuration = map[string]uint{
+	ViewingKindViewing:   30,
+	ViewingKindTestDrive: 60,
+}
+
+// Viewing request statuses. Pending requests await the seller, countered ones the buyer.
+const (
+	ViewingStatusPending   = "pending"
+	ViewingStatusCountered = "countered"
+	ViewingStatusAccepted  = "accepted"
+	ViewingStatusDeclined  = "declined"
+	ViewingStatusCancelled = "cancelled"
+)
+
+// Viewing request parties
+const (
+	ViewingPartyBuyer  = "buyer"
+	ViewingPartySeller = "seller"
+)
+
+// ViewingRequest is a buyer's request to view or test drive a listing. The parties' email
+// addresses are only used for notifications and never exposed to each other.
+type ViewingRequest struct {
+	ID              uint64        `json:"id"`
+	UUID            string        `json:"uuid"`
+	VehicleID       uint64        `json:"vehicle_id"`
+	VehicleUUID     string        `json:"vehicle_uuid"`
+	VehicleSlug     string        `json:"vehicle_slug"`
+	VehicleTitle    string        `json:"vehicle_title"`
+	VehicleCity     string        `json:"vehicle_city"`
+	SellerID        uint64        `json:"seller_id"`
+	SellerName      string        `json:"seller_name"`
+	SellerEmail     string        `json:"-"`
+	BuyerID         uint64        `json:"buyer_id"`
+	BuyerName       string        `json:"buyer_name"`
+	BuyerEmail      string        `json:"-"`
+	Kind            string        `json:"kind"`
+	Message         *string       `json:"message,omitempty"`
+	Status          string        `json:"status"`
+	ScheduledAt     *time.Time    `json:"scheduled_at,omitempty"`
+	DurationMinutes uint          `json:"duration_minutes"`
+	ResponseMessage *string       `json:"response_message,omitempty"`
+	Slots           []ViewingSlot `json:"slots"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// ViewingSlot is a proposed start time; the slots of the highest round are the open proposal
+type ViewingSlot struct {
+	Round      uint      `json:"round"`
+	ProposedBy string    `json:"proposed_by"`
+	StartsAt   time.Time `json:"starts_at"`
+}
+
+// OpenSlots returns the slots of the latest round of proposals
+func (r *ViewingRequest) OpenSlots() []ViewingSlot {
+	var latest uint
+	for _, slot := range r.Slots {
+		if slot.Round > latest {
+			latest = slot.Round
+		}
+	}
+
+	var open []ViewingSlot
+	for _, slot := range r.Slots {
+		if slot.Round == latest {
+			open = append(open, slot)
+		}
+	}
+	return open
+}
+
+// AwaitingParty returns the party expected to respond, or "" once the request is closed
+func (r *ViewingRequest) AwaitingParty() string {
+	switch r.Status {
+	case ViewingStatusPending:
+		return ViewingPartySeller
+	case ViewingStatusCountered:
+		return ViewingPartyBuyer
+	}
+	return ""
+}
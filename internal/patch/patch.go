@@ -0,0 +1,126 @@
+// Package patch decodes JSON Merge Patch documents (RFC 7396) into structs of Field values, which tell
+// apart a member that was left out (unchanged), sent as null (cleared) and sent with a value (replaced).
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ContentType is the media type of JSON Merge Patch documents
+const ContentType = "application/merge-patch+json"
+
+// ErrNotObject is returned by Decode for documents that are not a JSON object
+var ErrNotObject = errors.New("merge patch must be a JSON object")
+
+// Field is a member of a merge patch
+type Field[T any] struct {
+	Set   bool // the member is present, possibly null
+	Null  bool
+	Value T
+}
+
+// Value returns a field set to v
+func Value[T any](v T) Field[T] {
+	return Field[T]{Set: true, Value: v}
+}
+
+// NonZero returns a field set to v, or an unset field when v is the zero value
+func NonZero[T comparable](v T) Field[T] {
+	var zero T
+	if v == zero {
+		return Field[T]{}
+	}
+	return Value(v)
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	f.Set = true
+	f.Null = bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+	f.Value = zero
+	if f.Null {
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+// Ptr returns a pointer to the value, nil when the field is null or unset
+func (f Field[T]) Ptr() *T {
+	if !f.Set || f.Null {
+		return nil
+	}
+	value := f.Value
+	return &value
+}
+
+func (f Field[T]) isNull() bool {
+	return f.Null
+}
+
+func (f Field[T]) validationValue() interface{} {
+	return f.Ptr()
+}
+
+// Decode decodes the merge patch document in r into dst, a pointer to a struct of Fields.
+// Members that do not match a field are rejected.
+func Decode(r io.Reader, dst interface{}) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return ErrNotObject
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after the merge patch")
+	}
+	return nil
+}
+
+// NullFields returns the names of the fields of the struct v that are null although their
+// `patch` tag does not mark them "nullable"
+func NullFields(v interface{}) []string {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	var fields []string
+	for i := 0; i < value.NumField(); i++ {
+		field, ok := value.Field(i).Interface().(interface{ isNull() bool })
+		if !ok || !field.isNull() {
+			continue
+		}
+		if structField := value.Type().Field(i); structField.Tag.Get("patch") != "nullable" {
+			fields = append(fields, structField.Name)
+		}
+	}
+	return fields
+}
+
+// RegisterValidation lets v validate the value of the Fields. Unset and null fields are seen as nil
+// pointers, so their rules are skipped with omitnil, e.g. `validate:"omitnil,required,max=100"`.
+func RegisterValidation(v *validator.Validate) {
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if f, ok := field.Interface().(interface{ validationValue() interface{} }); ok {
+			return f.validationValue()
+		}
+		return nil
+	},
+		Field[string]{},
+		Field[int]{},
+		Field[uint64]{},
+		Field[float64]{},
+		Field[bool]{},
+		Field[[]string]{},
+	)
+}
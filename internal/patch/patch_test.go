@@ -0,0 +1,106 @@
+package patch
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type testPatch struct {
+	Title      Field[string]   `json:"title" validate:"omitnil,required,min=5"`
+	Color      Field[string]   `json:"color" patch:"nullable"`
+	Kilometers Field[int]      `json:"kilometers" patch:"nullable" validate:"omitnil,min=0"`
+	Negotiable Field[bool]     `json:"negotiable"`
+	Equipment  Field[[]string] `json:"equipment" patch:"nullable"`
+	UploadIDs  Field[[]string] `json:"upload_ids" validate:"omitnil,max=2,dive,uuid"`
+}
+
+func TestDecode(t *testing.T) {
+	var p testPatch
+	err := Decode(strings.NewReader(`{"color": null, "kilometers": 0, "negotiable": false}`), &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Title.Set {
+		t.Error("title is set although it was left out")
+	}
+	if !p.Color.Set || !p.Color.Null || p.Color.Ptr() != nil {
+		t.Errorf("color = %+v, want null", p.Color)
+	}
+	if !p.Kilometers.Set || p.Kilometers.Null || *p.Kilometers.Ptr() != 0 {
+		t.Errorf("kilometers = %+v, want 0", p.Kilometers)
+	}
+	if !p.Negotiable.Set || p.Negotiable.Value {
+		t.Errorf("negotiable = %+v, want false", p.Negotiable)
+	}
+}
+
+func TestDecodeRejectsInvalidDocuments(t *testing.T) {
+	for _, document := range []string{
+		`[{"title": "Skoda Octavia"}]`,
+		`null`,
+		``,
+		`{"seats": 5}`,
+		`{"kilometers": "many"}`,
+		`{"title": "Skoda Octavia"} {}`,
+	} {
+		var p testPatch
+		if err := Decode(strings.NewReader(document), &p); err == nil {
+			t.Errorf("Decode(%q) succeeded", document)
+		}
+	}
+
+	var p testPatch
+	if err := Decode(strings.NewReader(`"title"`), &p); !errors.Is(err, ErrNotObject) {
+		t.Errorf("err = %v, want %v", err, ErrNotObject)
+	}
+}
+
+func TestNullFields(t *testing.T) {
+	var p testPatch
+	if err := Decode(strings.NewReader(`{"title": null, "color": null, "negotiable": null, "equipment": null}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := NullFields(&p), []string{"Title", "Negotiable"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NullFields = %v, want %v", got, want)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	validate := validator.New()
+	RegisterValidation(validate)
+
+	tests := []struct {
+		document string
+		invalid  []string
+	}{
+		{`{}`, nil},
+		{`{"title": null, "kilometers": null}`, nil},
+		{`{"title": "Skoda Octavia", "kilometers": 0}`, nil},
+		{`{"title": ""}`, []string{"Title"}},
+		{`{"title": "Golf", "kilometers": -1}`, []string{"Title", "Kilometers"}},
+		{`{"upload_ids": ["0b7ad5a4-3f5e-4d2b-9c1e-6f8a2b4c7d90"]}`, nil},
+		{`{"upload_ids": ["front.jpg"]}`, []string{"UploadIDs[0]"}},
+		{`{"upload_ids": ["a", "b", "c"]}`, []string{"UploadIDs"}},
+	}
+	for _, tt := range tests {
+		var p testPatch
+		if err := Decode(strings.NewReader(tt.document), &p); err != nil {
+			t.Fatal(err)
+		}
+
+		var invalid []string
+		if err := validate.Struct(p); err != nil {
+			for _, fieldErr := range err.(validator.ValidationErrors) {
+				invalid = append(invalid, fieldErr.Field())
+			}
+		}
+		if !reflect.DeepEqual(invalid, tt.invalid) {
+			t.Errorf("%s: invalid fields = %v, want %v", tt.document, invalid, tt.invalid)
+		}
+	}
+}
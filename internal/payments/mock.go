@@ -0,0 +1,199 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MockSignatureHeader carries the hex HMAC-SHA256 of the webhook body, keyed with the mock secret
+const MockSignatureHeader = "X-Mock-Signature"
+
+// Mock payment methods, choosing the simulated outcome of Confirm
+const (
+	MockMethodSuccess = "mock_success" // succeeds right away
+	MockMethodFailure = "mock_failure" // is declined right away
+	MockMethodDelayed = "mock_delayed" // is processing until the webhook reports success after the delay
+)
+
+// MockProvider is an in-memory gateway for development and tests. Every status change is
+// also reported to WebhookURL (when set) as a signed webhook, like a real gateway would.
+type MockProvider struct {
+	secret       string
+	webhookURL   string
+	webhookDelay time.Duration
+	client       *http.Client
+
+	mu      sync.Mutex
+	intents map[string]*Intent
+	keys    map[string]string // idempotency key -> intent ID
+}
+
+// NewMockProvider creates a mock gateway signing webhooks with secret. Delayed payments
+// are settled webhookDelay after they are confirmed.
+func NewMockProvider(secret, webhookURL string, webhookDelay time.Duration) *MockProvider {
+	return &MockProvider{
+		secret:       secret,
+		webhookURL:   webhookURL,
+		webhookDelay: webhookDelay,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		intents:      make(map[string]*Intent),
+		keys:         make(map[string]string),
+	}
+}
+
+func (p *MockProvider) Name() string {
+	return "mock"
+}
+
+func (p *MockProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("invalid amount %d", req.Amount)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, ok := p.keys[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		intent := *p.intents[id]
+		return &intent, nil
+	}
+
+	intent := &Intent{
+		ID:       "pi_mock_" + randomID(),
+		Status:   StatusPending,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+	}
+	p.intents[intent.ID] = intent
+	if req.IdempotencyKey != "" {
+		p.keys[req.IdempotencyKey] = intent.ID
+	}
+
+	result := *intent
+	return &result, nil
+}
+
+func (p *MockProvider) Confirm(ctx context.Context, intentID, method string) (*Intent, error) {
+	switch method {
+	case MockMethodSuccess:
+		return p.transition(intentID, StatusSucceeded, "")
+	case MockMethodFailure:
+		return p.transition(intentID, StatusFailed, "card_declined")
+	case MockMethodDelayed:
+		intent, err := p.transition(intentID, StatusProcessing, "")
+		if err != nil {
+			return nil, err
+		}
+		time.AfterFunc(p.webhookDelay, func() {
+			if _, err := p.transition(intentID, StatusSucceeded, ""); err != nil {
+				log.Printf("Mock payment %s: %v", intentID, err)
+			}
+		})
+		return intent, nil
+	}
+	return nil, fmt.Errorf("unsupported payment method %q, use %s, %s or %s", method, MockMethodSuccess, MockMethodFailure, MockMethodDelayed)
+}
+
+func (p *MockProvider) Refund(ctx context.Context, intentID string) (*Intent, error) {
+	return p.transition(intentID, StatusRefunded, "")
+}
+
+func (p *MockProvider) VerifyWebhook(payload []byte, header http.Header) (*Event, error) {
+	signature, err := hex.DecodeString(header.Get(MockSignatureHeader))
+	if err != nil || !hmac.Equal(signature, p.sign(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	if event.ID == "" || event.IntentID == "" || event.Status == "" {
+		return nil, fmt.Errorf("invalid webhook payload: missing id, intent_id or status")
+	}
+	return &event, nil
+}
+
+// Sign returns the signature header value for a webhook body
+func (p *MockProvider) Sign(payload []byte) string {
+	return hex.EncodeToString(p.sign(payload))
+}
+
+func (p *MockProvider) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// transition changes the status of an intent and reports the change by webhook
+func (p *MockProvider) transition(intentID, status, failureReason string) (*Intent, error) {
+	p.mu.Lock()
+	intent, ok := p.intents[intentID]
+	if !ok {
+		p.mu.Unlock()
+		return nil, ErrIntentNotFound
+	}
+	if !CanTransition(intent.Status, status) {
+		p.mu.Unlock()
+		return nil, ErrInvalidState
+	}
+	intent.Status = status
+	intent.FailureReason = failureReason
+	result := *intent
+	p.mu.Unlock()
+
+	go p.sendWebhook(Event{
+		ID:            "evt_mock_" + randomID(),
+		IntentID:      intentID,
+		Status:        status,
+		FailureReason: failureReason,
+	})
+	return &result, nil
+}
+
+func (p *MockProvider) sendWebhook(event Event) {
+	if p.webhookURL == "" {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Mock payment webhook %s: %v", event.ID, err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, p.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		log.Printf("Mock payment webhook %s: %v", event.ID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(MockSignatureHeader, p.Sign(payload))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		log.Printf("Mock payment webhook %s: %v", event.ID, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("Mock payment webhook %s: status %d", event.ID, resp.StatusCode)
+	}
+}
+
+func randomID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,161 @@
+package payments
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// webhookRecorder collects the verified events the mock provider delivers
+func webhookRecorder(t *testing.T, provider func() *MockProvider) (string, <-chan *Event) {
+	t.Helper()
+
+	events := make(chan *Event, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		event, err := provider().VerifyWebhook(payload, r.Header)
+		if err != nil {
+			t.Errorf("webhook verification failed: %v", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		events <- event
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, events
+}
+
+func nextEvent(t *testing.T, events <-chan *Event) *Event {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook delivered")
+		return nil
+	}
+}
+
+func TestMockProviderOutcomes(t *testing.T) {
+	var provider *MockProvider
+	url, events := webhookRecorder(t, func() *MockProvider { return provider })
+	provider = NewMockProvider("secret", url, 50*time.Millisecond)
+	ctx := context.Background()
+
+	tests := []struct {
+		method   string
+		confirm  string // status returned by Confirm
+		webhooks []string
+	}{
+		{MockMethodSuccess, StatusSucceeded, []string{StatusSucceeded}},
+		{MockMethodFailure, StatusFailed, []string{StatusFailed}},
+		{MockMethodDelayed, StatusProcessing, []string{StatusProcessing, StatusSucceeded}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			intent, err := provider.CreateIntent(ctx, IntentRequest{Amount: 4999, Currency: "lei", IdempotencyKey: tt.method})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			confirmed, err := provider.Confirm(ctx, intent.ID, tt.method)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if confirmed.Status != tt.confirm {
+				t.Errorf("Confirm status = %s, want %s", confirmed.Status, tt.confirm)
+			}
+
+			for _, want := range tt.webhooks {
+				event := nextEvent(t, events)
+				if event.IntentID != intent.ID || event.Status != want {
+					t.Errorf("webhook = %s %s, want %s %s", event.IntentID, event.Status, intent.ID, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMockProviderIdempotentIntent(t *testing.T) {
+	provider := NewMockProvider("secret", "", 0)
+	ctx := context.Background()
+
+	first, err := provider.CreateIntent(ctx, IntentRequest{Amount: 100, Currency: "lei", IdempotencyKey: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := provider.CreateIntent(ctx, IntentRequest{Amount: 100, Currency: "lei", IdempotencyKey: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != second.ID {
+		t.Errorf("same idempotency key created intents %s and %s", first.ID, second.ID)
+	}
+}
+
+func TestMockProviderRefund(t *testing.T) {
+	provider := NewMockProvider("secret", "", 0)
+	ctx := context.Background()
+
+	intent, _ := provider.CreateIntent(ctx, IntentRequest{Amount: 100, Currency: "lei"})
+	if _, err := provider.Refund(ctx, intent.ID); err != ErrInvalidState {
+		t.Errorf("refunding a pending intent: err = %v, want ErrInvalidState", err)
+	}
+	if _, err := provider.Confirm(ctx, intent.ID, MockMethodSuccess); err != nil {
+		t.Fatal(err)
+	}
+	refunded, err := provider.Refund(ctx, intent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refunded.Status != StatusRefunded {
+		t.Errorf("status = %s, want %s", refunded.Status, StatusRefunded)
+	}
+}
+
+func TestMockProviderVerifyWebhook(t *testing.T) {
+	provider := NewMockProvider("secret", "", 0)
+	payload := []byte(`{"id":"evt_1","intent_id":"pi_1","status":"succeeded"}`)
+
+	header := http.Header{}
+	header.Set(MockSignatureHeader, provider.Sign(payload))
+	event, err := provider.VerifyWebhook(payload, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != "evt_1" || event.IntentID != "pi_1" || event.Status != StatusSucceeded {
+		t.Errorf("event = %+v", event)
+	}
+
+	header.Set(MockSignatureHeader, NewMockProvider("other", "", 0).Sign(payload))
+	if _, err := provider.VerifyWebhook(payload, header); err != ErrInvalidSignature {
+		t.Errorf("wrong secret: err = %v, want ErrInvalidSignature", err)
+	}
+	header.Set(MockSignatureHeader, provider.Sign(payload))
+	if _, err := provider.VerifyWebhook([]byte(`{"id":"evt_2","intent_id":"pi_1","status":"succeeded"}`), header); err != ErrInvalidSignature {
+		t.Errorf("tampered payload: err = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StatusPending, StatusSucceeded, true},
+		{StatusProcessing, StatusFailed, true},
+		{StatusSucceeded, StatusRefunded, true},
+		{StatusSucceeded, StatusFailed, false},
+		{StatusFailed, StatusSucceeded, false},
+		{StatusRefunded, StatusSucceeded, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package payments abstracts the payment gateways used to pay for service orders.
+package payments
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+)
+
+// Payment statuses, shared by provider intents and stored payments
+const (
+	StatusPending    = "pending"    // created, waiting for the buyer to confirm
+	StatusProcessing = "processing" // confirmed, the outcome arrives by webhook
+	StatusSucceeded  = "succeeded"
+	StatusFailed     = "failed"
+	StatusRefunded   = "refunded"
+)
+
+var (
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrInvalidState     = errors.New("payment intent cannot be changed in its current state")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// transitions lists the statuses each status can move to
+var transitions = map[string][]string{
+	StatusPending:    {StatusProcessing, StatusSucceeded, StatusFailed},
+	StatusProcessing: {StatusSucceeded, StatusFailed},
+	StatusSucceeded:  {StatusRefunded},
+}
+
+// CanTransition reports whether a payment may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IntentRequest describes a payment to collect. Amount is in minor units (cents).
+type IntentRequest struct {
+	Amount         int64
+	Currency       string
+	Reference      string // our payment UUID, shown in the gateway dashboard
+	IdempotencyKey string // repeated requests with the same key return the same intent
+}
+
+// Intent is a payment as known by the provider
+type Intent struct {
+	ID            string
+	Status        string
+	Amount        int64
+	Currency      string
+	FailureReason string
+}
+
+// Event is a verified webhook notification about an intent
+type Event struct {
+	ID            string `json:"id"`
+	IntentID      string `json:"intent_id"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// Provider is a payment gateway
+type Provider interface {
+	// Name identifies the provider in stored payments and webhook URLs
+	Name() string
+	// CreateIntent registers a payment to collect
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+	// Confirm charges an intent with the given payment method
+	Confirm(ctx context.Context, intentID, method string) (*Intent, error)
+	// Refund returns the full amount of a succeeded intent
+	Refund(ctx context.Context, intentID string) (*Intent, error)
+	// VerifyWebhook checks the signature of a webhook request and decodes its event
+	VerifyWebhook(payload []byte, header http.Header) (*Event, error)
+}
+
+// ToMinorUnits converts an amount to minor units (cents)
+func ToMinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
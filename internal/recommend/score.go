@@ -0,0 +1,184 @@
+package recommend
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/repository"
+)
+
+// Score components; an ideal listing scores 100 before the manual recommended boost
+const (
+	maxCompleteness = 25.0
+	maxImages       = 20.0
+	maxPrice        = 20.0
+	maxRecency      = 20.0
+	maxEngagement   = 15.0
+
+	// RecommendedBoost is added to the score of listings an admin flagged as recommended
+	RecommendedBoost = 15.0
+)
+
+const (
+	fullImageCount      = 8                   // images needed for the full image score
+	minDescriptionChars = 50                  // shorter descriptions do not count towards completeness
+	recencyHalfLife     = 14 * 24 * time.Hour // the recency score halves every two weeks
+	fullEngagement      = 1000.0              // weighted engagement for the full engagement score
+	comparisonWeight    = 5                   // one comparison list entry counts as this many views
+	minMarketListings   = 3                   // listings needed to derive a market price
+)
+
+// ListingScore computes the quality and freshness score of a listing from completeness, image count,
+// price versus market (0 when unknown), recency and engagement, plus the recommended boost
+func ListingScore(row *repository.VehicleScoreRow, marketPrice float64, now time.Time) float64 {
+	score := completenessScore(row) + imageScore(row) + priceScore(row, marketPrice) +
+		recencyScore(row, now) + engagementScore(row)
+	if row.Recommended {
+		score += RecommendedBoost
+	}
+	return math.Round(score*100) / 100
+}
+
+func completenessScore(row *repository.VehicleScoreRow) float64 {
+	filled := []bool{
+		row.Description != nil && len(strings.TrimSpace(*row.Description)) >= minDescriptionChars,
+		row.FeaturedImage != nil && *row.FeaturedImage != "",
+		row.AutomobileID != nil,
+		row.VIN != nil && *row.VIN != "",
+		row.EngineCapacity != nil && *row.EngineCapacity > 0,
+		row.PowerHP != nil && *row.PowerHP > 0,
+		row.Kilometers != nil,
+		row.Color != nil && *row.Color != "",
+		row.NumberOfKeys != nil && *row.NumberOfKeys > 0,
+		row.Phone != nil && *row.Phone != "",
+	}
+
+	count := 0
+	for _, ok := range filled {
+		if ok {
+			count++
+		}
+	}
+	return maxCompleteness * float64(count) / float64(len(filled))
+}
+
+func imageScore(row *repository.VehicleScoreRow) float64 {
+	return maxImages * math.Min(float64(row.ImageCount), fullImageCount) / fullImageCount
+}
+
+// priceScore rewards listings priced at or below the market: full score at 90% of the market price or less,
+// half at the market price and nothing from 130% up. Without a market price the score is neutral.
+func priceScore(row *repository.VehicleScoreRow, marketPrice float64) float64 {
+	if marketPrice <= 0 || row.PriceNormalized == nil || *row.PriceNormalized <= 0 {
+		return maxPrice / 2
+	}
+	ratio := *row.PriceNormalized / marketPrice
+	return maxPrice * math.Max(0, math.Min(1, (1.3-ratio)/0.4))
+}
+
+func recencyScore(row *repository.VehicleScoreRow, now time.Time) float64 {
+	age := now.Sub(row.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+	return maxRecency * math.Pow(0.5, float64(age)/float64(recencyHalfLife))
+}
+
+// engagementScore grows logarithmically with views and comparison list entries
+func engagementScore(row *repository.VehicleScoreRow) float64 {
+	engagement := float64(row.ViewCount + comparisonWeight*row.ComparisonCount)
+	return maxEngagement * math.Min(1, math.Log10(1+engagement)/math.Log10(1+fullEngagement))
+}
+
+// MarketPrices returns the median normalized price per model (catalog automobile, or brand and model name),
+// for models with enough listings
+func MarketPrices(rows []repository.VehicleScoreRow) map[string]float64 {
+	pricesByModel := make(map[string][]float64)
+	for _, row := range rows {
+		if row.PriceNormalized == nil || *row.PriceNormalized <= 0 {
+			continue
+		}
+		key := marketKey(&row)
+		pricesByModel[key] = append(pricesByModel[key], *row.PriceNormalized)
+	}
+
+	markets := make(map[string]float64)
+	for key, prices := range pricesByModel {
+		if len(prices) < minMarketListings {
+			continue
+		}
+		sort.Float64s(prices)
+		middle := len(prices) / 2
+		if len(prices)%2 == 0 {
+			markets[key] = (prices[middle-1] + prices[middle]) / 2
+		} else {
+			markets[key] = prices[middle]
+		}
+	}
+	return markets
+}
+
+func marketKey(row *repository.VehicleScoreRow) string {
+	if row.AutomobileID != nil {
+		return "automobile:" + strconv.FormatUint(*row.AutomobileID, 10)
+	}
+	return "model:" + strings.ToLower(strings.TrimSpace(row.Brand)) + "|" + strings.ToLower(strings.TrimSpace(row.Model))
+}
+
+// RefreshScores recomputes and stores the score of every active listing and returns how many were scored
+func RefreshScores(vehicleRepo *repository.VehicleRepository, now time.Time) (int, error) {
+	rows, err := vehicleRepo.GetScoreRows()
+	if err != nil {
+		return 0, err
+	}
+
+	markets := MarketPrices(rows)
+	scores := make(map[uint64]float64, len(rows))
+	for i := range rows {
+		scores[rows[i].ID] = ListingScore(&rows[i], markets[marketKey(&rows[i])], now)
+	}
+
+	if err := vehicleRepo.UpdateScores(scores); err != nil {
+		return 0, err
+	}
+	return len(scores), nil
+}
+
+// Diversify picks up to limit vehicles in their current order with at most maxPerBrand of each brand.
+// When that leaves fewer than limit vehicles, the skipped ones fill the remaining places in order.
+func Diversify(vehicles []models.Vehicle, limit, maxPerBrand int) []models.Vehicle {
+	if maxPerBrand <= 0 {
+		maxPerBrand = 1
+	}
+
+	picked := make([]models.Vehicle, 0, limit)
+	var skipped []models.Vehicle
+	perBrand := make(map[string]int)
+	for _, vehicle := range vehicles {
+		if len(picked) == limit {
+			break
+		}
+		key := "name:" + strings.ToLower(vehicle.Brand)
+		if vehicle.BrandID != nil {
+			key = "id:" + strconv.FormatUint(*vehicle.BrandID, 10)
+		}
+		if perBrand[key] >= maxPerBrand {
+			skipped = append(skipped, vehicle)
+			continue
+		}
+		perBrand[key]++
+		picked = append(picked, vehicle)
+	}
+
+	for _, vehicle := range skipped {
+		if len(picked) == limit {
+			break
+		}
+		picked = append(picked, vehicle)
+	}
+	return picked
+}
@@ -0,0 +1,126 @@
+package recommend
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"autoelys_backend/internal/models"
+)
+
+// Scoring weights; a candidate matching the target on every criterion scores 100
+const (
+	weightBrand      = 25.0
+	weightModel      = 20.0
+	weightBodyType   = 12.0
+	weightFuelType   = 10.0
+	weightYear       = 10.0
+	weightPrice      = 12.0
+	weightKilometers = 6.0
+	weightCity       = 5.0
+)
+
+// Proximity ranges beyond which year, price and kilometers stop contributing to the score
+const (
+	yearRange          = 5     // years
+	priceRangeRatio    = 0.5   // of the target price
+	minKilometersRange = 50000 // km, widened to half the target mileage for high-mileage cars
+)
+
+// Scored is a candidate vehicle with its similarity score (0-100)
+type Scored struct {
+	Vehicle models.Vehicle
+	Score   float64
+}
+
+// Score rates how similar a candidate is to the target on brand/model, body type, fuel type,
+// year, price and kilometer proximity and city. Prices are compared in the base currency.
+func Score(target, candidate *models.Vehicle) float64 {
+	score := 0.0
+
+	if sameBrand(target, candidate) {
+		score += weightBrand
+		if sameModel(target, candidate) {
+			score += weightModel
+		}
+	}
+	if target.BodyTypeID != 0 && target.BodyTypeID == candidate.BodyTypeID {
+		score += weightBodyType
+	}
+	if target.FuelTypeID != 0 && target.FuelTypeID == candidate.FuelTypeID {
+		score += weightFuelType
+	}
+
+	if target.Year > 0 && candidate.Year > 0 {
+		score += weightYear * proximity(float64(target.Year), float64(candidate.Year), yearRange)
+	}
+	if target.PriceNormalized != nil && candidate.PriceNormalized != nil && *target.PriceNormalized > 0 {
+		score += weightPrice * proximity(*target.PriceNormalized, *candidate.PriceNormalized, *target.PriceNormalized*priceRangeRatio)
+	}
+	if target.Kilometers != nil && candidate.Kilometers != nil {
+		kmRange := math.Max(minKilometersRange, float64(*target.Kilometers)/2)
+		score += weightKilometers * proximity(float64(*target.Kilometers), float64(*candidate.Kilometers), kmRange)
+	}
+
+	if target.City != "" && strings.EqualFold(strings.TrimSpace(target.City), strings.TrimSpace(candidate.City)) {
+		score += weightCity
+	}
+
+	return math.Round(score*100) / 100
+}
+
+// Similar scores the candidates against the target and returns the best ones, at most limit.
+// Candidates without any similarity are dropped. Ties are broken by the newest listing, then the lowest ID,
+// so the same data always yields the same order.
+func Similar(target *models.Vehicle, candidates []models.Vehicle, limit int) []Scored {
+	scored := make([]Scored, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID == target.ID {
+			continue
+		}
+		if score := Score(target, &candidate); score > 0 {
+			scored = append(scored, Scored{Vehicle: candidate, Score: score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		a, b := scored[i], scored[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Vehicle.CreatedAt.Equal(b.Vehicle.CreatedAt) {
+			return a.Vehicle.CreatedAt.After(b.Vehicle.CreatedAt)
+		}
+		return a.Vehicle.ID < b.Vehicle.ID
+	})
+
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored
+}
+
+func sameBrand(target, candidate *models.Vehicle) bool {
+	if target.BrandID != nil && candidate.BrandID != nil {
+		return *target.BrandID == *candidate.BrandID
+	}
+	return target.Brand != "" && strings.EqualFold(target.Brand, candidate.Brand)
+}
+
+func sameModel(target, candidate *models.Vehicle) bool {
+	if target.AutomobileID != nil && candidate.AutomobileID != nil {
+		return *target.AutomobileID == *candidate.AutomobileID
+	}
+	return target.Model != "" && strings.EqualFold(strings.TrimSpace(target.Model), strings.TrimSpace(candidate.Model))
+}
+
+// proximity is 1 for equal values and decreases linearly to 0 at the given distance
+func proximity(a, b, distance float64) float64 {
+	if distance <= 0 {
+		if a == b {
+			return 1
+		}
+		return 0
+	}
+	return math.Max(0, 1-math.Abs(a-b)/distance)
+}
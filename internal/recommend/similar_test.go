@@ -0,0 +1,87 @@
+package recommend
+
+import (
+	"testing"
+	"time"
+
+	"autoelys_backend/internal/models"
+)
+
+func uint64Ptr(v uint64) *uint64 { return &v }
+
+func float64Ptr(v float64) *float64 { return &v }
+
+func intPtr(v int) *int { return &v }
+
+func testTarget() *models.Vehicle {
+	return &models.Vehicle{
+		ID:              1,
+		BrandID:         uint64Ptr(10),
+		AutomobileID:    uint64Ptr(100),
+		Brand:           "Volkswagen",
+		Model:           "Golf",
+		BodyTypeID:      2,
+		FuelTypeID:      3,
+		Year:            2018,
+		PriceNormalized: float64Ptr(15000),
+		Kilometers:      intPtr(80000),
+		City:            "Skopje",
+	}
+}
+
+func TestScore(t *testing.T) {
+	target := testTarget()
+
+	tests := []struct {
+		name      string
+		candidate models.Vehicle
+		want      float64
+	}{
+		{"identical", *target, 100},
+		{"same model by name", models.Vehicle{Brand: "volkswagen", Model: " golf "}, weightBrand + weightModel},
+		{"other model of the brand", models.Vehicle{BrandID: uint64Ptr(10), AutomobileID: uint64Ptr(101), Model: "Golf"}, weightBrand},
+		{"model without the brand", models.Vehicle{BrandID: uint64Ptr(11), AutomobileID: uint64Ptr(100)}, 0},
+		{"body and fuel", models.Vehicle{BodyTypeID: 2, FuelTypeID: 3}, weightBodyType + weightFuelType},
+		{"year halfway", models.Vehicle{Year: 2020}, weightYear * 0.6},
+		{"price out of range", models.Vehicle{PriceNormalized: float64Ptr(30000)}, 0},
+		{"price halfway", models.Vehicle{PriceNormalized: float64Ptr(11250)}, weightPrice * 0.5},
+		{"city ignoring case", models.Vehicle{City: "skopje "}, weightCity},
+		{"nothing in common", models.Vehicle{Brand: "Fiat", Year: 1990}, 0},
+	}
+	for _, tt := range tests {
+		if got := Score(target, &tt.candidate); got != tt.want {
+			t.Errorf("%s: Score = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	target := testTarget()
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	candidates := []models.Vehicle{
+		{ID: 1, BrandID: uint64Ptr(10), AutomobileID: uint64Ptr(100)},
+		{ID: 2, BodyTypeID: 2, CreatedAt: newer},
+		{ID: 3, BrandID: uint64Ptr(10), AutomobileID: uint64Ptr(100), CreatedAt: older},
+		{ID: 4, BodyTypeID: 2, CreatedAt: older},
+		{ID: 5, Brand: "Fiat", CreatedAt: newer},
+		{ID: 6, BodyTypeID: 2, CreatedAt: older},
+		{ID: 7, BrandID: uint64Ptr(10), CreatedAt: older},
+	}
+
+	got := Similar(target, candidates, 0)
+	want := []uint64{3, 7, 2, 4, 6}
+	if len(got) != len(want) {
+		t.Fatalf("Similar returned %d vehicles, want %d", len(got), len(want))
+	}
+	for i, scored := range got {
+		if scored.Vehicle.ID != want[i] {
+			t.Errorf("position %d: vehicle %d (score %v), want %d", i, scored.Vehicle.ID, scored.Score, want[i])
+		}
+	}
+
+	if limited := Similar(target, candidates, 2); len(limited) != 2 || limited[1].Vehicle.ID != 7 {
+		t.Errorf("Similar with limit 2 = %+v, want vehicles 3 and 7", limited)
+	}
+}
@@ -48,3 +48,27 @@ func (r *AutomobileRepository) GetByBrandID(brandID uint64) ([]models.Automobile
 
 	return automobiles, nil
 }
+
+// FindByID retrieves an automobile by ID
+func (r *AutomobileRepository) FindByID(id uint64) (*models.Automobile, error) {
+	query := `SELECT a.id, a.brand_id, a.name
+	          FROM automobiles a
+	          INNER JOIN brands b ON a.brand_id = b.id
+	          WHERE a.id = ? AND b.deleted_at IS NULL`
+
+	var automobile models.Automobile
+	err := r.db.QueryRow(query, id).Scan(
+		&automobile.ID,
+		&automobile.BrandID,
+		&automobile.Name,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &automobile, nil
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"autoelys_backend/internal/booking"
+	"autoelys_backend/internal/models"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrBookingNotFound = errors.New("booking not found")
+var ErrBookingNotConfirmed = errors.New("booking is not confirmed")
+var ErrSlotUnavailable = errors.New("slot is not available")
+var ErrBookingExceptionNotFound = errors.New("booking exception not found")
+
+type BookingRepository struct {
+	db *sql.DB
+}
+
+func NewBookingRepository(db *sql.DB) *BookingRepository {
+	return &BookingRepository{db: db}
+}
+
+// GetHours retrieves the weekly working hours, by weekday and opening time
+func (r *BookingRepository) GetHours() ([]models.BookingHours, error) {
+	rows, err := r.db.Query(`
+		SELECT id, weekday, TIME_FORMAT(opens_at, '%H:%i'), TIME_FORMAT(closes_at, '%H:%i'), capacity
+		FROM booking_hours
+		ORDER BY weekday, opens_at
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := []models.BookingHours{}
+	for rows.Next() {
+		var h models.BookingHours
+		if err := rows.Scan(&h.ID, &h.Weekday, &h.OpensAt, &h.ClosesAt, &h.Capacity); err != nil {
+			return nil, err
+		}
+		hours = append(hours, h)
+	}
+	return hours, rows.Err()
+}
+
+// ReplaceHours replaces the whole weekly schedule
+func (r *BookingRepository) ReplaceHours(hours []models.BookingHours) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM booking_hours"); err != nil {
+		return err
+	}
+	for i := range hours {
+		result, err := tx.Exec("INSERT INTO booking_hours (weekday, opens_at, closes_at, capacity) VALUES (?, ?, ?, ?)",
+			hours[i].Weekday, hours[i].OpensAt, hours[i].ClosesAt, hours[i].Capacity)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		hours[i].ID = uint(id)
+	}
+
+	return tx.Commit()
+}
+
+// GetExceptions retrieves the exceptions between two dates (YYYY-MM-DD, inclusive)
+func (r *BookingRepository) GetExceptions(from, to string) ([]models.BookingException, error) {
+	rows, err := r.db.Query(`
+		SELECT id, DATE_FORMAT(date, '%Y-%m-%d'), TIME_FORMAT(opens_at, '%H:%i'), TIME_FORMAT(closes_at, '%H:%i'), capacity, note, created_at
+		FROM booking_exceptions
+		WHERE date BETWEEN ? AND ?
+		ORDER BY date, opens_at
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exceptions := []models.BookingException{}
+	for rows.Next() {
+		var e models.BookingException
+		if err := rows.Scan(&e.ID, &e.Date, &e.OpensAt, &e.ClosesAt, &e.Capacity, &e.Note, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, e)
+	}
+	return exceptions, rows.Err()
+}
+
+func (r *BookingRepository) CreateException(exception *models.BookingException) error {
+	result, err := r.db.Exec("INSERT INTO booking_exceptions (date, opens_at, closes_at, capacity, note) VALUES (?, ?, ?, ?, ?)",
+		exception.Date, exception.OpensAt, exception.ClosesAt, exception.Capacity, exception.Note)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	exception.ID = uint(id)
+	return nil
+}
+
+func (r *BookingRepository) DeleteException(id uint) error {
+	result, err := r.db.Exec("DELETE FROM booking_exceptions WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrBookingExceptionNotFound
+	}
+	return nil
+}
+
+// GetBooked retrieves the confirmed appointments overlapping the day starting at dayStart
+func (r *BookingRepository) GetBooked(dayStart time.Time) ([]booking.Interval, error) {
+	return bookedIntervals(r.db, dayStart, 0)
+}
+
+const bookingSelect = `
+	SELECT b.id, b.uuid, b.user_id, CONCAT(u.first_name, ' ', u.last_name), u.email, b.service_id, s.uuid, s.title,
+		b.starts_at, b.ends_at, b.status, b.notes, b.created_at, b.updated_at
+	FROM bookings b
+	INNER JOIN users u ON u.id = b.user_id
+	INNER JOIN services s ON s.id = b.service_id
+`
+
+func scanBooking(scanner interface{ Scan(...interface{}) error }) (*models.Booking, error) {
+	b := &models.Booking{}
+	err := scanner.Scan(
+		&b.ID,
+		&b.UUID,
+		&b.UserID,
+		&b.UserName,
+		&b.UserEmail,
+		&b.ServiceID,
+		&b.ServiceUUID,
+		&b.ServiceTitle,
+		&b.StartsAt,
+		&b.EndsAt,
+		&b.Status,
+		&b.Notes,
+		&b.CreatedAt,
+		&b.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *BookingRepository) FindByUUID(uuid string) (*models.Booking, error) {
+	b, err := scanBooking(r.db.QueryRow(bookingSelect+" WHERE b.uuid = ?", uuid))
+	if err == sql.ErrNoRows {
+		return nil, ErrBookingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetByUserID retrieves the bookings of a user, latest appointment first
+func (r *BookingRepository) GetByUserID(userID uint64, limit, offset int) ([]models.Booking, int, error) {
+	return r.list(" WHERE b.user_id = ?", []interface{}{userID}, "b.starts_at DESC", limit, offset)
+}
+
+// GetAll retrieves the bookings with appointments in [from, to), optionally with a status, in appointment order
+func (r *BookingRepository) GetAll(from, to time.Time, status string, limit, offset int) ([]models.Booking, int, error) {
+	conditions := []string{"b.starts_at >= ?", "b.starts_at < ?"}
+	args := []interface{}{from, to}
+	if status != "" {
+		conditions = append(conditions, "b.status = ?")
+		args = append(args, status)
+	}
+	return r.list(" WHERE "+strings.Join(conditions, " AND "), args, "b.starts_at", limit, offset)
+}
+
+func (r *BookingRepository) list(whereClause string, args []interface{}, order string, limit, offset int) ([]models.Booking, int, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM bookings b"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(bookingSelect+whereClause+" ORDER BY "+order+", b.id LIMIT ? OFFSET ?", append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	bookings := []models.Booking{}
+	for rows.Next() {
+		b, err := scanBooking(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		bookings = append(bookings, *b)
+	}
+	return bookings, total, rows.Err()
+}
+
+// Create stores a confirmed booking on the day starting at dayStart. The day is locked while fits
+// checks the slot against the day's other appointments, so concurrent bookings cannot overbook it;
+// ErrSlotUnavailable is returned when it does not fit.
+func (r *BookingRepository) Create(b *models.Booking, dayStart time.Time, fits func(booked []booking.Interval) bool) error {
+	b.UUID = uuid.New().String()
+	b.Status = models.BookingStatusConfirmed
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	booked, err := lockBookingDay(tx, dayStart, 0)
+	if err != nil {
+		return err
+	}
+	if !fits(booked) {
+		return ErrSlotUnavailable
+	}
+
+	result, err := tx.Exec("INSERT INTO bookings (uuid, user_id, service_id, starts_at, ends_at, status, notes) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		b.UUID, b.UserID, b.ServiceID, b.StartsAt, b.EndsAt, b.Status, b.Notes)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	b.ID = uint64(id)
+
+	return tx.Commit()
+}
+
+// Reschedule moves a confirmed booking to a new time on the day starting at dayStart, checked like Create
+// against the other appointments of that day
+func (r *BookingRepository) Reschedule(id uint64, startsAt, endsAt, dayStart time.Time, fits func(booked []booking.Interval) bool) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow("SELECT status FROM bookings WHERE id = ? FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return ErrBookingNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.BookingStatusConfirmed {
+		return ErrBookingNotConfirmed
+	}
+
+	booked, err := lockBookingDay(tx, dayStart, id)
+	if err != nil {
+		return err
+	}
+	if !fits(booked) {
+		return ErrSlotUnavailable
+	}
+
+	if _, err := tx.Exec("UPDATE bookings SET starts_at = ?, ends_at = ? WHERE id = ?", startsAt, endsAt, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Cancel cancels a confirmed booking
+func (r *BookingRepository) Cancel(id uint64) error {
+	result, err := r.db.Exec("UPDATE bookings SET status = 'cancelled' WHERE id = ? AND status = 'confirmed'", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrBookingNotConfirmed
+	}
+	return nil
+}
+
+// lockBookingDay locks the day starting at dayStart and returns its confirmed appointments other than excludeID
+func lockBookingDay(tx *sql.Tx, dayStart time.Time, excludeID uint64) ([]booking.Interval, error) {
+	// The upsert takes an exclusive lock on the day row, held until the transaction ends
+	day := dayStart.Format("2006-01-02")
+	if _, err := tx.Exec("INSERT INTO booking_days (day) VALUES (?) ON DUPLICATE KEY UPDATE day = day", day); err != nil {
+		return nil, err
+	}
+	return bookedIntervals(tx, dayStart, excludeID)
+}
+
+func bookedIntervals(q interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}, dayStart time.Time, excludeID uint64) ([]booking.Interval, error) {
+	rows, err := q.Query("SELECT starts_at, ends_at FROM bookings WHERE status = 'confirmed' AND starts_at < ? AND ends_at > ? AND id <> ?",
+		dayStart.AddDate(0, 0, 1), dayStart, excludeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var booked []booking.Interval
+	for rows.Next() {
+		var interval booking.Interval
+		if err := rows.Scan(&interval.Start, &interval.End); err != nil {
+			return nil, err
+		}
+		booked = append(booked, interval)
+	}
+	return booked, rows.Err()
+}
@@ -1,20 +1,48 @@
 package repository
 
 import (
+	"autoelys_backend/internal/cache"
 	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/utils"
 	"database/sql"
+	"time"
 )
 
 type BrandRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	cache *cache.Store
 }
 
 func NewBrandRepository(db *sql.DB) *BrandRepository {
 	return &BrandRepository{db: db}
 }
 
-// GetAll retrieves all brands from the database
+// The brand catalog changes only with catalog imports
+const (
+	brandsCacheKey = "brands:all"
+	brandsCacheTTL = time.Hour
+)
+
+// SetCache caches GetAll in store
+func (r *BrandRepository) SetCache(store *cache.Store) {
+	r.cache = store
+}
+
+// InvalidateCache drops the cached brands
+func (r *BrandRepository) InvalidateCache() {
+	r.cache.Invalidate(brandsCacheKey)
+}
+
+// GetAll retrieves all brands from the database. The result is cached; callers get their own copy of the slice.
 func (r *BrandRepository) GetAll() ([]models.Brand, error) {
+	brands, err := cache.Fetch(r.cache, brandsCacheKey, brandsCacheTTL, r.getAll)
+	if err != nil {
+		return nil, err
+	}
+	return append([]models.Brand(nil), brands...), nil
+}
+
+func (r *BrandRepository) getAll() ([]models.Brand, error) {
 	query := `SELECT id, name
 	          FROM brands
 	          WHERE deleted_at IS NULL
@@ -73,3 +101,20 @@ func (r *BrandRepository) FindByID(id uint64) (*models.Brand, error) {
 
 	return &brand, nil
 }
+
+// FindBySlug retrieves a brand by the slug of its name (e.g. "alfa-romeo"), from the cached catalog of
+// GetAll, so only its ID and name are set
+func (r *BrandRepository) FindBySlug(slug string) (*models.Brand, error) {
+	brands, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range brands {
+		if utils.GenerateSlug(brands[i].Name) == slug {
+			return &brands[i], nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// MaxComparisonVehicles is the maximum number of vehicles in a comparison
+const MaxComparisonVehicles = 4
+
+var ErrComparisonFull = errors.New("comparison list is full")
+
+type ComparisonRepository struct {
+	db *sql.DB
+}
+
+func NewComparisonRepository(db *sql.DB) *ComparisonRepository {
+	return &ComparisonRepository{db: db}
+}
+
+// GetSlugs retrieves the slugs of the vehicles in a user's comparison list, oldest first
+func (r *ComparisonRepository) GetSlugs(userID uint64) ([]string, error) {
+	query := `SELECT v.slug
+	FROM vehicle_comparisons vc
+	INNER JOIN vehicles v ON vc.vehicle_id = v.id
+	WHERE vc.user_id = ?
+	ORDER BY vc.created_at ASC, vc.vehicle_id ASC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slugs []string
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, err
+		}
+		slugs = append(slugs, slug)
+	}
+
+	return slugs, rows.Err()
+}
+
+// Add puts a vehicle in a user's comparison list. Adding a vehicle that is already listed is a no-op;
+// ErrComparisonFull is returned when the list already holds MaxComparisonVehicles vehicles. The user row
+// is locked while the list is counted, so concurrent adds cannot exceed the limit.
+func (r *ComparisonRepository) Add(userID, vehicleID uint64) error {
+	return withTx(r.db, func(tx *Tx) error {
+		var lockedID uint64
+		if err := tx.QueryRow("SELECT id FROM users WHERE id = ? FOR UPDATE", userID).Scan(&lockedID); err != nil {
+			return err
+		}
+
+		var exists bool
+		err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM vehicle_comparisons WHERE user_id = ? AND vehicle_id = ?)", userID, vehicleID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		var count int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM vehicle_comparisons WHERE user_id = ?", userID).Scan(&count); err != nil {
+			return err
+		}
+		if count >= MaxComparisonVehicles {
+			return ErrComparisonFull
+		}
+
+		_, err = tx.Exec("INSERT IGNORE INTO vehicle_comparisons (user_id, vehicle_id) VALUES (?, ?)", userID, vehicleID)
+		return err
+	})
+}
+
+// Remove takes a vehicle out of a user's comparison list and reports whether it was listed
+func (r *ComparisonRepository) Remove(userID, vehicleID uint64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM vehicle_comparisons WHERE user_id = ? AND vehicle_id = ?", userID, vehicleID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// Clear empties a user's comparison list
+func (r *ComparisonRepository) Clear(userID uint64) error {
+	_, err := r.db.Exec("DELETE FROM vehicle_comparisons WHERE user_id = ?", userID)
+	return err
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"autoelys_backend/internal/models"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+var ErrEquipmentNotFound = errors.New("equipment not found")
+var ErrEquipmentCategoryNotFound = errors.New("equipment category not found")
+var ErrDuplicateEquipmentName = errors.New("equipment name already exists")
+var ErrEquipmentCategoryInUse = errors.New("equipment category still has equipment")
+
+type EquipmentRepository struct {
+	db *sql.DB
+}
+
+func NewEquipmentRepository(db *sql.DB) *EquipmentRepository {
+	return &EquipmentRepository{db: db}
+}
+
+// GetCatalog returns all equipment categories with their equipment, in display order
+func (r *EquipmentRepository) GetCatalog() ([]models.EquipmentCategory, error) {
+	categories, err := r.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	equipment, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[uint16]int, len(categories))
+	for i := range categories {
+		index[categories[i].ID] = i
+		categories[i].Equipment = []models.Equipment{}
+	}
+	for _, item := range equipment {
+		if i, ok := index[item.CategoryID]; ok {
+			categories[i].Equipment = append(categories[i].Equipment, item)
+		}
+	}
+	return categories, nil
+}
+
+func (r *EquipmentRepository) GetCategories() ([]models.EquipmentCategory, error) {
+	rows, err := r.db.Query("SELECT id, name, display_name, sort_order FROM equipment_categories ORDER BY sort_order ASC, id ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []models.EquipmentCategory
+	for rows.Next() {
+		var category models.EquipmentCategory
+		if err := rows.Scan(&category.ID, &category.Name, &category.DisplayName, &category.SortOrder); err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+// GetAll returns all equipment ordered by category and display order
+func (r *EquipmentRepository) GetAll() ([]models.Equipment, error) {
+	query := `SELECT e.id, e.category_id, c.name, e.name, e.display_name, e.sort_order
+		FROM equipment e
+		INNER JOIN equipment_categories c ON c.id = e.category_id
+		ORDER BY c.sort_order ASC, c.id ASC, e.sort_order ASC, e.id ASC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var equipment []models.Equipment
+	for rows.Next() {
+		var item models.Equipment
+		if err := rows.Scan(&item.ID, &item.CategoryID, &item.Category, &item.Name, &item.DisplayName, &item.SortOrder); err != nil {
+			return nil, err
+		}
+		equipment = append(equipment, item)
+	}
+	return equipment, rows.Err()
+}
+
+// FindByNames returns the equipment with the given names and the names that do not exist
+func (r *EquipmentRepository) FindByNames(names []string) ([]models.Equipment, []string, error) {
+	if len(names) == 0 {
+		return nil, nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		args[i] = name
+	}
+
+	query := `SELECT e.id, e.category_id, c.name, e.name, e.display_name, e.sort_order
+		FROM equipment e
+		INNER JOIN equipment_categories c ON c.id = e.category_id
+		WHERE e.name IN (` + placeholders + `)
+		ORDER BY c.sort_order ASC, c.id ASC, e.sort_order ASC, e.id ASC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool)
+	var equipment []models.Equipment
+	for rows.Next() {
+		var item models.Equipment
+		if err := rows.Scan(&item.ID, &item.CategoryID, &item.Category, &item.Name, &item.DisplayName, &item.SortOrder); err != nil {
+			return nil, nil, err
+		}
+		found[item.Name] = true
+		equipment = append(equipment, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var unknown []string
+	for _, name := range names {
+		if !found[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	return equipment, unknown, nil
+}
+
+func (r *EquipmentRepository) CreateCategory(category *models.EquipmentCategory) error {
+	result, err := r.db.Exec(
+		"INSERT INTO equipment_categories (name, display_name, sort_order) VALUES (?, ?, ?)",
+		category.Name, category.DisplayName, category.SortOrder,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrDuplicateEquipmentName
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	category.ID = uint16(id)
+	return nil
+}
+
+func (r *EquipmentRepository) UpdateCategory(category *models.EquipmentCategory) error {
+	result, err := r.db.Exec(
+		"UPDATE equipment_categories SET name = ?, display_name = ?, sort_order = ? WHERE id = ?",
+		category.Name, category.DisplayName, category.SortOrder, category.ID,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrDuplicateEquipmentName
+		}
+		return err
+	}
+	return r.checkFound(result, "SELECT EXISTS(SELECT 1 FROM equipment_categories WHERE id = ?)", category.ID, ErrEquipmentCategoryNotFound)
+}
+
+// DeleteCategory removes an empty equipment category
+func (r *EquipmentRepository) DeleteCategory(id uint16) error {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM equipment WHERE category_id = ?", id).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrEquipmentCategoryInUse
+	}
+
+	result, err := r.db.Exec("DELETE FROM equipment_categories WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrEquipmentCategoryNotFound
+	}
+	return nil
+}
+
+func (r *EquipmentRepository) Create(item *models.Equipment) error {
+	result, err := r.db.Exec(
+		"INSERT INTO equipment (category_id, name, display_name, sort_order) VALUES (?, ?, ?, ?)",
+		item.CategoryID, item.Name, item.DisplayName, item.SortOrder,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrDuplicateEquipmentName
+		}
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			return ErrEquipmentCategoryNotFound
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	item.ID = uint16(id)
+	return nil
+}
+
+func (r *EquipmentRepository) Update(item *models.Equipment) error {
+	result, err := r.db.Exec(
+		"UPDATE equipment SET category_id = ?, name = ?, display_name = ?, sort_order = ? WHERE id = ?",
+		item.CategoryID, item.Name, item.DisplayName, item.SortOrder, item.ID,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrDuplicateEquipmentName
+		}
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			return ErrEquipmentCategoryNotFound
+		}
+		return err
+	}
+	return r.checkFound(result, "SELECT EXISTS(SELECT 1 FROM equipment WHERE id = ?)", item.ID, ErrEquipmentNotFound)
+}
+
+// Delete removes an equipment item and unlinks it from all vehicles
+func (r *EquipmentRepository) Delete(id uint16) error {
+	result, err := r.db.Exec("DELETE FROM equipment WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrEquipmentNotFound
+	}
+	return nil
+}
+
+// checkFound returns notFound when an update matched no row. MySQL reports 0 affected rows for unchanged rows,
+// so existence is checked separately.
+func (r *EquipmentRepository) checkFound(result sql.Result, existsQuery string, id uint16, notFound error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.db.QueryRow(existsQuery, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return notFound
+	}
+	return nil
+}
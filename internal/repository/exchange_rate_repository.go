@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"autoelys_backend/internal/currency"
+	"autoelys_backend/internal/models"
+	"database/sql"
+)
+
+type ExchangeRateRepository struct {
+	db *sql.DB
+}
+
+func NewExchangeRateRepository(db *sql.DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+// GetAll retrieves all exchange rates
+func (r *ExchangeRateRepository) GetAll() ([]models.ExchangeRate, error) {
+	query := `SELECT currency, rate, updated_at FROM exchange_rates ORDER BY currency ASC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []models.ExchangeRate
+	for rows.Next() {
+		var rate models.ExchangeRate
+		if err := rows.Scan(&rate.Currency, &rate.Rate, &rate.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, rows.Err()
+}
+
+// GetRates retrieves all exchange rates as a currency lookup map
+func (r *ExchangeRateRepository) GetRates() (currency.Rates, error) {
+	all, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(currency.Rates, len(all))
+	for _, rate := range all {
+		rates[rate.Currency] = rate.Rate
+	}
+	return rates, nil
+}
+
+// SaveRates upserts the given rates and recomputes the normalized price of every vehicle in one transaction
+func (r *ExchangeRateRepository) SaveRates(rates currency.Rates) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for code, rate := range rates {
+		_, err := tx.Exec(`INSERT INTO exchange_rates (currency, rate) VALUES (?, ?)
+			ON DUPLICATE KEY UPDATE rate = VALUES(rate), updated_at = NOW()`, code, rate)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Keep updated_at untouched: a rate change is not an edit of the listing
+	_, err = tx.Exec(`UPDATE vehicles v
+		LEFT JOIN exchange_rates er ON er.currency = v.currency
+		SET v.price_normalized = ROUND(v.price * er.rate, 2), v.updated_at = v.updated_at`)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
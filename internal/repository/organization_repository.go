@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"autoelys_backend/internal/models"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrOrganizationNotFound = errors.New("organization not found")
+var ErrMemberNotFound = errors.New("organization member not found")
+var ErrDuplicateMember = errors.New("user is already a member of the organization")
+var ErrLastOwner = errors.New("organization must keep at least one owner")
+
+type OrganizationRepository struct {
+	db *sql.DB
+}
+
+func NewOrganizationRepository(db *sql.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+const organizationColumns = `o.id, o.uuid, o.slug, o.name, o.description, o.logo, o.email, o.phone, o.website,
+	o.address, o.city, o.county, o.postal_code, o.vat_code, o.created_at, o.updated_at`
+
+func scanOrganization(row interface{ Scan(...interface{}) error }, org *models.Organization, extra ...interface{}) error {
+	dest := []interface{}{
+		&org.ID,
+		&org.UUID,
+		&org.Slug,
+		&org.Name,
+		&org.Description,
+		&org.Logo,
+		&org.Email,
+		&org.Phone,
+		&org.Website,
+		&org.Address,
+		&org.City,
+		&org.County,
+		&org.PostalCode,
+		&org.VATCode,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// Create stores a new organization with ownerID as its owner
+func (r *OrganizationRepository) Create(org *models.Organization, ownerID uint64) error {
+	org.UUID = uuid.New().String()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO organizations (uuid, slug, name, description, logo, email, phone, website, address, city, county, postal_code, vat_code)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := tx.Exec(query,
+		org.UUID,
+		org.Slug,
+		org.Name,
+		org.Description,
+		org.Logo,
+		org.Email,
+		org.Phone,
+		org.Website,
+		org.Address,
+		org.City,
+		org.County,
+		org.PostalCode,
+		org.VATCode,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	org.ID = uint64(id)
+
+	if _, err := tx.Exec(
+		"INSERT INTO organization_members (organization_id, user_id, role) VALUES (?, ?, ?)",
+		org.ID, ownerID, models.OrganizationRoleOwner,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *OrganizationRepository) FindByUUID(uuid string) (*models.Organization, error) {
+	return r.findOne("o.uuid = ?", uuid)
+}
+
+func (r *OrganizationRepository) FindBySlug(slug string) (*models.Organization, error) {
+	return r.findOne("o.slug = ?", slug)
+}
+
+func (r *OrganizationRepository) FindByID(id uint64) (*models.Organization, error) {
+	return r.findOne("o.id = ?", id)
+}
+
+func (r *OrganizationRepository) findOne(condition string, arg interface{}) (*models.Organization, error) {
+	org := &models.Organization{}
+	err := scanOrganization(r.db.QueryRow("SELECT "+organizationColumns+" FROM organizations o WHERE "+condition, arg), org)
+	if err == sql.ErrNoRows {
+		return nil, ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// SlugExists reports whether an organization already uses the given slug
+func (r *OrganizationRepository) SlugExists(slug string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM organizations WHERE slug = ?)", slug).Scan(&exists)
+	return exists, err
+}
+
+// Update saves the organization profile. The slug and logo are not changed.
+func (r *OrganizationRepository) Update(org *models.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = ?, description = ?, email = ?, phone = ?, website = ?, address = ?, city = ?, county = ?, postal_code = ?, vat_code = ?
+		WHERE id = ?
+	`
+	result, err := r.db.Exec(query,
+		org.Name,
+		org.Description,
+		org.Email,
+		org.Phone,
+		org.Website,
+		org.Address,
+		org.City,
+		org.County,
+		org.PostalCode,
+		org.VATCode,
+		org.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		// MySQL reports 0 affected rows when nothing changed, so check the organization exists
+		if _, err := r.FindByID(org.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) UpdateLogo(id uint64, logo *string) error {
+	_, err := r.db.Exec("UPDATE organizations SET logo = ? WHERE id = ?", logo, id)
+	return err
+}
+
+// Delete removes an organization and its memberships; its vehicles stay with the members who listed them
+func (r *OrganizationRepository) Delete(id uint64) error {
+	result, err := r.db.Exec("DELETE FROM organizations WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
+
+// GetByUserID returns the organizations a user belongs to, with the user's role
+func (r *OrganizationRepository) GetByUserID(userID uint64) ([]models.OrganizationMembership, error) {
+	query := `SELECT ` + organizationColumns + `, m.role
+		FROM organizations o
+		INNER JOIN organization_members m ON m.organization_id = o.id
+		WHERE m.user_id = ?
+		ORDER BY o.name ASC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberships := []models.OrganizationMembership{}
+	for rows.Next() {
+		var membership models.OrganizationMembership
+		if err := scanOrganization(rows, &membership.Organization, &membership.Role); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, membership)
+	}
+	return memberships, rows.Err()
+}
+
+// GetMemberRole returns the role of a user in an organization, or an empty string when the user is not a member
+func (r *OrganizationRepository) GetMemberRole(organizationID, userID uint64) (string, error) {
+	var role string
+	err := r.db.QueryRow(
+		"SELECT role FROM organization_members WHERE organization_id = ? AND user_id = ?",
+		organizationID, userID,
+	).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return role, err
+}
+
+// GetMembers returns the members of an organization, owners first
+func (r *OrganizationRepository) GetMembers(organizationID uint64) ([]models.OrganizationMember, error) {
+	query := `SELECT u.id, u.uuid, u.first_name, u.last_name, u.email, m.role, m.created_at
+		FROM organization_members m
+		INNER JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = ?
+		ORDER BY FIELD(m.role, 'owner', 'manager', 'agent'), u.first_name ASC, u.last_name ASC`
+
+	rows, err := r.db.Query(query, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.OrganizationMember{}
+	for rows.Next() {
+		var member models.OrganizationMember
+		if err := rows.Scan(
+			&member.UserID,
+			&member.UserUUID,
+			&member.FirstName,
+			&member.LastName,
+			&member.Email,
+			&member.Role,
+			&member.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+func (r *OrganizationRepository) AddMember(organizationID, userID uint64, role string) error {
+	_, err := r.db.Exec(
+		"INSERT INTO organization_members (organization_id, user_id, role) VALUES (?, ?, ?)",
+		organizationID, userID, role,
+	)
+	if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
+		return ErrDuplicateMember
+	}
+	return err
+}
+
+// UpdateMemberRole changes the role of a member, or returns ErrLastOwner when that would demote the last
+// owner. The organization row is locked while the owners are counted, like the user in
+// ComparisonRepository.Add, so two owners stepping down at the same time cannot both succeed.
+func (r *OrganizationRepository) UpdateMemberRole(organizationID, userID uint64, role string) error {
+	return withTx(r.db, func(tx *Tx) error {
+		currentRole, err := lockMemberRole(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if currentRole == models.OrganizationRoleOwner && role != models.OrganizationRoleOwner {
+			if err := requireOtherOwner(tx, organizationID); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(
+			"UPDATE organization_members SET role = ? WHERE organization_id = ? AND user_id = ?",
+			role, organizationID, userID,
+		)
+		return err
+	})
+}
+
+// RemoveMember removes a member from an organization, or returns ErrLastOwner when they are its last owner.
+// The owners are counted under a lock on the organization like in UpdateMemberRole.
+func (r *OrganizationRepository) RemoveMember(organizationID, userID uint64) error {
+	return withTx(r.db, func(tx *Tx) error {
+		currentRole, err := lockMemberRole(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if currentRole == models.OrganizationRoleOwner {
+			if err := requireOtherOwner(tx, organizationID); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Exec(
+			"DELETE FROM organization_members WHERE organization_id = ? AND user_id = ?",
+			organizationID, userID,
+		)
+		return err
+	})
+}
+
+// lockMemberRole locks the organization row within tx and returns the role of a member, ErrOrganizationNotFound
+// or ErrMemberNotFound
+func lockMemberRole(tx *Tx, organizationID, userID uint64) (string, error) {
+	var lockedID uint64
+	err := tx.QueryRow("SELECT id FROM organizations WHERE id = ? FOR UPDATE", organizationID).Scan(&lockedID)
+	if err == sql.ErrNoRows {
+		return "", ErrOrganizationNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var role string
+	err = tx.QueryRow(
+		"SELECT role FROM organization_members WHERE organization_id = ? AND user_id = ?",
+		organizationID, userID,
+	).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", ErrMemberNotFound
+	}
+	return role, err
+}
+
+// requireOtherOwner returns ErrLastOwner unless the organization has more than one owner
+func requireOtherOwner(tx *Tx, organizationID uint64) error {
+	var count int
+	err := tx.QueryRow(
+		"SELECT COUNT(*) FROM organization_members WHERE organization_id = ? AND role = ?",
+		organizationID, models.OrganizationRoleOwner,
+	).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"autoelys_backend/internal/cache"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/payments"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrPaymentNotFound = errors.New("payment not found")
+var ErrDuplicateIdempotencyKey = errors.New("idempotency key already used")
+var ErrInvalidPaymentTransition = errors.New("invalid payment status transition")
+
+// ErrPaymentOrderNotPending is returned by Transition, after recording the success, for a payment that
+// succeeded when its service order was no longer pending; the payment must be refunded
+var ErrPaymentOrderNotPending = errors.New("payment succeeded for a service order that is no longer pending")
+
+type PaymentRepository struct {
+	db    *sql.DB
+	cache *cache.Store
+}
+
+func NewPaymentRepository(db *sql.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// SetCache lets successful payments, which activate promotions, invalidate the recommended vehicles cached in store
+func (r *PaymentRepository) SetCache(store *cache.Store) {
+	r.cache = store
+}
+
+const paymentSelect = `
+	SELECT p.id, p.uuid, p.user_id, p.service_order_id, o.uuid, p.provider, p.provider_intent_id, p.idempotency_key,
+		p.amount, p.currency, p.status, p.failure_reason, p.created_at, p.updated_at
+	FROM payments p
+	INNER JOIN service_orders o ON o.id = p.service_order_id
+`
+
+func scanPayment(scanner interface{ Scan(...interface{}) error }) (*models.Payment, error) {
+	payment := &models.Payment{}
+	err := scanner.Scan(
+		&payment.ID,
+		&payment.UUID,
+		&payment.UserID,
+		&payment.ServiceOrderID,
+		&payment.ServiceOrderUUID,
+		&payment.Provider,
+		&payment.ProviderIntentID,
+		&payment.IdempotencyKey,
+		&payment.Amount,
+		&payment.Currency,
+		&payment.Status,
+		&payment.FailureReason,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Create stores a pending payment for a service order. The order is locked so that it can only have
+// one payment in progress or succeeded; ErrServiceOrderNotPending and ErrServiceOrderPaymentInProgress
+// report orders that cannot be paid.
+func (r *PaymentRepository) Create(payment *models.Payment) error {
+	payment.UUID = uuid.New().String()
+	payment.Status = payments.StatusPending
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var orderStatus string
+	err = tx.QueryRow("SELECT status FROM service_orders WHERE id = ? FOR UPDATE", payment.ServiceOrderID).Scan(&orderStatus)
+	if err == sql.ErrNoRows {
+		return ErrServiceOrderNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if orderStatus != models.ServiceOrderStatusPending {
+		return ErrServiceOrderNotPending
+	}
+
+	var open int
+	err = tx.QueryRow("SELECT COUNT(*) FROM payments WHERE service_order_id = ? AND status IN ('pending', 'processing', 'succeeded')", payment.ServiceOrderID).Scan(&open)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return ErrServiceOrderPaymentInProgress
+	}
+
+	query := `
+		INSERT INTO payments (uuid, user_id, service_order_id, provider, idempotency_key, amount, currency, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := tx.Exec(query,
+		payment.UUID,
+		payment.UserID,
+		payment.ServiceOrderID,
+		payment.Provider,
+		payment.IdempotencyKey,
+		payment.Amount,
+		payment.Currency,
+		payment.Status,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return ErrDuplicateIdempotencyKey
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	payment.ID = uint64(id)
+
+	if _, err := tx.Exec("INSERT INTO payment_events (payment_id, to_status, source) VALUES (?, ?, ?)", payment.ID, payment.Status, models.PaymentSourceUser); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetProviderIntent stores the ID of the provider intent created for a payment
+func (r *PaymentRepository) SetProviderIntent(id uint64, intentID string) error {
+	_, err := r.db.Exec("UPDATE payments SET provider_intent_id = ? WHERE id = ?", intentID, id)
+	return err
+}
+
+func (r *PaymentRepository) FindByUUID(uuid string) (*models.Payment, error) {
+	return r.findOne(" WHERE p.uuid = ?", uuid)
+}
+
+// FindByIdempotencyKey finds the payment a user created with the given Idempotency-Key
+func (r *PaymentRepository) FindByIdempotencyKey(userID uint64, key string) (*models.Payment, error) {
+	return r.findOne(" WHERE p.user_id = ? AND p.idempotency_key = ?", userID, key)
+}
+
+// FindByProviderIntent finds the payment of a provider intent
+func (r *PaymentRepository) FindByProviderIntent(provider, intentID string) (*models.Payment, error) {
+	return r.findOne(" WHERE p.provider = ? AND p.provider_intent_id = ?", provider, intentID)
+}
+
+func (r *PaymentRepository) findOne(whereClause string, args ...interface{}) (*models.Payment, error) {
+	payment, err := scanPayment(r.db.QueryRow(paymentSelect+whereClause, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrPaymentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// GetEvents retrieves the status changes of a payment, oldest first
+func (r *PaymentRepository) GetEvents(paymentID uint64) ([]models.PaymentEvent, error) {
+	rows, err := r.db.Query(`
+		SELECT id, from_status, to_status, source, provider_event_id, created_at
+		FROM payment_events
+		WHERE payment_id = ?
+		ORDER BY id
+	`, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.PaymentEvent{}
+	for rows.Next() {
+		var event models.PaymentEvent
+		if err := rows.Scan(&event.ID, &event.FromStatus, &event.ToStatus, &event.Source, &event.ProviderEventID, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// PaymentTransition is a status change to apply to a payment
+type PaymentTransition struct {
+	Status          string
+	Source          string
+	ProviderEventID string // set for webhook events, which are applied at most once
+	FailureReason   string
+}
+
+// Transition moves a payment to a new status and records the change. Repeated transitions (the same
+// provider event, or a status the payment already has) are ignored and report false. A payment that
+// succeeds activates its service order in the same transaction; when the order is no longer pending, the
+// success is still recorded and ErrPaymentOrderNotPending is returned so the caller refunds the payment.
+func (r *PaymentRepository) Transition(id uint64, transition PaymentTransition) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var status string
+	var orderID uint64
+	err = tx.QueryRow("SELECT status, service_order_id FROM payments WHERE id = ? FOR UPDATE", id).Scan(&status, &orderID)
+	if err == sql.ErrNoRows {
+		return false, ErrPaymentNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if transition.ProviderEventID != "" {
+		var seen int
+		err := tx.QueryRow("SELECT COUNT(*) FROM payment_events WHERE payment_id = ? AND provider_event_id = ?", id, transition.ProviderEventID).Scan(&seen)
+		if err != nil {
+			return false, err
+		}
+		if seen > 0 {
+			return false, nil
+		}
+	}
+	if status == transition.Status {
+		return false, nil
+	}
+	if !payments.CanTransition(status, transition.Status) {
+		return false, ErrInvalidPaymentTransition
+	}
+
+	var failureReason *string
+	if transition.FailureReason != "" {
+		failureReason = &transition.FailureReason
+	}
+	if _, err := tx.Exec("UPDATE payments SET status = ?, failure_reason = ? WHERE id = ?", transition.Status, failureReason, id); err != nil {
+		return false, err
+	}
+
+	var providerEventID *string
+	if transition.ProviderEventID != "" {
+		providerEventID = &transition.ProviderEventID
+	}
+	_, err = tx.Exec("INSERT INTO payment_events (payment_id, from_status, to_status, source, provider_event_id) VALUES (?, ?, ?, ?, ?)",
+		id, status, transition.Status, transition.Source, providerEventID)
+	if err != nil {
+		return false, err
+	}
+
+	orderNotPending := false
+	if transition.Status == payments.StatusSucceeded {
+		err := activateServiceOrder(tx, orderID)
+		if errors.Is(err, ErrServiceOrderNotPending) {
+			orderNotPending = true
+		} else if err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	if orderNotPending {
+		return true, ErrPaymentOrderNotPending
+	}
+	if transition.Status == payments.StatusSucceeded {
+		r.cache.InvalidatePrefix(recommendedCachePrefix)
+	}
+	return true, nil
+}
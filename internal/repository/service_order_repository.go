@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"autoelys_backend/internal/cache"
+	"autoelys_backend/internal/models"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrServiceOrderNotFound = errors.New("service order not found")
+var ErrServiceOrderNotPending = errors.New("service order is not pending")
+var ErrServiceOrderPaymentInProgress = errors.New("service order has a payment in progress")
+
+// promotionColumns maps each promotion to the vehicle column holding its window
+var promotionColumns = map[string]string{
+	models.PromotionTop:       "promoted_until",
+	models.PromotionHighlight: "highlighted_until",
+}
+
+type ServiceOrderRepository struct {
+	db    *sql.DB
+	cache *cache.Store
+}
+
+func NewServiceOrderRepository(db *sql.DB) *ServiceOrderRepository {
+	return &ServiceOrderRepository{db: db}
+}
+
+// SetCache lets activations invalidate the recommended vehicles cached in store, which rank promoted listings first
+func (r *ServiceOrderRepository) SetCache(store *cache.Store) {
+	r.cache = store
+}
+
+// serviceOrderSelect reports active orders past their end as expired
+const serviceOrderSelect = `
+	SELECT o.id, o.uuid, o.user_id, o.vehicle_id, v.uuid, v.title, o.service_id, s.uuid, s.title,
+		o.promotion, o.price, o.currency, o.duration_minutes,
+		CASE WHEN o.status = 'active' AND o.ends_at <= NOW() THEN 'expired' ELSE o.status END,
+		o.starts_at, o.ends_at, o.created_at, o.updated_at
+	FROM service_orders o
+	INNER JOIN vehicles v ON v.id = o.vehicle_id
+	INNER JOIN services s ON s.id = o.service_id
+`
+
+func scanServiceOrder(scanner interface{ Scan(...interface{}) error }) (*models.ServiceOrder, error) {
+	order := &models.ServiceOrder{}
+	err := scanner.Scan(
+		&order.ID,
+		&order.UUID,
+		&order.UserID,
+		&order.VehicleID,
+		&order.VehicleUUID,
+		&order.VehicleTitle,
+		&order.ServiceID,
+		&order.ServiceUUID,
+		&order.ServiceTitle,
+		&order.Promotion,
+		&order.Price,
+		&order.Currency,
+		&order.DurationMinutes,
+		&order.Status,
+		&order.StartsAt,
+		&order.EndsAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Create stores a pending order, copying the price, currency, duration and promotion of the service
+func (r *ServiceOrderRepository) Create(order *models.ServiceOrder) error {
+	order.UUID = uuid.New().String()
+	order.Status = models.ServiceOrderStatusPending
+
+	query := `
+		INSERT INTO service_orders (uuid, user_id, vehicle_id, service_id, promotion, price, currency, duration_minutes, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.Exec(query,
+		order.UUID,
+		order.UserID,
+		order.VehicleID,
+		order.ServiceID,
+		order.Promotion,
+		order.Price,
+		order.Currency,
+		order.DurationMinutes,
+		order.Status,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	order.ID = uint64(id)
+	return nil
+}
+
+func (r *ServiceOrderRepository) FindByUUID(uuid string) (*models.ServiceOrder, error) {
+	order, err := scanServiceOrder(r.db.QueryRow(serviceOrderSelect+" WHERE o.uuid = ?", uuid))
+	if err == sql.ErrNoRows {
+		return nil, ErrServiceOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetByUserID retrieves the orders placed by a user, newest first
+func (r *ServiceOrderRepository) GetByUserID(userID uint64, limit, offset int) ([]models.ServiceOrder, int, error) {
+	return r.list(" WHERE o.user_id = ?", []interface{}{userID}, limit, offset)
+}
+
+// GetAll retrieves all orders, newest first, optionally filtered by (derived) status
+func (r *ServiceOrderRepository) GetAll(status string, limit, offset int) ([]models.ServiceOrder, int, error) {
+	switch status {
+	case "":
+		return r.list("", nil, limit, offset)
+	case models.ServiceOrderStatusActive:
+		return r.list(" WHERE o.status = 'active' AND o.ends_at > NOW()", nil, limit, offset)
+	case models.ServiceOrderStatusExpired:
+		return r.list(" WHERE o.status = 'active' AND o.ends_at <= NOW()", nil, limit, offset)
+	default:
+		return r.list(" WHERE o.status = ?", []interface{}{status}, limit, offset)
+	}
+}
+
+func (r *ServiceOrderRepository) list(whereClause string, args []interface{}, limit, offset int) ([]models.ServiceOrder, int, error) {
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM service_orders o"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(serviceOrderSelect+whereClause+" ORDER BY o.created_at DESC, o.id DESC LIMIT ? OFFSET ?", append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	orders := []models.ServiceOrder{}
+	for rows.Next() {
+		order, err := scanServiceOrder(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, *order)
+	}
+	return orders, total, rows.Err()
+}
+
+// Activate starts a pending order and extends the promotion window of its vehicle
+func (r *ServiceOrderRepository) Activate(id uint64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := activateServiceOrder(tx, id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	r.cache.InvalidatePrefix(recommendedCachePrefix)
+	return nil
+}
+
+// activateServiceOrder activates a pending order within tx. The order runs from the end of the
+// vehicle's current promotion window (or now, when none is running) for its duration.
+func activateServiceOrder(tx *sql.Tx, id uint64) error {
+	var promotion string
+	err := tx.QueryRow("SELECT promotion FROM service_orders WHERE id = ? AND status = 'pending' FOR UPDATE", id).Scan(&promotion)
+	if err == sql.ErrNoRows {
+		return ErrServiceOrderNotPending
+	}
+	if err != nil {
+		return err
+	}
+	column, ok := promotionColumns[promotion]
+	if !ok {
+		return errors.New("unknown promotion: " + promotion)
+	}
+
+	start := "GREATEST(COALESCE(v." + column + ", NOW()), NOW())"
+	_, err = tx.Exec(`
+		UPDATE service_orders o
+		INNER JOIN vehicles v ON v.id = o.vehicle_id
+		SET o.status = 'active', o.starts_at = `+start+`, o.ends_at = `+start+` + INTERVAL o.duration_minutes MINUTE
+		WHERE o.id = ?
+	`, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE vehicles v
+		INNER JOIN service_orders o ON o.vehicle_id = v.id
+		SET v.`+column+` = o.ends_at, v.version = v.version + 1, v.updated_at = v.updated_at
+		WHERE o.id = ?
+	`, id)
+	return err
+}
+
+// Cancel cancels a pending order without payments pending, in progress or succeeded. The order is locked
+// like in PaymentRepository.Create, so no payment can be created for it while it is being cancelled.
+func (r *ServiceOrderRepository) Cancel(id uint64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow("SELECT status FROM service_orders WHERE id = ? FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return ErrServiceOrderNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.ServiceOrderStatusPending {
+		return ErrServiceOrderNotPending
+	}
+
+	var payments int
+	err = tx.QueryRow("SELECT COUNT(*) FROM payments WHERE service_order_id = ? AND status IN ('pending', 'processing', 'succeeded')", id).Scan(&payments)
+	if err != nil {
+		return err
+	}
+	if payments > 0 {
+		return ErrServiceOrderPaymentInProgress
+	}
+
+	if _, err := tx.Exec("UPDATE service_orders SET status = 'cancelled' WHERE id = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -9,6 +9,7 @@ import (
 )
 
 var ErrServiceNotFound = errors.New("service not found")
+var ErrServiceInUse = errors.New("service has been ordered or booked")
 
 type ServiceRepository struct {
 	db *sql.DB
@@ -22,8 +23,8 @@ func (r *ServiceRepository) Create(service *models.Service) error {
 	service.UUID = uuid.New().String()
 
 	query := `
-		INSERT INTO services (uuid, title, description, price, currency, duration_minutes, active)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO services (uuid, title, description, price, currency, duration_minutes, promotion, active)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.Exec(
@@ -34,6 +35,7 @@ func (r *ServiceRepository) Create(service *models.Service) error {
 		service.Price,
 		service.Currency,
 		service.DurationMinutes,
+		service.Promotion,
 		service.Active,
 	)
 
@@ -47,12 +49,13 @@ func (r *ServiceRepository) Create(service *models.Service) error {
 	}
 
 	service.ID = uint64(id)
+	service.Version = 1
 	return nil
 }
 
 func (r *ServiceRepository) FindByUUID(uuid string) (*models.Service, error) {
 	query := `
-		SELECT id, uuid, title, description, price, currency, duration_minutes, active, created_at, updated_at
+		SELECT id, uuid, title, description, price, currency, duration_minutes, promotion, active, version, created_at, updated_at
 		FROM services
 		WHERE uuid = ?
 	`
@@ -66,7 +69,9 @@ func (r *ServiceRepository) FindByUUID(uuid string) (*models.Service, error) {
 		&service.Price,
 		&service.Currency,
 		&service.DurationMinutes,
+		&service.Promotion,
 		&service.Active,
+		&service.Version,
 		&service.CreatedAt,
 		&service.UpdatedAt,
 	)
@@ -81,13 +86,15 @@ func (r *ServiceRepository) FindByUUID(uuid string) (*models.Service, error) {
 	return service, nil
 }
 
+// Update updates a service if it is still at service.Version, which it then increments.
+// It returns a VersionConflictError when the service was updated since it was read.
 func (r *ServiceRepository) Update(service *models.Service) error {
 	query := `
 		UPDATE services
-		SET title = ?, description = ?, price = ?, currency = ?, duration_minutes = ?, active = ?, updated_at = NOW()
-		WHERE id = ?
+		SET title = ?, description = ?, price = ?, currency = ?, duration_minutes = ?, promotion = ?, active = ?, version = version + 1, updated_at = NOW()
+		WHERE id = ? AND version = ?
 	`
-	result, err := r.db.Exec(query, service.Title, service.Description, service.Price, service.Currency, service.DurationMinutes, service.Active, service.ID)
+	result, err := r.db.Exec(query, service.Title, service.Description, service.Price, service.Currency, service.DurationMinutes, service.Promotion, service.Active, service.ID, service.Version)
 	if err != nil {
 		return err
 	}
@@ -98,13 +105,24 @@ func (r *ServiceRepository) Update(service *models.Service) error {
 	}
 
 	if rowsAffected == 0 {
-		return ErrServiceNotFound
+		return staleVersion(r.db, "services", "id", service.ID, ErrServiceNotFound)
 	}
 
+	service.Version++
 	return nil
 }
 
+// Delete removes a service that has never been ordered or booked; such services can only be deactivated
 func (r *ServiceRepository) Delete(id uint64) error {
+	var count int
+	usage := `SELECT (SELECT COUNT(*) FROM service_orders WHERE service_id = ?) + (SELECT COUNT(*) FROM bookings WHERE service_id = ?)`
+	if err := r.db.QueryRow(usage, id, id).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrServiceInUse
+	}
+
 	query := `DELETE FROM services WHERE id = ?`
 	result, err := r.db.Exec(query, id)
 	if err != nil {
@@ -129,7 +147,7 @@ func (r *ServiceRepository) GetAll(limit, offset int, activeOnly bool) ([]models
 
 	countQuery := `SELECT COUNT(*) FROM services`
 	dataQuery := `
-		SELECT id, uuid, title, description, price, currency, duration_minutes, active, created_at, updated_at
+		SELECT id, uuid, title, description, price, currency, duration_minutes, promotion, active, version, created_at, updated_at
 		FROM services
 	`
 
@@ -163,7 +181,9 @@ func (r *ServiceRepository) GetAll(limit, offset int, activeOnly bool) ([]models
 			&service.Price,
 			&service.Currency,
 			&service.DurationMinutes,
+			&service.Promotion,
 			&service.Active,
+			&service.Version,
 			&service.CreatedAt,
 			&service.UpdatedAt,
 		); err != nil {
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"autoelys_backend/internal/models"
+)
+
+// SitemapRepository reads the public pages listed in the XML sitemaps
+type SitemapRepository struct {
+	db *sql.DB
+}
+
+func NewSitemapRepository(db *sql.DB) *SitemapRepository {
+	return &SitemapRepository{db: db}
+}
+
+// SitemapPage is a public page with the time its content last changed (zero when unknown)
+type SitemapPage struct {
+	Brand     string // brand name, for brand and model pages
+	Model     string // catalog automobile name, for model pages
+	Slug      string // vehicle slug, for listing pages
+	UpdatedAt time.Time
+}
+
+// CountVehicles returns the number of active vehicles and their latest update time
+func (r *SitemapRepository) CountVehicles() (int, time.Time, error) {
+	var count int
+	var updatedAt sql.NullTime
+	err := r.db.QueryRow(`SELECT COUNT(*), MAX(updated_at) FROM vehicles WHERE status = ?`, models.VehicleStatusActive).
+		Scan(&count, &updatedAt)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return count, updatedAt.Time, nil
+}
+
+// GetVehicles returns a page of active vehicles in id order
+func (r *SitemapRepository) GetVehicles(offset, limit int) ([]SitemapPage, error) {
+	rows, err := r.db.Query(`SELECT slug, updated_at FROM vehicles WHERE status = ? ORDER BY id LIMIT ? OFFSET ?`,
+		models.VehicleStatusActive, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pages []SitemapPage
+	for rows.Next() {
+		var page SitemapPage
+		if err := rows.Scan(&page.Slug, &page.UpdatedAt); err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, rows.Err()
+}
+
+// GetBrands returns the catalog brands, last changed when one of their active listings was
+func (r *SitemapRepository) GetBrands() ([]SitemapPage, error) {
+	return r.queryCatalog(`SELECT b.name, '', MAX(v.updated_at)
+	          FROM brands b
+	          LEFT JOIN vehicles v ON v.brand_id = b.id AND v.status = ?
+	          WHERE b.deleted_at IS NULL
+	          GROUP BY b.id, b.name
+	          ORDER BY b.name, b.id`)
+}
+
+// GetModels returns the catalog automobiles of the brands, last changed when one of their active listings was
+func (r *SitemapRepository) GetModels() ([]SitemapPage, error) {
+	return r.queryCatalog(`SELECT b.name, a.name, MAX(v.updated_at)
+	          FROM automobiles a
+	          INNER JOIN brands b ON a.brand_id = b.id
+	          LEFT JOIN vehicles v ON v.automobile_id = a.id AND v.status = ?
+	          WHERE b.deleted_at IS NULL
+	          GROUP BY a.id, b.name, a.name
+	          ORDER BY b.name, a.name, a.id`)
+}
+
+func (r *SitemapRepository) queryCatalog(query string) ([]SitemapPage, error) {
+	rows, err := r.db.Query(query, models.VehicleStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pages []SitemapPage
+	for rows.Next() {
+		var page SitemapPage
+		var updatedAt sql.NullTime
+		if err := rows.Scan(&page.Brand, &page.Model, &updatedAt); err != nil {
+			return nil, err
+		}
+		page.UpdatedAt = updatedAt.Time
+		pages = append(pages, page)
+	}
+	return pages, rows.Err()
+}
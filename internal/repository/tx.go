@@ -0,0 +1,89 @@
+package repository
+
+import "database/sql"
+
+// querier runs queries on the database directly or inside a transaction; *sql.DB, *sql.Tx and *Tx implement it
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Tx is a transaction that the ...Tx methods of several repositories can write through, so a multi-step
+// write is committed or rolled back as a whole. Work outside the database, such as invalidating caches
+// or deleting files, is registered with OnCommit and OnRollback and runs once the outcome is known.
+type Tx struct {
+	tx         *sql.Tx
+	onCommit   []func()
+	onRollback []func()
+}
+
+// OnCommit registers fn to run after the transaction commits
+func (t *Tx) OnCommit(fn func()) {
+	t.onCommit = append(t.onCommit, fn)
+}
+
+// OnRollback registers fn to run after the transaction is rolled back, including when its commit fails
+func (t *Tx) OnRollback(fn func()) {
+	t.onRollback = append(t.onRollback, fn)
+}
+
+func (t *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.Exec(query, args...)
+}
+
+func (t *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.tx.Query(query, args...)
+}
+
+func (t *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return t.tx.QueryRow(query, args...)
+}
+
+// Transactor starts the transactions shared by repositories
+type Transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithTx runs fn in a transaction, which is committed when fn returns nil and rolled back when it
+// returns an error or panics. The hooks registered on the transaction run after it ends.
+func (t *Transactor) WithTx(fn func(tx *Tx) error) error {
+	return withTx(t.db, fn)
+}
+
+func withTx(db *sql.DB, fn func(tx *Tx) error) error {
+	sqlTx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	tx := &Tx{tx: sqlTx}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		_ = sqlTx.Rollback()
+		runHooks(tx.onRollback)
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return err
+	}
+	committed = true
+	runHooks(tx.onCommit)
+	return nil
+}
+
+func runHooks(hooks []func()) {
+	for _, hook := range hooks {
+		hook()
+	}
+}
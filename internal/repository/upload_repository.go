@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"autoelys_backend/internal/models"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUploadNotFound = errors.New("upload not found")
+var ErrUploadOffsetChanged = errors.New("upload offset has changed")
+var ErrUploadNotClaimable = errors.New("uploads are not completed, have expired or belong to another user")
+
+type UploadRepository struct {
+	db *sql.DB
+}
+
+func NewUploadRepository(db *sql.DB) *UploadRepository {
+	return &UploadRepository{db: db}
+}
+
+const uploadSelect = `
+	SELECT id, uuid, user_id, filename, size, received, status, image_url, expires_at, created_at, updated_at
+	FROM uploads
+`
+
+func scanUpload(scanner interface{ Scan(...interface{}) error }) (*models.Upload, error) {
+	upload := &models.Upload{}
+	err := scanner.Scan(
+		&upload.ID,
+		&upload.UUID,
+		&upload.UserID,
+		&upload.Filename,
+		&upload.Size,
+		&upload.Received,
+		&upload.Status,
+		&upload.ImageURL,
+		&upload.ExpiresAt,
+		&upload.CreatedAt,
+		&upload.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// Create opens an upload session expiring after ttl and fills in the stored fields of upload
+func (r *UploadRepository) Create(upload *models.Upload, ttl time.Duration) error {
+	upload.UUID = uuid.New().String()
+
+	_, err := r.db.Exec(`
+		INSERT INTO uploads (uuid, user_id, filename, size, status, expires_at)
+		VALUES (?, ?, ?, ?, 'pending', NOW() + INTERVAL ? SECOND)
+	`, upload.UUID, upload.UserID, upload.Filename, upload.Size, int64(ttl.Seconds()))
+	if err != nil {
+		return err
+	}
+
+	created, err := r.FindByUUID(upload.UUID)
+	if err != nil {
+		return err
+	}
+	*upload = *created
+	return nil
+}
+
+// FindByUUID returns an upload that has not expired
+func (r *UploadRepository) FindByUUID(uuid string) (*models.Upload, error) {
+	upload, err := scanUpload(r.db.QueryRow(uploadSelect+" WHERE uuid = ? AND expires_at > NOW()", uuid))
+	if err == sql.ErrNoRows {
+		return nil, ErrUploadNotFound
+	}
+	return upload, err
+}
+
+// CountOpen counts the unexpired uploads of a user that no vehicle has claimed yet
+func (r *UploadRepository) CountOpen(userID uint64) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM uploads WHERE user_id = ? AND expires_at > NOW()", userID).Scan(&count)
+	return count, err
+}
+
+// SetReceived records the bytes received for a pending upload, provided it still had from bytes, and runs
+// store while the row stays locked, so that only the request that moved the offset stores its chunk. The
+// offset is left unchanged if store fails.
+func (r *UploadRepository) SetReceived(id uint64, from, to int64, store func() error) error {
+	return withTx(r.db, func(tx *Tx) error {
+		result, err := tx.Exec("UPDATE uploads SET received = ? WHERE id = ? AND received = ? AND status = 'pending'", to, id, from)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrUploadOffsetChanged
+		}
+		return store()
+	})
+}
+
+// Complete marks a fully received upload as completed with the URL of its image
+func (r *UploadRepository) Complete(id uint64, imageURL string) error {
+	_, err := r.db.Exec("UPDATE uploads SET status = 'completed', image_url = ? WHERE id = ?", imageURL, id)
+	return err
+}
+
+// Delete deletes an upload and reports whether it did. An upload claimed by a vehicle in the meantime is
+// already gone, and its image now belongs to the vehicle.
+func (r *UploadRepository) Delete(id uint64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM uploads WHERE id = ?", id)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// GetClaimable returns the uploads among uuids that the user can claim: completed and not expired
+func (r *UploadRepository) GetClaimable(userID uint64, uuids []string) ([]models.Upload, error) {
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.Query(uploadSelect+claimableWhere(len(uuids)), claimableArgs(userID, uuids)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var uploads []models.Upload
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, *upload)
+	}
+	return uploads, rows.Err()
+}
+
+// ClaimTx turns the uploads into images of a vehicle within tx, in the order of uuids, and returns their
+// URLs. It fails with ErrUploadNotClaimable unless all of them can be claimed by the user.
+func (r *UploadRepository) ClaimTx(tx *Tx, userID uint64, uuids []string, vehicleID uint64) ([]string, error) {
+	if len(uuids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query("SELECT id, uuid, image_url FROM uploads"+claimableWhere(len(uuids))+" FOR UPDATE", claimableArgs(userID, uuids)...)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uint64, len(uuids))
+	imageURLs := make(map[string]string, len(uuids))
+	for rows.Next() {
+		var id uint64
+		var uploadUUID, imageURL string
+		if err := rows.Scan(&id, &uploadUUID, &imageURL); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids[uploadUUID] = id
+		imageURLs[uploadUUID] = imageURL
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) != len(uuids) {
+		return nil, ErrUploadNotClaimable
+	}
+
+	var urls []string
+	for _, uploadUUID := range uuids {
+		if err := createVehicleImage(tx, vehicleID, imageURLs[uploadUUID]); err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec("DELETE FROM uploads WHERE id = ?", ids[uploadUUID]); err != nil {
+			return nil, err
+		}
+		urls = append(urls, imageURLs[uploadUUID])
+	}
+	return urls, nil
+}
+
+func claimableWhere(count int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+	return " WHERE user_id = ? AND status = 'completed' AND expires_at > NOW() AND uuid IN (" + placeholders + ")"
+}
+
+func claimableArgs(userID uint64, uuids []string) []interface{} {
+	args := []interface{}{userID}
+	for _, uploadUUID := range uuids {
+		args = append(args, uploadUUID)
+	}
+	return args
+}
+
+// DeleteExpired deletes an upload provided it is still expired, and reports whether it did. An upload
+// claimed by a vehicle since it was listed as expired is no longer there and is left alone.
+func (r *UploadRepository) DeleteExpired(id uint64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM uploads WHERE id = ? AND expires_at <= NOW()", id)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// GetExpired returns the uploads past their expiry, which were never claimed
+func (r *UploadRepository) GetExpired() ([]models.Upload, error) {
+	rows, err := r.db.Query(uploadSelect + " WHERE expires_at <= NOW()")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var uploads []models.Upload
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, *upload)
+	}
+	return uploads, rows.Err()
+}
@@ -56,6 +56,7 @@ func (r *UserRepository) Create(user *models.User) error {
 	}
 
 	user.ID = uint64(id)
+	user.Version = 1
 	return nil
 }
 
@@ -63,7 +64,7 @@ func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
 	email = strings.ToLower(email)
 
 	query := `
-		SELECT id, uuid, role_id, first_name, last_name, email, phone, password_hash, active, accepted_terms_at, created_at, updated_at
+		SELECT id, uuid, role_id, first_name, last_name, email, phone, password_hash, active, version, accepted_terms_at, created_at, updated_at
 		FROM users
 		WHERE email = ?
 	`
@@ -79,6 +80,7 @@ func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
 		&user.Phone,
 		&user.PasswordHash,
 		&user.Active,
+		&user.Version,
 		&user.AcceptedTermsAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -96,7 +98,7 @@ func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
 
 func (r *UserRepository) FindByID(id uint64) (*models.User, error) {
 	query := `
-		SELECT id, uuid, role_id, first_name, last_name, email, phone, password_hash, active, accepted_terms_at, created_at, updated_at
+		SELECT id, uuid, role_id, first_name, last_name, email, phone, password_hash, active, version, accepted_terms_at, created_at, updated_at
 		FROM users
 		WHERE id = ?
 	`
@@ -112,6 +114,7 @@ func (r *UserRepository) FindByID(id uint64) (*models.User, error) {
 		&user.Phone,
 		&user.PasswordHash,
 		&user.Active,
+		&user.Version,
 		&user.AcceptedTermsAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -159,19 +162,33 @@ func (r *UserRepository) UpdatePassword(userID uint64, hashedPassword string) er
 	return err
 }
 
+// UpdateProfile updates the profile of a user if it is still at user.Version, which it then increments.
+// It returns a VersionConflictError when the user was updated since it was read.
 func (r *UserRepository) UpdateProfile(user *models.User) error {
 	query := `
 		UPDATE users
-		SET first_name = ?, last_name = ?, phone = ?, updated_at = NOW()
-		WHERE id = ?
+		SET first_name = ?, last_name = ?, phone = ?, version = version + 1, updated_at = NOW()
+		WHERE id = ? AND version = ?
 	`
-	_, err := r.db.Exec(query, user.FirstName, user.LastName, user.Phone, user.ID)
-	return err
+	result, err := r.db.Exec(query, user.FirstName, user.LastName, user.Phone, user.ID, user.Version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return staleVersion(r.db, "users", "id", user.ID, ErrUserNotFound)
+	}
+	user.Version++
+	return nil
 }
 
 func (r *UserRepository) FindByUUID(uuid string) (*models.User, error) {
 	query := `
-		SELECT id, uuid, role_id, first_name, last_name, email, phone, password_hash, active, accepted_terms_at, created_at, updated_at
+		SELECT id, uuid, role_id, first_name, last_name, email, phone, password_hash, active, version, accepted_terms_at, created_at, updated_at
 		FROM users
 		WHERE uuid = ?
 	`
@@ -187,6 +204,7 @@ func (r *UserRepository) FindByUUID(uuid string) (*models.User, error) {
 		&user.Phone,
 		&user.PasswordHash,
 		&user.Active,
+		&user.Version,
 		&user.AcceptedTermsAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -202,19 +220,30 @@ func (r *UserRepository) FindByUUID(uuid string) (*models.User, error) {
 	return user, nil
 }
 
+// AdminUpdateUser updates a user if it is still at user.Version, which it then increments.
+// It returns a VersionConflictError when the user was updated since it was read.
 func (r *UserRepository) AdminUpdateUser(user *models.User) error {
 	query := `
 		UPDATE users
-		SET role_id = ?, first_name = ?, last_name = ?, email = ?, phone = ?, active = ?, updated_at = NOW()
-		WHERE id = ?
+		SET role_id = ?, first_name = ?, last_name = ?, email = ?, phone = ?, active = ?, version = version + 1, updated_at = NOW()
+		WHERE id = ? AND version = ?
 	`
-	_, err := r.db.Exec(query, user.RoleID, user.FirstName, user.LastName, strings.ToLower(user.Email), user.Phone, user.Active, user.ID)
+	result, err := r.db.Exec(query, user.RoleID, user.FirstName, user.LastName, strings.ToLower(user.Email), user.Phone, user.Active, user.ID, user.Version)
 	if err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") || strings.Contains(err.Error(), "UNIQUE constraint") {
 			return ErrDuplicateEmail
 		}
 		return err
 	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return staleVersion(r.db, "users", "id", user.ID, ErrUserNotFound)
+	}
+	user.Version++
 	return nil
 }
 
@@ -243,7 +272,7 @@ func (r *UserRepository) GetAllUsers(limit, offset int, search string) ([]models
 
 	countQuery := `SELECT COUNT(*) FROM users`
 	dataQuery := `
-		SELECT id, uuid, role_id, first_name, last_name, email, phone, active, accepted_terms_at, created_at, updated_at
+		SELECT id, uuid, role_id, first_name, last_name, email, phone, active, version, accepted_terms_at, created_at, updated_at
 		FROM users
 	`
 
@@ -280,6 +309,7 @@ func (r *UserRepository) GetAllUsers(limit, offset int, search string) ([]models
 			&user.Email,
 			&user.Phone,
 			&user.Active,
+			&user.Version,
 			&user.AcceptedTermsAt,
 			&user.CreatedAt,
 			&user.UpdatedAt,
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"autoelys_backend/internal/models"
+	"database/sql"
+	"encoding/json"
+	"strings"
+)
+
+type VehicleAuditRepository struct {
+	db *sql.DB
+}
+
+func NewVehicleAuditRepository(db *sql.DB) *VehicleAuditRepository {
+	return &VehicleAuditRepository{db: db}
+}
+
+// VehicleAuditSearchParams filters the vehicle audit log; zero values match everything
+type VehicleAuditSearchParams struct {
+	VehicleID uint64
+	ActorID   uint64
+	IPAddress string
+	Action    string
+	Limit     int
+	Offset    int
+}
+
+// CreateTx stores an audit log entry within tx, the transaction of the audited change, so the change is
+// not saved without its entry
+func (r *VehicleAuditRepository) CreateTx(tx *Tx, entry *models.VehicleAuditLog) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO vehicle_audit_logs (vehicle_id, actor_id, actor_role, action, ip_address, changes) VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := tx.Exec(query, entry.VehicleID, entry.ActorID, entry.ActorRole, entry.Action, entry.IPAddress, changes)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	entry.ID = uint64(id)
+	return nil
+}
+
+// GetAll retrieves audit log entries, newest first, with the vehicle UUID and the name and email of the actor
+func (r *VehicleAuditRepository) GetAll(params VehicleAuditSearchParams) ([]models.VehicleAuditLog, int, error) {
+	var conditions []string
+	var args []interface{}
+	if params.VehicleID > 0 {
+		conditions = append(conditions, "l.vehicle_id = ?")
+		args = append(args, params.VehicleID)
+	}
+	if params.ActorID > 0 {
+		conditions = append(conditions, "l.actor_id = ?")
+		args = append(args, params.ActorID)
+	}
+	if params.IPAddress != "" {
+		conditions = append(conditions, "l.ip_address = ?")
+		args = append(args, params.IPAddress)
+	}
+	if params.Action != "" {
+		conditions = append(conditions, "l.action = ?")
+		args = append(args, params.Action)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM vehicle_audit_logs l"+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT l.id, l.vehicle_id, v.uuid, l.actor_id, CONCAT(u.first_name, ' ', u.last_name), u.email,
+			l.actor_role, l.action, l.ip_address, l.changes, l.created_at
+		FROM vehicle_audit_logs l
+		LEFT JOIN vehicles v ON v.id = l.vehicle_id
+		LEFT JOIN users u ON u.id = l.actor_id` + whereClause + `
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.Query(query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.VehicleAuditLog, 0)
+	for rows.Next() {
+		var entry models.VehicleAuditLog
+		var changes []byte
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.VehicleID,
+			&entry.VehicleUUID,
+			&entry.ActorID,
+			&entry.ActorName,
+			&entry.ActorEmail,
+			&entry.ActorRole,
+			&entry.Action,
+			&entry.IPAddress,
+			&changes,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, total, rows.Err()
+}
@@ -1,79 +1,368 @@
 package repository
 
 import (
+	"autoelys_backend/internal/cache"
 	"autoelys_backend/internal/models"
 	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
+var ErrVehicleNotFound = errors.New("vehicle not found")
+
 type VehicleRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	cache *cache.Store
+
+	// views counts the detail page views not yet written by FlushViewCounts
+	viewsMu sync.Mutex
+	views   map[uint64]int
 }
 
 func NewVehicleRepository(db *sql.DB) *VehicleRepository {
-	return &VehicleRepository{db: db}
+	return &VehicleRepository{db: db, views: make(map[uint64]int)}
+}
+
+// Cached reads: the lookup tables only change with migrations, recommended vehicles are also invalidated by
+// the vehicle writes of this repository and by promotion activations
+const (
+	lookupCachePrefix      = "lookups:"
+	lookupCacheTTL         = 24 * time.Hour
+	recommendedCachePrefix = "vehicles:recommended:"
+	recommendedCacheTTL    = 5 * time.Minute
+)
+
+// SetCache caches the lookup getters and GetRecommended in store
+func (r *VehicleRepository) SetCache(store *cache.Store) {
+	r.cache = store
+}
+
+// InvalidateLookups drops the cached lookup tables
+func (r *VehicleRepository) InvalidateLookups() {
+	r.cache.InvalidatePrefix(lookupCachePrefix)
+}
+
+// InvalidateRecommended drops the cached recommended vehicles
+func (r *VehicleRepository) InvalidateRecommended() {
+	r.cache.InvalidatePrefix(recommendedCachePrefix)
+}
+
+// lookupID returns the id of the named row of a lookup table
+func (r *VehicleRepository) lookupID(table, name string) (uint8, error) {
+	return cache.Fetch(r.cache, lookupCachePrefix+table+":"+name, lookupCacheTTL, func() (uint8, error) {
+		var id uint8
+		err := r.db.QueryRow("SELECT id FROM "+table+" WHERE name = ?", name).Scan(&id)
+		return id, err
+	})
 }
 
 // GetIDByName helper functions for lookup tables
 func (r *VehicleRepository) GetPersonTypeID(name string) (uint8, error) {
-	var id uint8
-	err := r.db.QueryRow("SELECT id FROM person_types WHERE name = ?", name).Scan(&id)
-	return id, err
+	return r.lookupID("person_types", name)
 }
 
 func (r *VehicleRepository) GetFuelTypeID(name string) (uint8, error) {
-	var id uint8
-	err := r.db.QueryRow("SELECT id FROM fuel_types WHERE name = ?", name).Scan(&id)
-	return id, err
+	return r.lookupID("fuel_types", name)
 }
 
 func (r *VehicleRepository) GetBodyTypeID(name string) (uint8, error) {
-	var id uint8
-	err := r.db.QueryRow("SELECT id FROM body_types WHERE name = ?", name).Scan(&id)
-	return id, err
+	return r.lookupID("body_types", name)
 }
 
 func (r *VehicleRepository) GetConditionID(name string) (uint8, error) {
-	var id uint8
-	err := r.db.QueryRow("SELECT id FROM conditions WHERE name = ?", name).Scan(&id)
-	return id, err
+	return r.lookupID("conditions", name)
 }
 
 func (r *VehicleRepository) GetTransmissionID(name string) (uint8, error) {
-	var id uint8
-	err := r.db.QueryRow("SELECT id FROM transmissions WHERE name = ?", name).Scan(&id)
-	return id, err
+	return r.lookupID("transmissions", name)
 }
 
 func (r *VehicleRepository) GetSteeringID(name string) (uint8, error) {
-	var id uint8
-	err := r.db.QueryRow("SELECT id FROM steerings WHERE name = ?", name).Scan(&id)
-	return id, err
+	return r.lookupID("steerings", name)
+}
+
+// VehicleCatalogRow holds the fields needed to link a vehicle to the brand and automobile catalog
+type VehicleCatalogRow struct {
+	ID           uint64
+	Brand        string
+	Model        string
+	Year         int
+	BrandID      *uint64
+	AutomobileID *uint64
+}
+
+// GetUnlinkedCatalogRows retrieves vehicles that are missing a brand or automobile link
+func (r *VehicleRepository) GetUnlinkedCatalogRows() ([]VehicleCatalogRow, error) {
+	query := `SELECT id, brand, model, year, brand_id, automobile_id
+	FROM vehicles
+	WHERE brand_id IS NULL OR automobile_id IS NULL
+	ORDER BY id ASC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []VehicleCatalogRow
+	for rows.Next() {
+		var row VehicleCatalogRow
+		if err := rows.Scan(&row.ID, &row.Brand, &row.Model, &row.Year, &row.BrandID, &row.AutomobileID); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// SetCatalogLinks stores the catalog brand/automobile of a vehicle and normalizes its brand name
+func (r *VehicleRepository) SetCatalogLinks(id uint64, brandID, automobileID *uint64, brandName string) error {
+	query := `UPDATE vehicles SET brand_id = ?, automobile_id = ?, brand = ? WHERE id = ?`
+	if _, err := r.db.Exec(query, brandID, automobileID, brandName, id); err != nil {
+		return err
+	}
+	r.InvalidateRecommended()
+	return nil
+}
+
+// VehicleScoreRow holds the fields used to compute the recommendation score of a listing
+type VehicleScoreRow struct {
+	ID              uint64
+	Recommended     bool
+	FeaturedImage   *string
+	Description     *string
+	PriceNormalized *float64
+	BrandID         *uint64
+	AutomobileID    *uint64
+	Brand           string
+	Model           string
+	VIN             *string
+	EngineCapacity  *int
+	PowerHP         *int
+	Kilometers      *int
+	Color           *string
+	NumberOfKeys    *int
+	Phone           *string
+	ViewCount       int
+	ImageCount      int
+	ComparisonCount int
+	CreatedAt       time.Time
+}
+
+// GetScoreRows retrieves the scoring fields of all active vehicles with their image and comparison counts
+func (r *VehicleRepository) GetScoreRows() ([]VehicleScoreRow, error) {
+	query := `SELECT
+		v.id, v.recommended, v.featured_image, v.description, v.price_normalized,
+		v.brand_id, v.automobile_id, v.brand, v.model, v.vin,
+		v.engine_capacity, v.power_hp, v.kilometers, v.color, v.number_of_keys, v.phone,
+		v.view_count,
+		(SELECT COUNT(*) FROM vehicle_images vi WHERE vi.vehicle_id = v.id) as image_count,
+		(SELECT COUNT(*) FROM vehicle_comparisons vc WHERE vc.vehicle_id = v.id) as comparison_count,
+		v.created_at
+	FROM vehicles v
+	WHERE v.status = ?
+	ORDER BY v.id ASC`
+
+	rows, err := r.db.Query(query, models.VehicleStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []VehicleScoreRow
+	for rows.Next() {
+		var row VehicleScoreRow
+		err := rows.Scan(
+			&row.ID,
+			&row.Recommended,
+			&row.FeaturedImage,
+			&row.Description,
+			&row.PriceNormalized,
+			&row.BrandID,
+			&row.AutomobileID,
+			&row.Brand,
+			&row.Model,
+			&row.VIN,
+			&row.EngineCapacity,
+			&row.PowerHP,
+			&row.Kilometers,
+			&row.Color,
+			&row.NumberOfKeys,
+			&row.Phone,
+			&row.ViewCount,
+			&row.ImageCount,
+			&row.ComparisonCount,
+			&row.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// UpdateScores stores the recommendation score of each vehicle in one transaction.
+// updated_at is left untouched: a score refresh is not an edit of the listing.
+func (r *VehicleRepository) UpdateScores(scores map[uint64]float64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE vehicles SET score = ?, score_updated_at = NOW(), updated_at = updated_at WHERE id = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for id, score := range scores {
+		if _, err := stmt.Exec(score, id); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	r.InvalidateRecommended()
+	return nil
+}
+
+// IncrementViewCount records a view of a vehicle detail page. Views are counted in memory and written in
+// batches by FlushViewCounts, so that page views do not each cost a database write.
+func (r *VehicleRepository) IncrementViewCount(id uint64) {
+	r.viewsMu.Lock()
+	r.views[id]++
+	r.viewsMu.Unlock()
+}
+
+// FlushViewCounts adds the views counted since the last flush to the vehicles without touching updated_at
+// and returns the number of vehicles updated. Views that fail to be written are kept for the next flush.
+func (r *VehicleRepository) FlushViewCounts() (int, error) {
+	r.viewsMu.Lock()
+	views := r.views
+	r.views = make(map[uint64]int)
+	r.viewsMu.Unlock()
+
+	updated := 0
+	var firstErr error
+	for id, count := range views {
+		query := `UPDATE vehicles SET view_count = view_count + ?, updated_at = updated_at WHERE id = ?`
+		if _, err := r.db.Exec(query, count, id); err != nil {
+			r.viewsMu.Lock()
+			r.views[id] += count
+			r.viewsMu.Unlock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		updated++
+	}
+	return updated, firstErr
+}
+
+// FindUUIDByExternalID returns the UUID of a user's vehicle with the given external ID, or an empty string
+func (r *VehicleRepository) FindUUIDByExternalID(userID uint64, externalID string) (string, error) {
+	var vehicleUUID string
+	err := r.db.QueryRow("SELECT uuid FROM vehicles WHERE user_id = ? AND external_id = ?", userID, externalID).Scan(&vehicleUUID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return vehicleUUID, err
+}
+
+// SlugExists reports whether a vehicle already uses the given slug
+func (r *VehicleRepository) SlugExists(slug string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM vehicles WHERE slug = ?)", slug).Scan(&exists)
+	return exists, err
 }
 
 // SetFeaturedImage updates the featured image for a vehicle
 func (r *VehicleRepository) SetFeaturedImage(uuid string, imagePath string) error {
-	query := `UPDATE vehicles SET featured_image = ? WHERE uuid = ?`
-	_, err := r.db.Exec(query, imagePath, uuid)
+	if err := setFeaturedImage(r.db, uuid, imagePath); err != nil {
+		return err
+	}
+	r.InvalidateRecommended()
+	return nil
+}
+
+// SetFeaturedImageTx updates the featured image for a vehicle within tx
+func (r *VehicleRepository) SetFeaturedImageTx(tx *Tx, uuid string, imagePath string) error {
+	if err := setFeaturedImage(tx, uuid, imagePath); err != nil {
+		return err
+	}
+	tx.OnCommit(r.InvalidateRecommended)
+	return nil
+}
+
+func setFeaturedImage(q querier, uuid string, imagePath string) error {
+	_, err := q.Exec(`UPDATE vehicles SET featured_image = ?, version = version + 1 WHERE uuid = ?`, imagePath, uuid)
 	return err
 }
 
+// ClearFeaturedImageTx removes the featured image of a vehicle within tx
+func (r *VehicleRepository) ClearFeaturedImageTx(tx *Tx, id uint64) error {
+	if _, err := tx.Exec("UPDATE vehicles SET featured_image = NULL, version = version + 1 WHERE id = ?", id); err != nil {
+		return err
+	}
+	tx.OnCommit(r.InvalidateRecommended)
+	return nil
+}
+
+// nullableLookupID stores a missing lookup value of a draft as NULL
+func nullableLookupID(id uint8) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// normalizedPriceExpr converts a price (first placeholder) in a currency (second placeholder) to the base currency
+const normalizedPriceExpr = `(SELECT ROUND(? * er.rate, 2) FROM exchange_rates er WHERE er.currency = ?)`
+
 // Create inserts a new vehicle and returns the created vehicle with ID
 func (r *VehicleRepository) Create(vehicle *models.Vehicle) (*models.Vehicle, error) {
+	if _, err := r.create(r.db, vehicle); err != nil {
+		return nil, err
+	}
+	r.InvalidateRecommended()
+	return vehicle, nil
+}
+
+// CreateTx inserts a vehicle within tx
+func (r *VehicleRepository) CreateTx(tx *Tx, vehicle *models.Vehicle) (*models.Vehicle, error) {
+	if _, err := r.create(tx, vehicle); err != nil {
+		return nil, err
+	}
+	tx.OnCommit(r.InvalidateRecommended)
+	return vehicle, nil
+}
+
+func (r *VehicleRepository) create(q querier, vehicle *models.Vehicle) (*models.Vehicle, error) {
 	// Default status to active if not set
 	if vehicle.Status == 0 {
 		vehicle.Status = models.VehicleStatusActive
 	}
 
 	query := `INSERT INTO vehicles (
-		user_id, status, recommended, featured_image, uuid, slug, title, category, description, price, currency, negotiable,
-		person_type_id, brand, model, engine_capacity, power_hp,
+		user_id, organization_id, external_id, status, recommended, featured_image, uuid, slug, title, category, description, price, currency, price_normalized, negotiable,
+		person_type_id, brand_id, automobile_id, brand, model, vin, engine_capacity, power_hp,
 		fuel_type_id, body_type_id, kilometers, color, year, number_of_keys,
 		condition_id, transmission_id, steering_id, registered,
 		city, contact_name, email, phone
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ` + normalizedPriceExpr + `, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	result, err := r.db.Exec(query,
+	result, err := q.Exec(query,
 		vehicle.UserID,
+		vehicle.OrganizationID,
+		vehicle.ExternalID,
 		vehicle.Status,
 		vehicle.Recommended,
 		vehicle.FeaturedImage,
@@ -84,21 +373,26 @@ func (r *VehicleRepository) Create(vehicle *models.Vehicle) (*models.Vehicle, er
 		vehicle.Description,
 		vehicle.Price,
 		vehicle.Currency,
+		vehicle.Price,
+		vehicle.Currency,
 		vehicle.Negotiable,
-		vehicle.PersonTypeID,
+		nullableLookupID(vehicle.PersonTypeID),
+		vehicle.BrandID,
+		vehicle.AutomobileID,
 		vehicle.Brand,
 		vehicle.Model,
+		vehicle.VIN,
 		vehicle.EngineCapacity,
 		vehicle.PowerHP,
-		vehicle.FuelTypeID,
-		vehicle.BodyTypeID,
+		nullableLookupID(vehicle.FuelTypeID),
+		nullableLookupID(vehicle.BodyTypeID),
 		vehicle.Kilometers,
 		vehicle.Color,
 		vehicle.Year,
 		vehicle.NumberOfKeys,
-		vehicle.ConditionID,
-		vehicle.TransmissionID,
-		vehicle.SteeringID,
+		nullableLookupID(vehicle.ConditionID),
+		nullableLookupID(vehicle.TransmissionID),
+		nullableLookupID(vehicle.SteeringID),
 		vehicle.Registered,
 		vehicle.City,
 		vehicle.ContactName,
@@ -116,123 +410,45 @@ func (r *VehicleRepository) Create(vehicle *models.Vehicle) (*models.Vehicle, er
 	}
 
 	vehicle.ID = uint64(id)
+	vehicle.Version = 1
 	return vehicle, nil
 }
 
 // CreateImage inserts a vehicle image
 func (r *VehicleRepository) CreateImage(vehicleID uint64, imageURL string) error {
-	query := `INSERT INTO vehicle_images (vehicle_id, image_url) VALUES (?, ?)`
-	_, err := r.db.Exec(query, vehicleID, imageURL)
-	return err
+	return createVehicleImage(r.db, vehicleID, imageURL)
 }
 
-// GetByID retrieves a vehicle by UUID with its images and lookup table data
-func (r *VehicleRepository) GetByID(uuid uint64) (*models.Vehicle, error) {
-	query := `SELECT
-		v.uuid, v.status, v.recommended, v.featured_image, v.slug, v.title, v.category, v.description, v.price, v.currency, v.negotiable,
-		v.person_type_id, pt.name as person_type_name,
-		v.brand, v.model, v.engine_capacity, v.power_hp,
-		v.fuel_type_id, ft.name as fuel_type_name,
-		v.body_type_id, bt.name as body_type_name,
-		v.kilometers, v.color, v.year, v.number_of_keys,
-		v.condition_id, c.name as condition_name,
-		v.transmission_id, t.name as transmission_name,
-		v.steering_id, s.name as steering_name,
-		v.registered,
-		v.city, v.contact_name, v.email, v.phone, v.created_at, v.updated_at
-	FROM vehicles v
-	LEFT JOIN person_types pt ON v.person_type_id = pt.id
-	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
-	LEFT JOIN body_types bt ON v.body_type_id = bt.id
-	LEFT JOIN conditions c ON v.condition_id = c.id
-	LEFT JOIN transmissions t ON v.transmission_id = t.id
-	LEFT JOIN steerings s ON v.steering_id = s.id
-	WHERE v.uuid = ?`
-
-	vehicle := &models.Vehicle{}
-	var personTypeName, fuelTypeName, bodyTypeName, conditionName, transmissionName, steeringName string
-
-	err := r.db.QueryRow(query, uuid).Scan(
-		&vehicle.UUID,
-		&vehicle.Status,
-		&vehicle.Recommended,
-		&vehicle.FeaturedImage,
-		&vehicle.Slug,
-		&vehicle.Title,
-		&vehicle.Category,
-		&vehicle.Description,
-		&vehicle.Price,
-		&vehicle.Currency,
-		&vehicle.Negotiable,
-		&vehicle.PersonTypeID,
-		&personTypeName,
-		&vehicle.Brand,
-		&vehicle.Model,
-		&vehicle.EngineCapacity,
-		&vehicle.PowerHP,
-		&vehicle.FuelTypeID,
-		&fuelTypeName,
-		&vehicle.BodyTypeID,
-		&bodyTypeName,
-		&vehicle.Kilometers,
-		&vehicle.Color,
-		&vehicle.Year,
-		&vehicle.NumberOfKeys,
-		&vehicle.ConditionID,
-		&conditionName,
-		&vehicle.TransmissionID,
-		&transmissionName,
-		&vehicle.SteeringID,
-		&steeringName,
-		&vehicle.Registered,
-		&vehicle.City,
-		&vehicle.ContactName,
-		&vehicle.Email,
-		&vehicle.Phone,
-		&vehicle.CreatedAt,
-		&vehicle.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	// Set the name fields
-	vehicle.PersonType = personTypeName
-	vehicle.FuelType = fuelTypeName
-	vehicle.BodyType = bodyTypeName
-	vehicle.Condition = conditionName
-	vehicle.Transmission = transmissionName
-	vehicle.Steering = steeringName
-	vehicle.StatusName = models.GetStatusName(vehicle.Status)
+// CreateImageTx inserts a vehicle image within tx
+func (r *VehicleRepository) CreateImageTx(tx *Tx, vehicleID uint64, imageURL string) error {
+	return createVehicleImage(tx, vehicleID, imageURL)
+}
 
-	// Get images
-	images, err := r.GetImagesByVehicleID(uuid)
-	if err != nil {
-		return nil, err
-	}
-	vehicle.Images = images
+func createVehicleImage(q querier, vehicleID uint64, imageURL string) error {
+	query := `INSERT INTO vehicle_images (vehicle_id, image_url) VALUES (?, ?)`
+	_, err := q.Exec(query, vehicleID, imageURL)
+	return err
+}
 
-	return vehicle, nil
+// GetByID retrieves a vehicle by ID with its images and lookup table data
+func (r *VehicleRepository) GetByID(id uint64) (*models.Vehicle, error) {
+	return getVehicle(r.db, "v.id = ?", id)
 }
 
 // GetBySlug retrieves a vehicle by slug with its images and lookup table data
 func (r *VehicleRepository) GetBySlug(slug string) (*models.Vehicle, error) {
 	query := `SELECT
-		v.id, v.user_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.description, v.price, v.currency, v.negotiable,
-		v.person_type_id, pt.name as person_type_name,
-		v.brand, v.model, v.engine_capacity, v.power_hp,
-		v.fuel_type_id, ft.name as fuel_type_name,
-		v.body_type_id, bt.name as body_type_name,
+		v.id, v.user_id, v.organization_id, v.external_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.description, v.price, v.currency, v.price_normalized, v.negotiable, IF(v.promoted_until > NOW(), v.promoted_until, NULL), IF(v.highlighted_until > NOW(), v.highlighted_until, NULL),
+		COALESCE(v.person_type_id, 0), COALESCE(pt.name, '') as person_type_name,
+		v.brand_id, v.automobile_id, v.brand, v.model, v.vin, v.engine_capacity, v.power_hp,
+		COALESCE(v.fuel_type_id, 0), COALESCE(ft.name, '') as fuel_type_name,
+		COALESCE(v.body_type_id, 0), COALESCE(bt.name, '') as body_type_name,
 		v.kilometers, v.color, v.year, v.number_of_keys,
-		v.condition_id, c.name as condition_name,
-		v.transmission_id, t.name as transmission_name,
-		v.steering_id, s.name as steering_name,
+		COALESCE(v.condition_id, 0), COALESCE(c.name, '') as condition_name,
+		COALESCE(v.transmission_id, 0), COALESCE(t.name, '') as transmission_name,
+		COALESCE(v.steering_id, 0), COALESCE(s.name, '') as steering_name,
 		v.registered,
-		v.city, v.contact_name, v.email, v.phone, v.created_at, v.updated_at
+		v.city, v.contact_name, v.email, v.phone, v.version, v.created_at, v.updated_at
 	FROM vehicles v
 	LEFT JOIN person_types pt ON v.person_type_id = pt.id
 	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
@@ -240,14 +456,16 @@ func (r *VehicleRepository) GetBySlug(slug string) (*models.Vehicle, error) {
 	LEFT JOIN conditions c ON v.condition_id = c.id
 	LEFT JOIN transmissions t ON v.transmission_id = t.id
 	LEFT JOIN steerings s ON v.steering_id = s.id
-	WHERE v.slug = ?`
+	WHERE v.slug = ? AND v.status <> ?`
 
 	vehicle := &models.Vehicle{}
 	var personTypeName, fuelTypeName, bodyTypeName, conditionName, transmissionName, steeringName string
 
-	err := r.db.QueryRow(query, slug).Scan(
+	err := r.db.QueryRow(query, slug, models.VehicleStatusDraft).Scan(
 		&vehicle.ID,
 		&vehicle.UserID,
+		&vehicle.OrganizationID,
+		&vehicle.ExternalID,
 		&vehicle.Status,
 		&vehicle.Recommended,
 		&vehicle.FeaturedImage,
@@ -258,11 +476,17 @@ func (r *VehicleRepository) GetBySlug(slug string) (*models.Vehicle, error) {
 		&vehicle.Description,
 		&vehicle.Price,
 		&vehicle.Currency,
+		&vehicle.PriceNormalized,
 		&vehicle.Negotiable,
+		&vehicle.PromotedUntil,
+		&vehicle.HighlightedUntil,
 		&vehicle.PersonTypeID,
 		&personTypeName,
+		&vehicle.BrandID,
+		&vehicle.AutomobileID,
 		&vehicle.Brand,
 		&vehicle.Model,
+		&vehicle.VIN,
 		&vehicle.EngineCapacity,
 		&vehicle.PowerHP,
 		&vehicle.FuelTypeID,
@@ -284,6 +508,7 @@ func (r *VehicleRepository) GetBySlug(slug string) (*models.Vehicle, error) {
 		&vehicle.ContactName,
 		&vehicle.Email,
 		&vehicle.Phone,
+		&vehicle.Version,
 		&vehicle.CreatedAt,
 		&vehicle.UpdatedAt,
 	)
@@ -311,23 +536,40 @@ func (r *VehicleRepository) GetBySlug(slug string) (*models.Vehicle, error) {
 	}
 	vehicle.Images = images
 
+	// Get equipment
+	equipment, err := r.GetEquipmentByVehicleID(vehicle.ID)
+	if err != nil {
+		return nil, err
+	}
+	vehicle.Equipment = equipment
+
 	return vehicle, nil
 }
 
 // GetByUUID retrieves a vehicle by UUID with its images and lookup table data
 func (r *VehicleRepository) GetByUUID(uuid string) (*models.Vehicle, error) {
+	return getVehicle(r.db, "v.uuid = ?", uuid)
+}
+
+// GetByUUIDTx retrieves a vehicle like GetByUUID within tx, seeing the changes tx made to it
+func (r *VehicleRepository) GetByUUIDTx(tx *Tx, uuid string) (*models.Vehicle, error) {
+	return getVehicle(tx, "v.uuid = ?", uuid)
+}
+
+// getVehicle retrieves the vehicle matching condition, a WHERE clause with one placeholder for arg
+func getVehicle(q querier, condition string, arg interface{}) (*models.Vehicle, error) {
 	query := `SELECT
-		v.id, v.user_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.description, v.price, v.currency, v.negotiable,
-		v.person_type_id, pt.name as person_type_name,
-		v.brand, v.model, v.engine_capacity, v.power_hp,
-		v.fuel_type_id, ft.name as fuel_type_name,
-		v.body_type_id, bt.name as body_type_name,
+		v.id, v.user_id, v.organization_id, v.external_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.description, v.price, v.currency, v.price_normalized, v.negotiable, IF(v.promoted_until > NOW(), v.promoted_until, NULL), IF(v.highlighted_until > NOW(), v.highlighted_until, NULL),
+		COALESCE(v.person_type_id, 0), COALESCE(pt.name, '') as person_type_name,
+		v.brand_id, v.automobile_id, v.brand, v.model, v.vin, v.engine_capacity, v.power_hp,
+		COALESCE(v.fuel_type_id, 0), COALESCE(ft.name, '') as fuel_type_name,
+		COALESCE(v.body_type_id, 0), COALESCE(bt.name, '') as body_type_name,
 		v.kilometers, v.color, v.year, v.number_of_keys,
-		v.condition_id, c.name as condition_name,
-		v.transmission_id, t.name as transmission_name,
-		v.steering_id, s.name as steering_name,
+		COALESCE(v.condition_id, 0), COALESCE(c.name, '') as condition_name,
+		COALESCE(v.transmission_id, 0), COALESCE(t.name, '') as transmission_name,
+		COALESCE(v.steering_id, 0), COALESCE(s.name, '') as steering_name,
 		v.registered,
-		v.city, v.contact_name, v.email, v.phone, v.created_at, v.updated_at
+		v.city, v.contact_name, v.email, v.phone, v.version, v.created_at, v.updated_at
 	FROM vehicles v
 	LEFT JOIN person_types pt ON v.person_type_id = pt.id
 	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
@@ -335,14 +577,16 @@ func (r *VehicleRepository) GetByUUID(uuid string) (*models.Vehicle, error) {
 	LEFT JOIN conditions c ON v.condition_id = c.id
 	LEFT JOIN transmissions t ON v.transmission_id = t.id
 	LEFT JOIN steerings s ON v.steering_id = s.id
-	WHERE v.uuid = ?`
+	WHERE ` + condition
 
 	vehicle := &models.Vehicle{}
 	var personTypeName, fuelTypeName, bodyTypeName, conditionName, transmissionName, steeringName string
 
-	err := r.db.QueryRow(query, uuid).Scan(
+	err := q.QueryRow(query, arg).Scan(
 		&vehicle.ID,
 		&vehicle.UserID,
+		&vehicle.OrganizationID,
+		&vehicle.ExternalID,
 		&vehicle.Status,
 		&vehicle.Recommended,
 		&vehicle.FeaturedImage,
@@ -353,11 +597,17 @@ func (r *VehicleRepository) GetByUUID(uuid string) (*models.Vehicle, error) {
 		&vehicle.Description,
 		&vehicle.Price,
 		&vehicle.Currency,
+		&vehicle.PriceNormalized,
 		&vehicle.Negotiable,
+		&vehicle.PromotedUntil,
+		&vehicle.HighlightedUntil,
 		&vehicle.PersonTypeID,
 		&personTypeName,
+		&vehicle.BrandID,
+		&vehicle.AutomobileID,
 		&vehicle.Brand,
 		&vehicle.Model,
+		&vehicle.VIN,
 		&vehicle.EngineCapacity,
 		&vehicle.PowerHP,
 		&vehicle.FuelTypeID,
@@ -379,6 +629,7 @@ func (r *VehicleRepository) GetByUUID(uuid string) (*models.Vehicle, error) {
 		&vehicle.ContactName,
 		&vehicle.Email,
 		&vehicle.Phone,
+		&vehicle.Version,
 		&vehicle.CreatedAt,
 		&vehicle.UpdatedAt,
 	)
@@ -400,63 +651,267 @@ func (r *VehicleRepository) GetByUUID(uuid string) (*models.Vehicle, error) {
 	vehicle.StatusName = models.GetStatusName(vehicle.Status)
 
 	// Get images
-	images, err := r.GetImagesByVehicleID(vehicle.ID)
+	images, err := getVehicleImages(q, vehicle.ID)
 	if err != nil {
 		return nil, err
 	}
 	vehicle.Images = images
 
+	// Get equipment
+	equipment, err := getVehicleEquipment(q, vehicle.ID)
+	if err != nil {
+		return nil, err
+	}
+	vehicle.Equipment = equipment
+
 	return vehicle, nil
 }
 
-// Update updates a vehicle by UUID
+// Update updates a vehicle by UUID if it is still at vehicle.Version, which it then increments.
+// It returns a VersionConflictError when the vehicle was updated since it was read.
 func (r *VehicleRepository) Update(uuid string, vehicle *models.Vehicle) error {
+	if err := r.update(r.db, uuid, vehicle); err != nil {
+		return err
+	}
+	vehicle.Version++
+	r.InvalidateRecommended()
+	return nil
+}
+
+// UpdateTx updates a vehicle like Update within tx; vehicle.Version is incremented once tx commits
+func (r *VehicleRepository) UpdateTx(tx *Tx, uuid string, vehicle *models.Vehicle) error {
+	if err := r.update(tx, uuid, vehicle); err != nil {
+		return err
+	}
+	tx.OnCommit(func() {
+		vehicle.Version++
+		r.InvalidateRecommended()
+	})
+	return nil
+}
+
+func (r *VehicleRepository) update(q querier, uuid string, vehicle *models.Vehicle) error {
 	query := `UPDATE vehicles SET
-		slug = ?, title = ?, category = ?, description = ?, price = ?, currency = ?, negotiable = ?,
-		person_type_id = ?, brand = ?, model = ?, engine_capacity = ?, power_hp = ?,
+		organization_id = ?, slug = ?, title = ?, category = ?, description = ?, price = ?, currency = ?, price_normalized = ` + normalizedPriceExpr + `, negotiable = ?,
+		person_type_id = ?, brand_id = ?, automobile_id = ?, brand = ?, model = ?, vin = ?, engine_capacity = ?, power_hp = ?,
 		fuel_type_id = ?, body_type_id = ?, kilometers = ?, color = ?, year = ?, number_of_keys = ?,
 		condition_id = ?, transmission_id = ?, steering_id = ?, registered = ?,
-		city = ?, contact_name = ?, email = ?, phone = ?
-	WHERE uuid = ?`
+		city = ?, contact_name = ?, email = ?, phone = ?, version = version + 1
+	WHERE uuid = ? AND version = ?`
 
-	_, err := r.db.Exec(query,
+	result, err := q.Exec(query,
+		vehicle.OrganizationID,
 		vehicle.Slug,
 		vehicle.Title,
 		vehicle.Category,
 		vehicle.Description,
 		vehicle.Price,
 		vehicle.Currency,
+		vehicle.Price,
+		vehicle.Currency,
 		vehicle.Negotiable,
-		vehicle.PersonTypeID,
+		nullableLookupID(vehicle.PersonTypeID),
+		vehicle.BrandID,
+		vehicle.AutomobileID,
 		vehicle.Brand,
 		vehicle.Model,
+		vehicle.VIN,
 		vehicle.EngineCapacity,
 		vehicle.PowerHP,
-		vehicle.FuelTypeID,
-		vehicle.BodyTypeID,
+		nullableLookupID(vehicle.FuelTypeID),
+		nullableLookupID(vehicle.BodyTypeID),
 		vehicle.Kilometers,
 		vehicle.Color,
 		vehicle.Year,
 		vehicle.NumberOfKeys,
-		vehicle.ConditionID,
-		vehicle.TransmissionID,
-		vehicle.SteeringID,
+		nullableLookupID(vehicle.ConditionID),
+		nullableLookupID(vehicle.TransmissionID),
+		nullableLookupID(vehicle.SteeringID),
 		vehicle.Registered,
 		vehicle.City,
 		vehicle.ContactName,
 		vehicle.Email,
 		vehicle.Phone,
 		uuid,
+		vehicle.Version,
 	)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return staleVersion(q, "vehicles", "uuid", uuid, ErrVehicleNotFound)
+	}
+	return nil
+}
+
+// UpdateStatusTx changes the status of a vehicle from fromStatus to status within tx, or returns a
+// *VersionConflictError when the vehicle is no longer at version, e.g. because an admin banned it since
+// the caller read it
+func (r *VehicleRepository) UpdateStatusTx(tx *Tx, id, version uint64, fromStatus, status uint8) error {
+	result, err := tx.Exec("UPDATE vehicles SET status = ?, version = version + 1 WHERE id = ? AND status = ? AND version = ?", status, id, fromStatus, version)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return staleVersion(tx, "vehicles", "id", id, ErrVehicleNotFound)
+	}
+	tx.OnCommit(r.InvalidateRecommended)
+	return nil
+}
 
+// DeleteImageTx removes an image record of a vehicle within tx and returns its URL, empty when the vehicle
+// has no such image
+func (r *VehicleRepository) DeleteImageTx(tx *Tx, vehicleID, imageID uint64) (string, error) {
+	var imageURL string
+	err := tx.QueryRow("SELECT image_url FROM vehicle_images WHERE id = ? AND vehicle_id = ? FOR UPDATE", imageID, vehicleID).Scan(&imageURL)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := tx.Exec("DELETE FROM vehicle_images WHERE id = ?", imageID); err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec("UPDATE vehicles SET version = version + 1 WHERE id = ?", vehicleID); err != nil {
+		return "", err
+	}
+	return imageURL, nil
+}
+
+// TouchTx sets updated_at of a vehicle to now and bumps its version within tx, for changes stored outside
+// the vehicles table
+func (r *VehicleRepository) TouchTx(tx *Tx, id uint64) error {
+	_, err := tx.Exec("UPDATE vehicles SET updated_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = ?", id)
 	return err
 }
 
+// DeleteDraft deletes a draft listing and reports whether it did, with its image URLs so the files can be
+// deleted. A listing published since the caller read it is left alone and its URLs are not returned.
+func (r *VehicleRepository) DeleteDraft(id uint64) ([]string, bool, error) {
+	return r.deleteDraft("id = ? AND status = ?", id, models.VehicleStatusDraft)
+}
+
+// DeleteStaleDraft deletes a draft like DeleteDraft, provided it was still last edited before the cutoff
+func (r *VehicleRepository) DeleteStaleDraft(id uint64, cutoff time.Time) ([]string, bool, error) {
+	return r.deleteDraft("id = ? AND status = ? AND updated_at < ?", id, models.VehicleStatusDraft, cutoff)
+}
+
+// deleteDraft deletes the draft matching condition, locking it while its image URLs are read
+func (r *VehicleRepository) deleteDraft(condition string, args ...interface{}) ([]string, bool, error) {
+	var urls []string
+	deleted := false
+	err := withTx(r.db, func(tx *Tx) error {
+		var id uint64
+		err := tx.QueryRow("SELECT id FROM vehicles WHERE "+condition+" FOR UPDATE", args...).Scan(&id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query("SELECT image_url FROM vehicle_images WHERE vehicle_id = ?", id)
+		if err != nil {
+			return err
+		}
+		var imageURLs []string
+		for rows.Next() {
+			var url string
+			if err := rows.Scan(&url); err != nil {
+				rows.Close()
+				return err
+			}
+			imageURLs = append(imageURLs, url)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec("DELETE FROM vehicles WHERE "+condition, args...)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 1 {
+			urls, deleted = imageURLs, true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return urls, deleted, nil
+}
+
+// GetStaleDraftIDs retrieves the IDs of drafts last edited before the cutoff
+func (r *VehicleRepository) GetStaleDraftIDs(cutoff time.Time) ([]uint64, error) {
+	rows, err := r.db.Query("SELECT id FROM vehicles WHERE status = ? AND updated_at < ?", models.VehicleStatusDraft, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint64
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// DeleteImagesByVehicleIDTx removes all image records of a vehicle within tx and returns their URLs, so the
+// files can be deleted once tx commits
+func (r *VehicleRepository) DeleteImagesByVehicleIDTx(tx *Tx, vehicleID uint64) ([]string, error) {
+	rows, err := tx.Query("SELECT image_url FROM vehicle_images WHERE vehicle_id = ? FOR UPDATE", vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	var urls []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec("DELETE FROM vehicle_images WHERE vehicle_id = ?", vehicleID); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
 // GetImagesByVehicleID retrieves all images for a vehicle
 func (r *VehicleRepository) GetImagesByVehicleID(vehicleID uint64) ([]models.VehicleImage, error) {
+	return getVehicleImages(r.db, vehicleID)
+}
+
+func getVehicleImages(q querier, vehicleID uint64) ([]models.VehicleImage, error) {
 	query := `SELECT id, vehicle_id, image_url, created_at FROM vehicle_images WHERE vehicle_id = ?`
 
-	rows, err := r.db.Query(query, vehicleID)
+	rows, err := q.Query(query, vehicleID)
 	if err != nil {
 		return nil, err
 	}
@@ -475,176 +930,283 @@ func (r *VehicleRepository) GetImagesByVehicleID(vehicleID uint64) ([]models.Veh
 	return images, rows.Err()
 }
 
-// GetAllPersonTypes retrieves all person types
-func (r *VehicleRepository) GetAllPersonTypes() ([]models.PersonType, error) {
-	query := "SELECT id, name, display_name FROM person_types"
-	rows, err := r.db.Query(query)
+// GetEquipmentByVehicleID retrieves the equipment of a vehicle in catalog order
+func (r *VehicleRepository) GetEquipmentByVehicleID(vehicleID uint64) ([]models.Equipment, error) {
+	return getVehicleEquipment(r.db, vehicleID)
+}
+
+func getVehicleEquipment(q querier, vehicleID uint64) ([]models.Equipment, error) {
+	query := `SELECT e.id, e.category_id, c.name, e.name, e.display_name, e.sort_order
+		FROM vehicle_equipment ve
+		INNER JOIN equipment e ON e.id = ve.equipment_id
+		INNER JOIN equipment_categories c ON c.id = e.category_id
+		WHERE ve.vehicle_id = ?
+		ORDER BY c.sort_order ASC, c.id ASC, e.sort_order ASC, e.id ASC`
+
+	rows, err := q.Query(query, vehicleID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var types []models.PersonType
+	var equipment []models.Equipment
 	for rows.Next() {
-		var t models.PersonType
-		if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+		var item models.Equipment
+		if err := rows.Scan(&item.ID, &item.CategoryID, &item.Category, &item.Name, &item.DisplayName, &item.SortOrder); err != nil {
 			return nil, err
 		}
-		types = append(types, t)
+		equipment = append(equipment, item)
 	}
-	return types, rows.Err()
+
+	return equipment, rows.Err()
 }
 
-// GetAllFuelTypes retrieves all fuel types
-func (r *VehicleRepository) GetAllFuelTypes() ([]models.FuelType, error) {
-	query := "SELECT id, name, display_name FROM fuel_types"
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
+// SetEquipmentTx replaces the equipment of a vehicle within tx
+func (r *VehicleRepository) SetEquipmentTx(tx *Tx, vehicleID uint64, equipment []models.Equipment) error {
+	if _, err := tx.Exec("DELETE FROM vehicle_equipment WHERE vehicle_id = ?", vehicleID); err != nil {
+		return err
 	}
-	defer rows.Close()
+	for _, item := range equipment {
+		if _, err := tx.Exec("INSERT IGNORE INTO vehicle_equipment (vehicle_id, equipment_id) VALUES (?, ?)", vehicleID, item.ID); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec("UPDATE vehicles SET version = version + 1 WHERE id = ?", vehicleID)
+	return err
+}
 
-	var types []models.FuelType
-	for rows.Next() {
-		var t models.FuelType
-		if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+// GetAllPersonTypes retrieves all person types
+func (r *VehicleRepository) GetAllPersonTypes() ([]models.PersonType, error) {
+	return cache.Fetch(r.cache, lookupCachePrefix+"person_types", lookupCacheTTL, func() ([]models.PersonType, error) {
+		query := "SELECT id, name, display_name FROM person_types"
+		rows, err := r.db.Query(query)
+		if err != nil {
 			return nil, err
 		}
-		types = append(types, t)
-	}
-	return types, rows.Err()
+		defer rows.Close()
+
+		var types []models.PersonType
+		for rows.Next() {
+			var t models.PersonType
+			if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		return types, rows.Err()
+	})
+}
+
+// GetAllFuelTypes retrieves all fuel types
+func (r *VehicleRepository) GetAllFuelTypes() ([]models.FuelType, error) {
+	return cache.Fetch(r.cache, lookupCachePrefix+"fuel_types", lookupCacheTTL, func() ([]models.FuelType, error) {
+		query := "SELECT id, name, display_name FROM fuel_types"
+		rows, err := r.db.Query(query)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var types []models.FuelType
+		for rows.Next() {
+			var t models.FuelType
+			if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		return types, rows.Err()
+	})
 }
 
 // GetAllBodyTypes retrieves all body types
 func (r *VehicleRepository) GetAllBodyTypes() ([]models.BodyType, error) {
-	query := "SELECT id, name, display_name FROM body_types"
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var types []models.BodyType
-	for rows.Next() {
-		var t models.BodyType
-		if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+	return cache.Fetch(r.cache, lookupCachePrefix+"body_types", lookupCacheTTL, func() ([]models.BodyType, error) {
+		query := "SELECT id, name, display_name FROM body_types"
+		rows, err := r.db.Query(query)
+		if err != nil {
 			return nil, err
 		}
-		types = append(types, t)
-	}
-	return types, rows.Err()
+		defer rows.Close()
+
+		var types []models.BodyType
+		for rows.Next() {
+			var t models.BodyType
+			if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		return types, rows.Err()
+	})
 }
 
 // GetAllConditions retrieves all conditions
 func (r *VehicleRepository) GetAllConditions() ([]models.Condition, error) {
-	query := "SELECT id, name, display_name FROM conditions"
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var types []models.Condition
-	for rows.Next() {
-		var t models.Condition
-		if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+	return cache.Fetch(r.cache, lookupCachePrefix+"conditions", lookupCacheTTL, func() ([]models.Condition, error) {
+		query := "SELECT id, name, display_name FROM conditions"
+		rows, err := r.db.Query(query)
+		if err != nil {
 			return nil, err
 		}
-		types = append(types, t)
-	}
-	return types, rows.Err()
+		defer rows.Close()
+
+		var types []models.Condition
+		for rows.Next() {
+			var t models.Condition
+			if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		return types, rows.Err()
+	})
 }
 
 // GetAllTransmissions retrieves all transmissions
 func (r *VehicleRepository) GetAllTransmissions() ([]models.Transmission, error) {
-	query := "SELECT id, name, display_name FROM transmissions"
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var types []models.Transmission
-	for rows.Next() {
-		var t models.Transmission
-		if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+	return cache.Fetch(r.cache, lookupCachePrefix+"transmissions", lookupCacheTTL, func() ([]models.Transmission, error) {
+		query := "SELECT id, name, display_name FROM transmissions"
+		rows, err := r.db.Query(query)
+		if err != nil {
 			return nil, err
 		}
-		types = append(types, t)
-	}
-	return types, rows.Err()
+		defer rows.Close()
+
+		var types []models.Transmission
+		for rows.Next() {
+			var t models.Transmission
+			if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		return types, rows.Err()
+	})
 }
 
 // GetAllSteerings retrieves all steerings
 func (r *VehicleRepository) GetAllSteerings() ([]models.Steering, error) {
-	query := "SELECT id, name, display_name FROM steerings"
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var types []models.Steering
-	for rows.Next() {
-		var t models.Steering
-		if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+	return cache.Fetch(r.cache, lookupCachePrefix+"steerings", lookupCacheTTL, func() ([]models.Steering, error) {
+		query := "SELECT id, name, display_name FROM steerings"
+		rows, err := r.db.Query(query)
+		if err != nil {
 			return nil, err
 		}
-		types = append(types, t)
-	}
-	return types, rows.Err()
+		defer rows.Close()
+
+		var types []models.Steering
+		for rows.Next() {
+			var t models.Steering
+			if err := rows.Scan(&t.ID, &t.Name, &t.DisplayName); err != nil {
+				return nil, err
+			}
+			types = append(types, t)
+		}
+		return types, rows.Err()
+	})
 }
 
+// Ordering terms that rank listings with a running top listing or homepage highlight promotion first
+const (
+	promotedFirst    = "(v.promoted_until IS NOT NULL AND v.promoted_until > NOW()) DESC"
+	highlightedFirst = "(v.highlighted_until IS NOT NULL AND v.highlighted_until > NOW()) DESC"
+)
+
+// Vehicle sort orders
+const (
+	VehicleSortNewest    = "newest"
+	VehicleSortPriceAsc  = "price_asc"
+	VehicleSortPriceDesc = "price_desc"
+	VehicleSortUpdated   = "updated" // least recently updated first, for incremental exports
+	VehicleSortCreated   = "created" // newest first without promotion ranking, for syndication feeds
+)
+
 // VehicleSearchParams holds all search and filter parameters
 type VehicleSearchParams struct {
 	Search       string
+	BrandID      uint64
 	Brand        string
 	Model        string
 	FuelType     string
 	BodyType     string
 	Transmission string
 	Condition    string
-	MinPrice     float64
-	MaxPrice     float64
+	MinPrice     float64 // in the base currency
+	MaxPrice     float64 // in the base currency
 	MinYear      int
 	MaxYear      int
 	City         string
+	Sort         string // newest (default), price_asc, price_desc, updated or created
 	Limit        int
 	Offset       int
+
+	// Only the listings of an organization (dealer storefront)
+	OrganizationID uint64
+
+	// Only vehicles having all of these equipment IDs
+	EquipmentIDs []uint16
+
+	// Export options: only vehicles updated after UpdatedSince (zero for all), vehicles of every status
+	// except drafts instead of active ones only, and the description, which listings leave out
+	UpdatedSince    time.Time
+	IncludeInactive bool
+	WithDescription bool
 }
 
 // GetAll retrieves all vehicles with optional search filters
 func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle, int, error) {
+	descriptionColumn := "NULL"
+	if params.WithDescription {
+		descriptionColumn = "v.description"
+	}
+
 	baseQuery := `SELECT
-		v.id, v.user_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.price, v.currency, v.negotiable,
-		v.person_type_id, pt.name as person_type_name,
-		v.brand, v.model, v.engine_capacity, v.power_hp,
-		v.fuel_type_id, ft.name as fuel_type_name,
-		v.body_type_id, bt.name as body_type_name,
+		v.id, v.user_id, v.organization_id, v.external_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, ` + descriptionColumn + `, v.price, v.currency, v.price_normalized, v.negotiable, IF(v.promoted_until > NOW(), v.promoted_until, NULL), IF(v.highlighted_until > NOW(), v.highlighted_until, NULL),
+		COALESCE(v.person_type_id, 0), COALESCE(pt.name, '') as person_type_name,
+		v.brand_id, v.automobile_id, v.brand, v.model, v.vin, v.engine_capacity, v.power_hp,
+		COALESCE(v.fuel_type_id, 0), COALESCE(ft.name, '') as fuel_type_name,
+		COALESCE(v.body_type_id, 0), COALESCE(bt.name, '') as body_type_name,
 		v.kilometers, v.color, v.year, v.number_of_keys,
-		v.condition_id, c.name as condition_name,
-		v.transmission_id, t.name as transmission_name,
-		v.steering_id, s.name as steering_name,
+		COALESCE(v.condition_id, 0), COALESCE(c.name, '') as condition_name,
+		COALESCE(v.transmission_id, 0), COALESCE(t.name, '') as transmission_name,
+		COALESCE(v.steering_id, 0), COALESCE(s.name, '') as steering_name,
 		v.registered,
-		v.city, v.contact_name, v.email, v.phone, v.created_at, v.updated_at
+		v.city, v.contact_name, v.email, v.phone, v.version, v.created_at, v.updated_at
 	FROM vehicles v
 	LEFT JOIN person_types pt ON v.person_type_id = pt.id
 	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
 	LEFT JOIN body_types bt ON v.body_type_id = bt.id
 	LEFT JOIN conditions c ON v.condition_id = c.id
 	LEFT JOIN transmissions t ON v.transmission_id = t.id
-	LEFT JOIN steerings s ON v.steering_id = s.id
-	WHERE v.status = ?`
+	LEFT JOIN steerings s ON v.steering_id = s.id`
 
 	countQuery := `SELECT COUNT(*) FROM vehicles v
 	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
 	LEFT JOIN body_types bt ON v.body_type_id = bt.id
 	LEFT JOIN transmissions t ON v.transmission_id = t.id
-	LEFT JOIN conditions c ON v.condition_id = c.id
-	WHERE v.status = ?`
+	LEFT JOIN conditions c ON v.condition_id = c.id`
+
+	var args, countArgs []interface{}
+	if params.IncludeInactive {
+		baseQuery += " WHERE v.status <> ?"
+		countQuery += " WHERE v.status <> ?"
+		args = append(args, models.VehicleStatusDraft)
+		countArgs = append(countArgs, models.VehicleStatusDraft)
+	} else {
+		baseQuery += " WHERE v.status = ?"
+		countQuery += " WHERE v.status = ?"
+		args = append(args, models.VehicleStatusActive)
+		countArgs = append(countArgs, models.VehicleStatusActive)
+	}
 
-	args := []interface{}{models.VehicleStatusActive}
-	countArgs := []interface{}{models.VehicleStatusActive}
+	// Add updated since filter
+	if !params.UpdatedSince.IsZero() {
+		baseQuery += " AND v.updated_at > ?"
+		countQuery += " AND v.updated_at > ?"
+		args = append(args, params.UpdatedSince)
+		countArgs = append(countArgs, params.UpdatedSince)
+	}
 
 	// Add search filter
 	if params.Search != "" {
@@ -655,8 +1217,13 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 		countArgs = append(countArgs, searchPattern, searchPattern, searchPattern, searchPattern)
 	}
 
-	// Add brand filter
-	if params.Brand != "" {
+	// Add brand filter (catalog brands match by id, unlinked legacy rows by name)
+	if params.BrandID > 0 {
+		baseQuery += " AND (v.brand_id = ? OR (v.brand_id IS NULL AND v.brand = ?))"
+		countQuery += " AND (v.brand_id = ? OR (v.brand_id IS NULL AND v.brand = ?))"
+		args = append(args, params.BrandID, params.Brand)
+		countArgs = append(countArgs, params.BrandID, params.Brand)
+	} else if params.Brand != "" {
 		baseQuery += " AND v.brand = ?"
 		countQuery += " AND v.brand = ?"
 		args = append(args, params.Brand)
@@ -703,16 +1270,16 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 		countArgs = append(countArgs, params.Condition)
 	}
 
-	// Add price range filter
+	// Add price range filter (compared across currencies via the normalized price)
 	if params.MinPrice > 0 {
-		baseQuery += " AND v.price >= ?"
-		countQuery += " AND v.price >= ?"
+		baseQuery += " AND v.price_normalized >= ?"
+		countQuery += " AND v.price_normalized >= ?"
 		args = append(args, params.MinPrice)
 		countArgs = append(countArgs, params.MinPrice)
 	}
 	if params.MaxPrice > 0 {
-		baseQuery += " AND v.price <= ?"
-		countQuery += " AND v.price <= ?"
+		baseQuery += " AND v.price_normalized <= ?"
+		countQuery += " AND v.price_normalized <= ?"
 		args = append(args, params.MaxPrice)
 		countArgs = append(countArgs, params.MaxPrice)
 	}
@@ -739,6 +1306,29 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 		countArgs = append(countArgs, params.City)
 	}
 
+	// Add equipment filter (the vehicle must have every selected item)
+	if len(params.EquipmentIDs) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(params.EquipmentIDs)), ", ")
+		equipmentFilter := " AND v.id IN (SELECT ve.vehicle_id FROM vehicle_equipment ve WHERE ve.equipment_id IN (" + placeholders +
+			") GROUP BY ve.vehicle_id HAVING COUNT(DISTINCT ve.equipment_id) = ?)"
+		baseQuery += equipmentFilter
+		countQuery += equipmentFilter
+		for _, id := range params.EquipmentIDs {
+			args = append(args, id)
+			countArgs = append(countArgs, id)
+		}
+		args = append(args, len(params.EquipmentIDs))
+		countArgs = append(countArgs, len(params.EquipmentIDs))
+	}
+
+	// Add organization filter
+	if params.OrganizationID > 0 {
+		baseQuery += " AND v.organization_id = ?"
+		countQuery += " AND v.organization_id = ?"
+		args = append(args, params.OrganizationID)
+		countArgs = append(countArgs, params.OrganizationID)
+	}
+
 	// Get total count
 	var total int
 	err := r.db.QueryRow(countQuery, countArgs...).Scan(&total)
@@ -746,8 +1336,20 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 		return nil, 0, err
 	}
 
-	// Add ordering and pagination
-	baseQuery += " ORDER BY v.created_at DESC LIMIT ? OFFSET ?"
+	// Add ordering and pagination; promoted listings come first except in exports
+	switch params.Sort {
+	case VehicleSortPriceAsc:
+		baseQuery += " ORDER BY " + promotedFirst + ", v.price_normalized IS NULL, v.price_normalized ASC, v.created_at DESC"
+	case VehicleSortPriceDesc:
+		baseQuery += " ORDER BY " + promotedFirst + ", v.price_normalized IS NULL, v.price_normalized DESC, v.created_at DESC"
+	case VehicleSortUpdated:
+		baseQuery += " ORDER BY v.updated_at ASC, v.id ASC"
+	case VehicleSortCreated:
+		baseQuery += " ORDER BY v.created_at DESC, v.id DESC"
+	default:
+		baseQuery += " ORDER BY " + promotedFirst + ", v.created_at DESC"
+	}
+	baseQuery += " LIMIT ? OFFSET ?"
 	args = append(args, params.Limit, params.Offset)
 
 	rows, err := r.db.Query(baseQuery, args...)
@@ -764,6 +1366,8 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 		err := rows.Scan(
 			&vehicle.ID,
 			&vehicle.UserID,
+			&vehicle.OrganizationID,
+			&vehicle.ExternalID,
 			&vehicle.Status,
 			&vehicle.Recommended,
 			&vehicle.FeaturedImage,
@@ -771,13 +1375,20 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 			&vehicle.Slug,
 			&vehicle.Title,
 			&vehicle.Category,
+			&vehicle.Description,
 			&vehicle.Price,
 			&vehicle.Currency,
+			&vehicle.PriceNormalized,
 			&vehicle.Negotiable,
+			&vehicle.PromotedUntil,
+			&vehicle.HighlightedUntil,
 			&vehicle.PersonTypeID,
 			&personTypeName,
+			&vehicle.BrandID,
+			&vehicle.AutomobileID,
 			&vehicle.Brand,
 			&vehicle.Model,
+			&vehicle.VIN,
 			&vehicle.EngineCapacity,
 			&vehicle.PowerHP,
 			&vehicle.FuelTypeID,
@@ -799,6 +1410,7 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 			&vehicle.ContactName,
 			&vehicle.Email,
 			&vehicle.Phone,
+			&vehicle.Version,
 			&vehicle.CreatedAt,
 			&vehicle.UpdatedAt,
 		)
@@ -829,24 +1441,34 @@ func (r *VehicleRepository) GetAll(params VehicleSearchParams) ([]models.Vehicle
 	return vehicles, total, rows.Err()
 }
 
-// GetRecommended retrieves recommended vehicles (featured, recent, or popular)
+// GetRecommended retrieves recommended vehicles (featured, recent, or popular). The result is cached; callers
+// get their own copy of the slice.
 func (r *VehicleRepository) GetRecommended(limit int) ([]models.Vehicle, error) {
-	// Get recommended vehicles based on:
-	// 1. Vehicles marked as recommended
-	// 2. Active status
-	// 3. Good quality (with images)
+	vehicles, err := cache.Fetch(r.cache, recommendedCachePrefix+strconv.Itoa(limit), recommendedCacheTTL, func() ([]models.Vehicle, error) {
+		return r.getRecommended(limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]models.Vehicle(nil), vehicles...), nil
+}
+
+func (r *VehicleRepository) getRecommended(limit int) ([]models.Vehicle, error) {
+	// Get recommended vehicles ranked by the listing score (quality, freshness and engagement,
+	// with vehicles marked as recommended boosted), see recommend.RefreshScores. Highlighted and
+	// promoted listings come first.
 	query := `SELECT
-	    v.status, v.recommended, v.uuid, v.slug, v.title, v.category, v.price, v.currency, v.negotiable,
-		v.person_type_id, pt.name as person_type_name,
-		v.brand, v.model, v.engine_capacity, v.power_hp,
-		v.fuel_type_id, ft.name as fuel_type_name,
-		v.body_type_id, bt.name as body_type_name,
+		v.id, v.user_id, v.organization_id, v.external_id, v.status, v.recommended, v.score, v.featured_image, v.uuid, v.slug, v.title, v.category, v.price, v.currency, v.price_normalized, v.negotiable, IF(v.promoted_until > NOW(), v.promoted_until, NULL), IF(v.highlighted_until > NOW(), v.highlighted_until, NULL),
+		COALESCE(v.person_type_id, 0), COALESCE(pt.name, '') as person_type_name,
+		v.brand_id, v.automobile_id, v.brand, v.model, v.vin, v.engine_capacity, v.power_hp,
+		COALESCE(v.fuel_type_id, 0), COALESCE(ft.name, '') as fuel_type_name,
+		COALESCE(v.body_type_id, 0), COALESCE(bt.name, '') as body_type_name,
 		v.kilometers, v.color, v.year, v.number_of_keys,
-		v.condition_id, c.name as condition_name,
-		v.transmission_id, t.name as transmission_name,
-		v.steering_id, s.name as steering_name,
+		COALESCE(v.condition_id, 0), COALESCE(c.name, '') as condition_name,
+		COALESCE(v.transmission_id, 0), COALESCE(t.name, '') as transmission_name,
+		COALESCE(v.steering_id, 0), COALESCE(s.name, '') as steering_name,
 		v.registered,
-		v.city, v.contact_name, v.email, v.phone, v.created_at, v.updated_at
+		v.city, v.contact_name, v.email, v.phone, v.version, v.created_at, v.updated_at
 	FROM vehicles v
 	LEFT JOIN person_types pt ON v.person_type_id = pt.id
 	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
@@ -855,9 +1477,7 @@ func (r *VehicleRepository) GetRecommended(limit int) ([]models.Vehicle, error)
 	LEFT JOIN transmissions t ON v.transmission_id = t.id
 	LEFT JOIN steerings s ON v.steering_id = s.id
 	WHERE v.status = ?
-	AND v.recommended = 1
-	AND EXISTS (SELECT 1 FROM vehicle_images WHERE vehicle_id = v.id)
-	ORDER BY v.created_at DESC
+	ORDER BY ` + highlightedFirst + `, ` + promotedFirst + `, v.score DESC, v.created_at DESC, v.id DESC
 	LIMIT ?`
 
 	rows, err := r.db.Query(query, models.VehicleStatusActive, limit)
@@ -872,19 +1492,31 @@ func (r *VehicleRepository) GetRecommended(limit int) ([]models.Vehicle, error)
 		var personTypeName, fuelTypeName, bodyTypeName, conditionName, transmissionName, steeringName string
 
 		err := rows.Scan(
+			&vehicle.ID,
+			&vehicle.UserID,
+			&vehicle.OrganizationID,
+			&vehicle.ExternalID,
 			&vehicle.Status,
 			&vehicle.Recommended,
+			&vehicle.Score,
+			&vehicle.FeaturedImage,
 			&vehicle.UUID,
 			&vehicle.Slug,
 			&vehicle.Title,
 			&vehicle.Category,
 			&vehicle.Price,
 			&vehicle.Currency,
+			&vehicle.PriceNormalized,
 			&vehicle.Negotiable,
+			&vehicle.PromotedUntil,
+			&vehicle.HighlightedUntil,
 			&vehicle.PersonTypeID,
 			&personTypeName,
+			&vehicle.BrandID,
+			&vehicle.AutomobileID,
 			&vehicle.Brand,
 			&vehicle.Model,
+			&vehicle.VIN,
 			&vehicle.EngineCapacity,
 			&vehicle.PowerHP,
 			&vehicle.FuelTypeID,
@@ -906,6 +1538,7 @@ func (r *VehicleRepository) GetRecommended(limit int) ([]models.Vehicle, error)
 			&vehicle.ContactName,
 			&vehicle.Email,
 			&vehicle.Phone,
+			&vehicle.Version,
 			&vehicle.CreatedAt,
 			&vehicle.UpdatedAt,
 		)
@@ -936,20 +1569,206 @@ func (r *VehicleRepository) GetRecommended(limit int) ([]models.Vehicle, error)
 	return vehicles, rows.Err()
 }
 
+// SimilarCandidateParams narrows the listings considered for the similar vehicles of a target listing
+type SimilarCandidateParams struct {
+	ExcludeVehicleID uint64
+	ExcludeUserID    uint64 // 0 keeps the seller's other listings
+	BrandID          *uint64
+	Brand            string
+	AutomobileID     *uint64
+	Model            string
+	BodyTypeID       uint8
+	FuelTypeID       uint8
+	Limit            int
+}
+
+// GetSimilarCandidates retrieves up to params.Limit active listings like a target listing: those of the same
+// model first, then of the same brand, then sharing the brand, body type or fuel type, newest first within
+// each group. Images are not loaded; callers rank the candidates and use the featured image.
+func (r *VehicleRepository) GetSimilarCandidates(params SimilarCandidateParams) ([]models.Vehicle, error) {
+	brandCondition, brandArgs := "v.brand = ?", []interface{}{params.Brand}
+	if params.BrandID != nil {
+		brandCondition, brandArgs = "(v.brand_id = ? OR v.brand = ?)", []interface{}{*params.BrandID, params.Brand}
+	}
+
+	type group struct {
+		condition string
+		args      []interface{}
+	}
+	var groups []group
+	if params.AutomobileID != nil {
+		groups = append(groups, group{"v.automobile_id = ?", []interface{}{*params.AutomobileID}})
+	} else if params.Model != "" {
+		groups = append(groups, group{brandCondition + " AND v.model = ?", append(append([]interface{}{}, brandArgs...), params.Model)})
+	}
+	groups = append(groups,
+		group{brandCondition, brandArgs},
+		group{"(" + brandCondition + " OR v.body_type_id = ? OR v.fuel_type_id = ?)", append(append([]interface{}{}, brandArgs...), params.BodyTypeID, params.FuelTypeID)},
+	)
+
+	var candidates []models.Vehicle
+	var seen []interface{}
+	for _, g := range groups {
+		if len(candidates) >= params.Limit {
+			break
+		}
+		vehicles, err := r.getSimilarCandidates(params, g.condition, g.args, seen, params.Limit-len(candidates))
+		if err != nil {
+			return nil, err
+		}
+		for _, vehicle := range vehicles {
+			seen = append(seen, vehicle.ID)
+		}
+		candidates = append(candidates, vehicles...)
+	}
+	return candidates, nil
+}
+
+// getSimilarCandidates retrieves the newest active listings matching condition, other than the excluded IDs
+func (r *VehicleRepository) getSimilarCandidates(params SimilarCandidateParams, condition string, conditionArgs []interface{}, excludeIDs []interface{}, limit int) ([]models.Vehicle, error) {
+	query := `SELECT
+		v.id, v.user_id, v.organization_id, v.external_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.price, v.currency, v.price_normalized, v.negotiable, IF(v.promoted_until > NOW(), v.promoted_until, NULL), IF(v.highlighted_until > NOW(), v.highlighted_until, NULL),
+		COALESCE(v.person_type_id, 0), COALESCE(pt.name, '') as person_type_name,
+		v.brand_id, v.automobile_id, v.brand, v.model, v.vin, v.engine_capacity, v.power_hp,
+		COALESCE(v.fuel_type_id, 0), COALESCE(ft.name, '') as fuel_type_name,
+		COALESCE(v.body_type_id, 0), COALESCE(bt.name, '') as body_type_name,
+		v.kilometers, v.color, v.year, v.number_of_keys,
+		COALESCE(v.condition_id, 0), COALESCE(c.name, '') as condition_name,
+		COALESCE(v.transmission_id, 0), COALESCE(t.name, '') as transmission_name,
+		COALESCE(v.steering_id, 0), COALESCE(s.name, '') as steering_name,
+		v.registered,
+		v.city, v.contact_name, v.email, v.phone, v.version, v.created_at, v.updated_at
+	FROM vehicles v
+	LEFT JOIN person_types pt ON v.person_type_id = pt.id
+	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
+	LEFT JOIN body_types bt ON v.body_type_id = bt.id
+	LEFT JOIN conditions c ON v.condition_id = c.id
+	LEFT JOIN transmissions t ON v.transmission_id = t.id
+	LEFT JOIN steerings s ON v.steering_id = s.id
+	WHERE v.status = ? AND v.id <> ?`
+
+	args := []interface{}{models.VehicleStatusActive, params.ExcludeVehicleID}
+
+	if params.ExcludeUserID > 0 {
+		query += " AND v.user_id <> ?"
+		args = append(args, params.ExcludeUserID)
+	}
+
+	query += " AND " + condition
+	args = append(args, conditionArgs...)
+
+	if len(excludeIDs) > 0 {
+		query += " AND v.id NOT IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(excludeIDs)), ", ") + ")"
+		args = append(args, excludeIDs...)
+	}
+
+	query += " ORDER BY v.created_at DESC, v.id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var vehicles []models.Vehicle
+	for rows.Next() {
+		vehicle := models.Vehicle{}
+		var personTypeName, fuelTypeName, bodyTypeName, conditionName, transmissionName, steeringName string
+
+		err := rows.Scan(
+			&vehicle.ID,
+			&vehicle.UserID,
+			&vehicle.OrganizationID,
+			&vehicle.ExternalID,
+			&vehicle.Status,
+			&vehicle.Recommended,
+			&vehicle.FeaturedImage,
+			&vehicle.UUID,
+			&vehicle.Slug,
+			&vehicle.Title,
+			&vehicle.Category,
+			&vehicle.Price,
+			&vehicle.Currency,
+			&vehicle.PriceNormalized,
+			&vehicle.Negotiable,
+			&vehicle.PromotedUntil,
+			&vehicle.HighlightedUntil,
+			&vehicle.PersonTypeID,
+			&personTypeName,
+			&vehicle.BrandID,
+			&vehicle.AutomobileID,
+			&vehicle.Brand,
+			&vehicle.Model,
+			&vehicle.VIN,
+			&vehicle.EngineCapacity,
+			&vehicle.PowerHP,
+			&vehicle.FuelTypeID,
+			&fuelTypeName,
+			&vehicle.BodyTypeID,
+			&bodyTypeName,
+			&vehicle.Kilometers,
+			&vehicle.Color,
+			&vehicle.Year,
+			&vehicle.NumberOfKeys,
+			&vehicle.ConditionID,
+			&conditionName,
+			&vehicle.TransmissionID,
+			&transmissionName,
+			&vehicle.SteeringID,
+			&steeringName,
+			&vehicle.Registered,
+			&vehicle.City,
+			&vehicle.ContactName,
+			&vehicle.Email,
+			&vehicle.Phone,
+			&vehicle.Version,
+			&vehicle.CreatedAt,
+			&vehicle.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Set the name fields
+		vehicle.PersonType = personTypeName
+		vehicle.FuelType = fuelTypeName
+		vehicle.BodyType = bodyTypeName
+		vehicle.Condition = conditionName
+		vehicle.Transmission = transmissionName
+		vehicle.Steering = steeringName
+		vehicle.StatusName = models.GetStatusName(vehicle.Status)
+
+		vehicles = append(vehicles, vehicle)
+	}
+
+	return vehicles, rows.Err()
+}
+
 // GetByUserID retrieves all vehicles for a specific user
 func (r *VehicleRepository) GetByUserID(userID uint64) ([]models.Vehicle, error) {
+	return r.getByUserID(userID, "v.status <> ?")
+}
+
+// GetDraftsByUserID retrieves the draft listings of a user
+func (r *VehicleRepository) GetDraftsByUserID(userID uint64) ([]models.Vehicle, error) {
+	return r.getByUserID(userID, "v.status = ?")
+}
+
+// getByUserID retrieves the vehicles of a user whose status matches statusCondition (compared with the draft status)
+func (r *VehicleRepository) getByUserID(userID uint64, statusCondition string) ([]models.Vehicle, error) {
 	query := `SELECT
-		v.id, v.user_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.description, v.price, v.currency, v.negotiable,
-		v.person_type_id, pt.name as person_type_name,
-		v.brand, v.model, v.engine_capacity, v.power_hp,
-		v.fuel_type_id, ft.name as fuel_type_name,
-		v.body_type_id, bt.name as body_type_name,
+		v.id, v.user_id, v.organization_id, v.external_id, v.status, v.recommended, v.featured_image, v.uuid, v.slug, v.title, v.category, v.description, v.price, v.currency, v.price_normalized, v.negotiable, IF(v.promoted_until > NOW(), v.promoted_until, NULL), IF(v.highlighted_until > NOW(), v.highlighted_until, NULL),
+		COALESCE(v.person_type_id, 0), COALESCE(pt.name, '') as person_type_name,
+		v.brand_id, v.automobile_id, v.brand, v.model, v.vin, v.engine_capacity, v.power_hp,
+		COALESCE(v.fuel_type_id, 0), COALESCE(ft.name, '') as fuel_type_name,
+		COALESCE(v.body_type_id, 0), COALESCE(bt.name, '') as body_type_name,
 		v.kilometers, v.color, v.year, v.number_of_keys,
-		v.condition_id, c.name as condition_name,
-		v.transmission_id, t.name as transmission_name,
-		v.steering_id, s.name as steering_name,
+		COALESCE(v.condition_id, 0), COALESCE(c.name, '') as condition_name,
+		COALESCE(v.transmission_id, 0), COALESCE(t.name, '') as transmission_name,
+		COALESCE(v.steering_id, 0), COALESCE(s.name, '') as steering_name,
 		v.registered,
-		v.city, v.contact_name, v.email, v.phone, v.created_at, v.updated_at
+		v.city, v.contact_name, v.email, v.phone, v.version, v.created_at, v.updated_at
 	FROM vehicles v
 	LEFT JOIN person_types pt ON v.person_type_id = pt.id
 	LEFT JOIN fuel_types ft ON v.fuel_type_id = ft.id
@@ -957,10 +1776,10 @@ func (r *VehicleRepository) GetByUserID(userID uint64) ([]models.Vehicle, error)
 	LEFT JOIN conditions c ON v.condition_id = c.id
 	LEFT JOIN transmissions t ON v.transmission_id = t.id
 	LEFT JOIN steerings s ON v.steering_id = s.id
-	WHERE v.user_id = ?
+	WHERE v.user_id = ? AND ` + statusCondition + `
 	ORDER BY v.created_at DESC`
 
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.db.Query(query, userID, models.VehicleStatusDraft)
 	if err != nil {
 		return nil, err
 	}
@@ -974,6 +1793,8 @@ func (r *VehicleRepository) GetByUserID(userID uint64) ([]models.Vehicle, error)
 		err := rows.Scan(
 			&vehicle.ID,
 			&vehicle.UserID,
+			&vehicle.OrganizationID,
+			&vehicle.ExternalID,
 			&vehicle.Status,
 			&vehicle.Recommended,
 			&vehicle.FeaturedImage,
@@ -984,11 +1805,17 @@ func (r *VehicleRepository) GetByUserID(userID uint64) ([]models.Vehicle, error)
 			&vehicle.Description,
 			&vehicle.Price,
 			&vehicle.Currency,
+			&vehicle.PriceNormalized,
 			&vehicle.Negotiable,
+			&vehicle.PromotedUntil,
+			&vehicle.HighlightedUntil,
 			&vehicle.PersonTypeID,
 			&personTypeName,
+			&vehicle.BrandID,
+			&vehicle.AutomobileID,
 			&vehicle.Brand,
 			&vehicle.Model,
+			&vehicle.VIN,
 			&vehicle.EngineCapacity,
 			&vehicle.PowerHP,
 			&vehicle.FuelTypeID,
@@ -1010,6 +1837,7 @@ func (r *VehicleRepository) GetByUserID(userID uint64) ([]models.Vehicle, error)
 			&vehicle.ContactName,
 			&vehicle.Email,
 			&vehicle.Phone,
+			&vehicle.Version,
 			&vehicle.CreatedAt,
 			&vehicle.UpdatedAt,
 		)
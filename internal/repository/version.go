@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// ErrVersionConflict matches the VersionConflictError of updates of a row whose version changed since the caller read it
+var ErrVersionConflict = errors.New("modified since it was read")
+
+// VersionConflictError is returned by updates guarded by a version that is no longer the current one
+type VersionConflictError struct {
+	Current uint64
+}
+
+func (e *VersionConflictError) Error() string {
+	return ErrVersionConflict.Error() + ", the current version is " + strconv.FormatUint(e.Current, 10)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
+// staleVersion tells why an update guarded by the version of a row matched no row: notFound when the
+// row is gone, a VersionConflictError when another update changed its version
+func staleVersion(q querier, table, column string, key interface{}, notFound error) error {
+	var current uint64
+	err := q.QueryRow("SELECT version FROM "+table+" WHERE "+column+" = ?", key).Scan(&current)
+	if err == sql.ErrNoRows {
+		return notFound
+	}
+	if err != nil {
+		return err
+	}
+	return &VersionConflictError{Current: current}
+}
@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"autoelys_backend/internal/models"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrViewingRequestNotFound = errors.New("viewing request not found")
+var ErrViewingRequestChanged = errors.New("viewing request has changed")
+
+type ViewingRequestRepository struct {
+	db *sql.DB
+}
+
+func NewViewingRequestRepository(db *sql.DB) *ViewingRequestRepository {
+	return &ViewingRequestRepository{db: db}
+}
+
+// The seller is the owner of the vehicle
+const viewingRequestSelect = `
+	SELECT r.id, r.uuid, r.vehicle_id, v.uuid, v.slug, v.title, v.city,
+		v.user_id, CONCAT(su.first_name, ' ', su.last_name), su.email,
+		r.buyer_id, CONCAT(bu.first_name, ' ', bu.last_name), bu.email,
+		r.kind, r.message, r.status, r.scheduled_at, r.duration_minutes, r.response_message, r.created_at, r.updated_at
+	FROM viewing_requests r
+	INNER JOIN vehicles v ON v.id = r.vehicle_id
+	INNER JOIN users su ON su.id = v.user_id
+	INNER JOIN users bu ON bu.id = r.buyer_id
+`
+
+func scanViewingRequest(scanner interface{ Scan(...interface{}) error }) (*models.ViewingRequest, error) {
+	req := &models.ViewingRequest{}
+	err := scanner.Scan(
+		&req.ID,
+		&req.UUID,
+		&req.VehicleID,
+		&req.VehicleUUID,
+		&req.VehicleSlug,
+		&req.VehicleTitle,
+		&req.VehicleCity,
+		&req.SellerID,
+		&req.SellerName,
+		&req.SellerEmail,
+		&req.BuyerID,
+		&req.BuyerName,
+		&req.BuyerEmail,
+		&req.Kind,
+		&req.Message,
+		&req.Status,
+		&req.ScheduledAt,
+		&req.DurationMinutes,
+		&req.ResponseMessage,
+		&req.CreatedAt,
+		&req.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// Create stores a pending request with the buyer's proposed slots as the first round
+func (r *ViewingRequestRepository) Create(req *models.ViewingRequest, slots []time.Time) error {
+	req.UUID = uuid.New().String()
+	req.Status = models.ViewingStatusPending
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("INSERT INTO viewing_requests (uuid, vehicle_id, buyer_id, kind, message, status, duration_minutes) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		req.UUID, req.VehicleID, req.BuyerID, req.Kind, req.Message, req.Status, req.DurationMinutes)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	req.ID = uint64(id)
+
+	if err := insertViewingSlots(tx, req.ID, 1, models.ViewingPartyBuyer, slots); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// FindByUUID retrieves a request with all its proposed slots
+func (r *ViewingRequestRepository) FindByUUID(uuid string) (*models.ViewingRequest, error) {
+	req, err := scanViewingRequest(r.db.QueryRow(viewingRequestSelect+" WHERE r.uuid = ?", uuid))
+	if err == sql.ErrNoRows {
+		return nil, ErrViewingRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query("SELECT round, proposed_by, starts_at FROM viewing_request_slots WHERE viewing_request_id = ? ORDER BY round, starts_at", req.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	req.Slots = []models.ViewingSlot{}
+	for rows.Next() {
+		var slot models.ViewingSlot
+		if err := rows.Scan(&slot.Round, &slot.ProposedBy, &slot.StartsAt); err != nil {
+			return nil, err
+		}
+		req.Slots = append(req.Slots, slot)
+	}
+	return req, rows.Err()
+}
+
+// GetInbox retrieves the requests for the vehicles a user owns or manages through an organization,
+// newest first, optionally with a status
+func (r *ViewingRequestRepository) GetInbox(userID uint64, status string, limit, offset int) ([]models.ViewingRequest, int, error) {
+	whereClause := ` WHERE (v.user_id = ? OR v.organization_id IN (
+		SELECT organization_id FROM organization_members WHERE user_id = ? AND role IN ('owner', 'manager')
+	))`
+	return r.list(whereClause, []interface{}{userID, userID}, status, limit, offset)
+}
+
+// GetSent retrieves the requests a user made, newest first, optionally with a status
+func (r *ViewingRequestRepository) GetSent(userID uint64, status string, limit, offset int) ([]models.ViewingRequest, int, error) {
+	return r.list(" WHERE r.buyer_id = ?", []interface{}{userID}, status, limit, offset)
+}
+
+func (r *ViewingRequestRepository) list(whereClause string, args []interface{}, status string, limit, offset int) ([]models.ViewingRequest, int, error) {
+	if status != "" {
+		whereClause += " AND r.status = ?"
+		args = append(args, status)
+	}
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM viewing_requests r INNER JOIN vehicles v ON v.id = r.vehicle_id" + whereClause
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(viewingRequestSelect+whereClause+" ORDER BY r.updated_at DESC, r.id DESC LIMIT ? OFFSET ?", append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	requests := []models.ViewingRequest{}
+	for rows.Next() {
+		req, err := scanViewingRequest(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		requests = append(requests, *req)
+	}
+	return requests, total, rows.Err()
+}
+
+// Accept schedules a request at one of the open slots
+func (r *ViewingRequestRepository) Accept(id uint64, fromStatus string, scheduledAt time.Time, message *string) error {
+	return r.update(id, fromStatus, func(tx *sql.Tx) error {
+		_, err := tx.Exec("UPDATE viewing_requests SET status = ?, scheduled_at = ?, response_message = ? WHERE id = ?",
+			models.ViewingStatusAccepted, scheduledAt, message, id)
+		return err
+	})
+}
+
+// Counter adds a new round of slots proposed by party and hands the request to the other party
+func (r *ViewingRequestRepository) Counter(id uint64, fromStatus, party string, slots []time.Time, message *string) error {
+	status := models.ViewingStatusCountered
+	if party == models.ViewingPartyBuyer {
+		status = models.ViewingStatusPending
+	}
+
+	return r.update(id, fromStatus, func(tx *sql.Tx) error {
+		var round uint
+		if err := tx.QueryRow("SELECT COALESCE(MAX(round), 0) FROM viewing_request_slots WHERE viewing_request_id = ?", id).Scan(&round); err != nil {
+			return err
+		}
+		if err := insertViewingSlots(tx, id, round+1, party, slots); err != nil {
+			return err
+		}
+		_, err := tx.Exec("UPDATE viewing_requests SET status = ?, response_message = ? WHERE id = ?", status, message, id)
+		return err
+	})
+}
+
+// Close declines or cancels a request
+func (r *ViewingRequestRepository) Close(id uint64, fromStatus, status string, message *string) error {
+	return r.update(id, fromStatus, func(tx *sql.Tx) error {
+		_, err := tx.Exec("UPDATE viewing_requests SET status = ?, response_message = COALESCE(?, response_message) WHERE id = ?", status, message, id)
+		return err
+	})
+}
+
+// update applies change to a request that still has fromStatus, or returns ErrViewingRequestChanged
+// when the other party responded in the meantime
+func (r *ViewingRequestRepository) update(id uint64, fromStatus string, change func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status string
+	err = tx.QueryRow("SELECT status FROM viewing_requests WHERE id = ? FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return ErrViewingRequestNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != fromStatus {
+		return ErrViewingRequestChanged
+	}
+
+	if err := change(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertViewingSlots(tx *sql.Tx, requestID uint64, round uint, party string, slots []time.Time) error {
+	for _, startsAt := range slots {
+		_, err := tx.Exec("INSERT INTO viewing_request_slots (viewing_request_id, round, proposed_by, starts_at) VALUES (?, ?, ?, ?)",
+			requestID, round, party, startsAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package seo
+
+import (
+	"strconv"
+
+	"autoelys_backend/internal/models"
+)
+
+// Schema.org values of the vehicle lookup tables; unknown values are left out
+var (
+	fuelTypes = map[string]string{
+		"benzina":         "Gasoline",
+		"motorina":        "Diesel",
+		"electric":        "Electric",
+		"hibrid":          "Hybrid",
+		"gpl":             "LPG",
+		"hybrid_benzina":  "Gasoline hybrid",
+		"hybrid_motorina": "Diesel hybrid",
+	}
+	transmissions = map[string]string{
+		"manuala":  "Manual",
+		"automata": "Automatic",
+	}
+	bodyTypes = map[string]string{
+		"sedan":     "Sedan",
+		"suv":       "SUV",
+		"break":     "Wagon",
+		"coupe":     "Coupe",
+		"cabrio":    "Convertible",
+		"hatchback": "Hatchback",
+		"pickup":    "Pickup",
+		"van":       "Van",
+		"monovolum": "Minivan",
+	}
+)
+
+// Car is the schema.org Car JSON-LD of a listing
+type Car struct {
+	Context             string               `json:"@context"`
+	Type                string               `json:"@type"`
+	Name                string               `json:"name"`
+	Description         string               `json:"description,omitempty"`
+	URL                 string               `json:"url"`
+	Image               []string             `json:"image,omitempty"`
+	Brand               Thing                `json:"brand"`
+	Model               string               `json:"model"`
+	VehicleModelDate    string               `json:"vehicleModelDate"`
+	BodyType            string               `json:"bodyType,omitempty"`
+	FuelType            string               `json:"fuelType,omitempty"`
+	VehicleTransmission string               `json:"vehicleTransmission,omitempty"`
+	Color               string               `json:"color,omitempty"`
+	MileageFromOdometer *QuantitativeValue   `json:"mileageFromOdometer,omitempty"`
+	VehicleEngine       *EngineSpecification `json:"vehicleEngine,omitempty"`
+	ItemCondition       string               `json:"itemCondition"`
+	Offers              Offer                `json:"offers"`
+}
+
+// Thing is a schema.org item referenced by type and name, such as a Brand
+type Thing struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// QuantitativeValue is a schema.org value with a UN/CEFACT unit code
+type QuantitativeValue struct {
+	Type     string `json:"@type"`
+	Value    int    `json:"value"`
+	UnitCode string `json:"unitCode"`
+}
+
+// EngineSpecification is the schema.org engine of a vehicle
+type EngineSpecification struct {
+	Type               string             `json:"@type"`
+	EngineDisplacement *QuantitativeValue `json:"engineDisplacement,omitempty"`
+	EnginePower        *QuantitativeValue `json:"enginePower,omitempty"`
+	FuelType           string             `json:"fuelType,omitempty"`
+}
+
+// Offer is the schema.org offer of a listing
+type Offer struct {
+	Type              string `json:"@type"`
+	URL               string `json:"url"`
+	Price             string `json:"price"`
+	PriceCurrency     string `json:"priceCurrency"`
+	Availability      string `json:"availability"`
+	ItemCondition     string `json:"itemCondition"`
+	AvailableAtOrFrom Place  `json:"availableAtOrFrom"`
+}
+
+// Place is the schema.org place a listing is available at
+type Place struct {
+	Type    string        `json:"@type"`
+	Address PostalAddress `json:"address"`
+}
+
+// PostalAddress is the schema.org address of a place
+type PostalAddress struct {
+	Type            string `json:"@type"`
+	AddressLocality string `json:"addressLocality"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+func newCar(vehicle *models.Vehicle, meta Metadata, images []string) Car {
+	condition := "https://schema.org/UsedCondition"
+	if vehicle.Condition == "nou" {
+		condition = "https://schema.org/NewCondition"
+	}
+	availability := "https://schema.org/InStock"
+	if vehicle.Status != models.VehicleStatusActive {
+		availability = "https://schema.org/OutOfStock"
+	}
+
+	car := Car{
+		Context:             "https://schema.org",
+		Type:                "Car",
+		Name:                vehicle.Title,
+		Description:         meta.Description,
+		URL:                 meta.CanonicalURL,
+		Image:               images,
+		Brand:               Thing{Type: "Brand", Name: vehicle.Brand},
+		Model:               vehicle.Model,
+		VehicleModelDate:    strconv.Itoa(vehicle.Year),
+		BodyType:            bodyTypes[vehicle.BodyType],
+		FuelType:            fuelTypes[vehicle.FuelType],
+		VehicleTransmission: transmissions[vehicle.Transmission],
+		ItemCondition:       condition,
+		Offers: Offer{
+			Type:          "Offer",
+			URL:           meta.CanonicalURL,
+			Price:         price(vehicle.Price),
+			PriceCurrency: models.ISOCurrency(vehicle.Currency),
+			Availability:  availability,
+			ItemCondition: condition,
+			AvailableAtOrFrom: Place{
+				Type:    "Place",
+				Address: PostalAddress{Type: "PostalAddress", AddressLocality: vehicle.City, AddressCountry: "RO"},
+			},
+		},
+	}
+	if vehicle.Color != nil {
+		car.Color = *vehicle.Color
+	}
+	if vehicle.Kilometers != nil {
+		car.MileageFromOdometer = &QuantitativeValue{Type: "QuantitativeValue", Value: *vehicle.Kilometers, UnitCode: "KMT"}
+	}
+	if vehicle.EngineCapacity != nil || vehicle.PowerHP != nil {
+		car.VehicleEngine = &EngineSpecification{Type: "EngineSpecification", FuelType: car.FuelType}
+		if vehicle.EngineCapacity != nil {
+			car.VehicleEngine.EngineDisplacement = &QuantitativeValue{Type: "QuantitativeValue", Value: *vehicle.EngineCapacity, UnitCode: "CMQ"}
+		}
+		if vehicle.PowerHP != nil {
+			car.VehicleEngine.EnginePower = &QuantitativeValue{Type: "QuantitativeValue", Value: *vehicle.PowerHP, UnitCode: "BHP"}
+		}
+	}
+	return car
+}
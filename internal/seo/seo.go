@@ -0,0 +1,169 @@
+package seo
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"autoelys_backend/internal/models"
+)
+
+// descriptionLength is the length search engines and social cards show of a description
+const descriptionLength = 160
+
+// Options configure the generated metadata
+type Options struct {
+	SiteURL  string // base URL of the public site, used for canonical URLs
+	MediaURL string // base URL the uploaded images are served from
+	SiteName string
+	Locale   string // Open Graph locale, e.g. ro_RO
+}
+
+// Metadata is everything a client needs to render the head of a listing page
+type Metadata struct {
+	CanonicalURL string    `json:"canonical_url"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	JSONLD       Car       `json:"json_ld"`
+	OpenGraph    []MetaTag `json:"open_graph"`
+	Twitter      []MetaTag `json:"twitter"`
+}
+
+// MetaTag is a <meta> tag; Open Graph tags use the property attribute, Twitter card tags the name attribute
+type MetaTag struct {
+	Property string `json:"property,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Content  string `json:"content"`
+}
+
+// ForVehicle derives the page metadata of a vehicle listing
+func ForVehicle(vehicle *models.Vehicle, opts Options) Metadata {
+	meta := Metadata{
+		CanonicalURL: joinURL(opts.SiteURL, "/vehicles/"+vehicle.Slug),
+		Title:        vehicle.Title,
+		Description:  description(vehicle),
+	}
+	if opts.SiteName != "" {
+		meta.Title += " | " + opts.SiteName
+	}
+
+	images := imageURLs(vehicle, opts.MediaURL)
+	meta.JSONLD = newCar(vehicle, meta, images)
+	meta.OpenGraph = openGraph(vehicle, meta, images, opts)
+	meta.Twitter = twitterCard(meta, images)
+	return meta
+}
+
+// description is the start of the listing description, or its key specs when it has none
+func description(vehicle *models.Vehicle) string {
+	if vehicle.Description != nil {
+		if text := strings.Join(strings.Fields(*vehicle.Description), " "); text != "" {
+			return truncate(text, descriptionLength)
+		}
+	}
+
+	parts := []string{vehicle.Brand + " " + vehicle.Model, strconv.Itoa(vehicle.Year)}
+	if vehicle.Kilometers != nil {
+		parts = append(parts, strconv.Itoa(*vehicle.Kilometers)+" km")
+	}
+	for _, value := range []string{fuelTypes[vehicle.FuelType], transmissions[vehicle.Transmission], vehicle.City} {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	parts = append(parts, price(vehicle.Price)+" "+models.ISOCurrency(vehicle.Currency))
+	return strings.Join(parts, ", ")
+}
+
+// truncate shortens text to at most max characters at a word boundary, marking the cut with an ellipsis
+func truncate(text string, max int) string {
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)[:max-1]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
+
+// imageURLs returns the absolute image URLs, featured image first
+func imageURLs(vehicle *models.Vehicle, mediaURL string) []string {
+	var images []string
+	if vehicle.FeaturedImage != nil && *vehicle.FeaturedImage != "" {
+		images = append(images, joinURL(mediaURL, *vehicle.FeaturedImage))
+	}
+	for _, image := range vehicle.Images {
+		if vehicle.FeaturedImage != nil && image.ImageURL == *vehicle.FeaturedImage {
+			continue
+		}
+		images = append(images, joinURL(mediaURL, image.ImageURL))
+	}
+	return images
+}
+
+func openGraph(vehicle *models.Vehicle, meta Metadata, images []string, opts Options) []MetaTag {
+	tags := []MetaTag{
+		{Property: "og:type", Content: "product"},
+		{Property: "og:title", Content: vehicle.Title},
+		{Property: "og:description", Content: meta.Description},
+		{Property: "og:url", Content: meta.CanonicalURL},
+	}
+	if opts.SiteName != "" {
+		tags = append(tags, MetaTag{Property: "og:site_name", Content: opts.SiteName})
+	}
+	if opts.Locale != "" {
+		tags = append(tags, MetaTag{Property: "og:locale", Content: opts.Locale})
+	}
+	for _, image := range images {
+		tags = append(tags, MetaTag{Property: "og:image", Content: image}, MetaTag{Property: "og:image:alt", Content: vehicle.Title})
+	}
+	return append(tags,
+		MetaTag{Property: "product:price:amount", Content: price(vehicle.Price)},
+		MetaTag{Property: "product:price:currency", Content: models.ISOCurrency(vehicle.Currency)},
+		MetaTag{Property: "product:availability", Content: ogAvailability(vehicle)},
+		MetaTag{Property: "product:condition", Content: ogCondition(vehicle)},
+	)
+}
+
+func twitterCard(meta Metadata, images []string) []MetaTag {
+	card := "summary"
+	if len(images) > 0 {
+		card = "summary_large_image"
+	}
+	tags := []MetaTag{
+		{Name: "twitter:card", Content: card},
+		{Name: "twitter:title", Content: meta.JSONLD.Name},
+		{Name: "twitter:description", Content: meta.Description},
+	}
+	if len(images) > 0 {
+		tags = append(tags, MetaTag{Name: "twitter:image", Content: images[0]}, MetaTag{Name: "twitter:image:alt", Content: meta.JSONLD.Name})
+	}
+	return tags
+}
+
+func ogAvailability(vehicle *models.Vehicle) string {
+	if vehicle.Status == models.VehicleStatusActive {
+		return "in stock"
+	}
+	return "out of stock"
+}
+
+func ogCondition(vehicle *models.Vehicle) string {
+	if vehicle.Condition == "nou" {
+		return "new"
+	}
+	return "used"
+}
+
+func price(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+func joinURL(base, path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
+}
@@ -0,0 +1,108 @@
+package seo
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"autoelys_backend/internal/models"
+)
+
+var testOptions = Options{
+	SiteURL:  "https://autoelys.example/",
+	MediaURL: "https://api.autoelys.example",
+	SiteName: "AutoElys",
+	Locale:   "ro_RO",
+}
+
+func testVehicle() *models.Vehicle {
+	featured := "/uploads/vehicles/octavia-2.jpg"
+	kilometers, capacity, power := 128500, 1968, 150
+	return &models.Vehicle{
+		Status:         models.VehicleStatusActive,
+		FeaturedImage:  &featured,
+		Slug:           "skoda-octavia-2-0-tdi-style",
+		Title:          "Skoda Octavia 2.0 TDI Style",
+		Price:          14900,
+		Currency:       models.CurrencyEuro,
+		Brand:          "Skoda",
+		Model:          "Octavia",
+		EngineCapacity: &capacity,
+		PowerHP:        &power,
+		FuelType:       "motorina",
+		BodyType:       "break",
+		Kilometers:     &kilometers,
+		Year:           2019,
+		Condition:      "utilizat",
+		Transmission:   "automata",
+		City:           "Cluj-Napoca",
+		Images: []models.VehicleImage{
+			{ImageURL: "/uploads/vehicles/octavia-1.jpg"},
+			{ImageURL: "/uploads/vehicles/octavia-2.jpg"},
+		},
+	}
+}
+
+func tagContent(tags []MetaTag, key string) []string {
+	var values []string
+	for _, tag := range tags {
+		if tag.Property == key || tag.Name == key {
+			values = append(values, tag.Content)
+		}
+	}
+	return values
+}
+
+func TestForVehicle(t *testing.T) {
+	meta := ForVehicle(testVehicle(), testOptions)
+
+	if meta.CanonicalURL != "https://autoelys.example/vehicles/skoda-octavia-2-0-tdi-style" {
+		t.Errorf("CanonicalURL = %q", meta.CanonicalURL)
+	}
+	if meta.Title != "Skoda Octavia 2.0 TDI Style | AutoElys" {
+		t.Errorf("Title = %q", meta.Title)
+	}
+	if want := "Skoda Octavia, 2019, 128500 km, Diesel, Automatic, Cluj-Napoca, 14900.00 EUR"; meta.Description != want {
+		t.Errorf("Description = %q, want %q", meta.Description, want)
+	}
+
+	images := tagContent(meta.OpenGraph, "og:image")
+	if len(images) != 2 || images[0] != "https://api.autoelys.example/uploads/vehicles/octavia-2.jpg" {
+		t.Errorf("og:image = %v, want the featured image first", images)
+	}
+	if got := tagContent(meta.Twitter, "twitter:card"); len(got) != 1 || got[0] != "summary_large_image" {
+		t.Errorf("twitter:card = %v", got)
+	}
+
+	data, err := json.Marshal(meta.JSONLD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"@type":"Car"`,
+		`"priceCurrency":"EUR"`,
+		`"price":"14900.00"`,
+		`"availability":"https://schema.org/InStock"`,
+		`"mileageFromOdometer":{"@type":"QuantitativeValue","value":128500,"unitCode":"KMT"}`,
+		`"bodyType":"Wagon"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON-LD %s does not contain %s", data, want)
+		}
+	}
+}
+
+func TestDescriptionTruncated(t *testing.T) {
+	vehicle := testVehicle()
+	text := strings.Repeat("Masina foarte bine intretinuta, ", 10)
+	vehicle.Description = &text
+
+	description := ForVehicle(vehicle, testOptions).Description
+	if n := len([]rune(description)); n > descriptionLength {
+		t.Errorf("description has %d characters, want at most %d", n, descriptionLength)
+	}
+	cut := strings.TrimSuffix(description, "…")
+	if cut == description || !strings.HasPrefix(text, cut) || !strings.ContainsAny(text[len(cut):len(cut)+1], " ,") {
+		t.Errorf("description %q is not cut at a word boundary", description)
+	}
+}
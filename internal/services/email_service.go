@@ -75,6 +75,93 @@ AutoElys Team
 	return nil
 }
 
+// BookingEmail describes an appointment in booking emails; When is already formatted in the workshop's time zone
+type BookingEmail struct {
+	FirstName string
+	Service   string
+	When      string
+	Reference string
+}
+
+func (s *EmailService) SendBookingConfirmationEmail(toEmail string, booking BookingEmail) error {
+	return s.sendBookingEmail(toEmail, "BOOKING CONFIRMATION", "Your appointment is confirmed", "Your appointment is confirmed:", booking)
+}
+
+func (s *EmailService) SendBookingRescheduledEmail(toEmail string, booking BookingEmail) error {
+	return s.sendBookingEmail(toEmail, "BOOKING RESCHEDULED", "Your appointment has been rescheduled", "Your appointment has been moved to a new time:", booking)
+}
+
+func (s *EmailService) SendBookingCancelledEmail(toEmail string, booking BookingEmail) error {
+	return s.sendBookingEmail(toEmail, "BOOKING CANCELLED", "Your appointment has been cancelled", "The following appointment has been cancelled:", booking)
+}
+
+func (s *EmailService) sendBookingEmail(toEmail, kind, subject, intro string, booking BookingEmail) error {
+	body := fmt.Sprintf(`
+Hello %s,
+
+%s
+
+Service: %s
+When: %s
+Reference: %s
+
+You can manage your appointments at %s/bookings
+
+Best regards,
+AutoElys Team
+`, booking.FirstName, intro, booking.Service, booking.When, booking.Reference, s.appURL)
+
+	log.Printf("===== %s EMAIL =====", kind)
+	log.Printf("To: %s", toEmail)
+	log.Printf("Subject: %s", subject)
+	log.Printf("Body:\n%s", body)
+	log.Printf("=====================")
+
+	return nil
+}
+
+// EmailAttachment is a file attached to an email
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// ViewingEmail describes an update of a viewing or test drive request. Times are already formatted
+// in the local time zone; Path is the page of the request on the site.
+type ViewingEmail struct {
+	FirstName   string
+	Subject     string
+	Summary     string
+	Vehicle     string
+	Times       []string
+	Message     string
+	Path        string
+	Attachments []EmailAttachment
+}
+
+func (s *EmailService) SendViewingRequestEmail(toEmail string, email ViewingEmail) error {
+	body := fmt.Sprintf("\nHello %s,\n\n%s\n\nVehicle: %s\n", email.FirstName, email.Summary, email.Vehicle)
+	for _, t := range email.Times {
+		body += fmt.Sprintf("- %s\n", t)
+	}
+	if email.Message != "" {
+		body += fmt.Sprintf("\nMessage: %s\n", email.Message)
+	}
+	body += fmt.Sprintf("\nView the request at %s%s\n\nBest regards,\nAutoElys Team\n", s.appURL, email.Path)
+
+	log.Printf("===== VIEWING REQUEST EMAIL =====")
+	log.Printf("To: %s", toEmail)
+	log.Printf("Subject: %s", email.Subject)
+	log.Printf("Body:\n%s", body)
+	for _, attachment := range email.Attachments {
+		log.Printf("Attachment: %s (%s, %d bytes)", attachment.Filename, attachment.ContentType, len(attachment.Data))
+	}
+	log.Printf("=================================")
+
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
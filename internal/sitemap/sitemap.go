@@ -0,0 +1,243 @@
+package sitemap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"autoelys_backend/internal/catalog"
+	"autoelys_backend/internal/repository"
+	"autoelys_backend/internal/utils"
+)
+
+// MaxURLs is the maximum number of URLs in a sitemap file allowed by the sitemaps protocol
+const MaxURLs = 50000
+
+// Paths of the sitemap index and of the sitemap files, relative to the site root
+const (
+	IndexFile = "sitemap.xml"
+	Dir       = "sitemaps"
+)
+
+// Sitemap sections, each split into files of at most MaxURLs URLs named <section>-<page>.xml
+const (
+	SectionVehicles = "vehicles"
+	SectionBrands   = "brands"
+	SectionModels   = "models"
+)
+
+// ErrNotFound is returned for sitemap file names that do not exist
+var ErrNotFound = errors.New("sitemap not found")
+
+// URL is a page listed in a sitemap; a zero LastMod is left out
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// File is a sitemap file listed in the sitemap index
+type File struct {
+	Name    string
+	LastMod time.Time
+}
+
+// Generator builds the sitemaps of the public vehicle, brand and model pages
+type Generator struct {
+	repo     *repository.SitemapRepository
+	siteURL  string
+	pageSize int
+}
+
+// NewGenerator creates a generator linking to pages of the site at siteURL
+func NewGenerator(repo *repository.SitemapRepository, siteURL string) *Generator {
+	return &Generator{
+		repo:     repo,
+		siteURL:  strings.TrimRight(siteURL, "/"),
+		pageSize: MaxURLs,
+	}
+}
+
+// Files lists the sitemap files of every section; sections without pages have no file
+func (g *Generator) Files() ([]File, error) {
+	var files []File
+
+	count, lastMod, err := g.repo.CountVehicles()
+	if err != nil {
+		return nil, err
+	}
+	for page := 1; (page-1)*g.pageSize < count; page++ {
+		files = append(files, File{Name: fileName(SectionVehicles, page), LastMod: lastMod.UTC()})
+	}
+
+	for _, section := range []string{SectionBrands, SectionModels} {
+		urls, err := g.catalogURLs(section)
+		if err != nil {
+			return nil, err
+		}
+		for page := 1; (page-1)*g.pageSize < len(urls); page++ {
+			files = append(files, File{Name: fileName(section, page), LastMod: latest(g.paginate(urls, page))})
+		}
+	}
+
+	return files, nil
+}
+
+// URLs returns the URLs of a sitemap file such as vehicles-1.xml
+func (g *Generator) URLs(name string) ([]URL, error) {
+	section, page, ok := parseFileName(name)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	var urls []URL
+	switch section {
+	case SectionVehicles:
+		vehicles, err := g.repo.GetVehicles((page-1)*g.pageSize, g.pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, vehicle := range vehicles {
+			urls = append(urls, URL{Loc: g.siteURL + "/vehicles/" + vehicle.Slug, LastMod: vehicle.UpdatedAt.UTC()})
+		}
+	case SectionBrands, SectionModels:
+		all, err := g.catalogURLs(section)
+		if err != nil {
+			return nil, err
+		}
+		urls = g.paginate(all, page)
+	default:
+		return nil, ErrNotFound
+	}
+
+	if len(urls) == 0 {
+		return nil, ErrNotFound
+	}
+	return urls, nil
+}
+
+// catalogURLs returns the brand pages (/brands/<brand>) or model pages (/brands/<brand>/<model>). Catalog
+// entries sharing a slug, such as the generations of a model, are listed once with their latest change.
+func (g *Generator) catalogURLs(section string) ([]URL, error) {
+	var pages []repository.SitemapPage
+	var err error
+	if section == SectionBrands {
+		pages, err = g.repo.GetBrands()
+	} else {
+		pages, err = g.repo.GetModels()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []URL
+	index := make(map[string]int, len(pages))
+	for _, page := range pages {
+		path := utils.GenerateSlug(page.Brand)
+		if path == "" {
+			continue
+		}
+		if section == SectionModels {
+			model := utils.GenerateSlug(catalog.CleanModelName(page.Model, page.Brand))
+			if model == "" {
+				continue
+			}
+			path += "/" + model
+		}
+
+		loc := g.siteURL + "/brands/" + path
+		if i, ok := index[loc]; ok {
+			if page.UpdatedAt.After(urls[i].LastMod) {
+				urls[i].LastMod = page.UpdatedAt.UTC()
+			}
+			continue
+		}
+		index[loc] = len(urls)
+		urls = append(urls, URL{Loc: loc, LastMod: page.UpdatedAt.UTC()})
+	}
+	return urls, nil
+}
+
+// paginate returns the URLs of the 1-based page
+func (g *Generator) paginate(urls []URL, page int) []URL {
+	start := (page - 1) * g.pageSize
+	if start >= len(urls) {
+		return nil
+	}
+	end := start + g.pageSize
+	if end > len(urls) {
+		end = len(urls)
+	}
+	return urls[start:end]
+}
+
+// WriteFiles writes the sitemap index to dir and the sitemap files to its sitemaps subdirectory, with the index
+// pointing to them under baseURL. It returns the number of sitemap files written.
+func (g *Generator) WriteFiles(dir, baseURL string) (int, error) {
+	files, err := g.Files()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, Dir), 0755); err != nil {
+		return 0, err
+	}
+
+	for _, file := range files {
+		urls, err := g.URLs(file.Name)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		if err := writeFile(filepath.Join(dir, Dir, file.Name), func(f *os.File) error { return WriteURLSet(f, urls) }); err != nil {
+			return 0, err
+		}
+	}
+
+	err = writeFile(filepath.Join(dir, IndexFile), func(f *os.File) error { return WriteIndex(f, baseURL, files) })
+	return len(files), err
+}
+
+func writeFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func fileName(section string, page int) string {
+	return section + "-" + strconv.Itoa(page) + ".xml"
+}
+
+// parseFileName splits a sitemap file name into its section and page
+func parseFileName(name string) (string, int, bool) {
+	base, ok := strings.CutSuffix(name, ".xml")
+	if !ok {
+		return "", 0, false
+	}
+	i := strings.LastIndex(base, "-")
+	if i < 0 {
+		return "", 0, false
+	}
+	page, err := strconv.Atoi(base[i+1:])
+	if err != nil || page < 1 {
+		return "", 0, false
+	}
+	return base[:i], page, true
+}
+
+func latest(urls []URL) time.Time {
+	var t time.Time
+	for _, url := range urls {
+		if url.LastMod.After(t) {
+			t = url.LastMod
+		}
+	}
+	return t
+}
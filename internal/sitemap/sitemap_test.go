@@ -0,0 +1,68 @@
+package sitemap
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		page    int
+		ok      bool
+	}{
+		{"vehicles-1.xml", SectionVehicles, 1, true},
+		{"models-12.xml", SectionModels, 12, true},
+		{"vehicles-0.xml", "", 0, false},
+		{"vehicles.xml", "", 0, false},
+		{"brands-1.txt", "", 0, false},
+		{"brands-x.xml", "", 0, false},
+	}
+	for _, tt := range tests {
+		section, page, ok := parseFileName(tt.name)
+		if section != tt.section || page != tt.page || ok != tt.ok {
+			t.Errorf("parseFileName(%q) = %q, %d, %v", tt.name, section, page, ok)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	g := &Generator{pageSize: 2}
+	urls := []URL{{Loc: "a"}, {Loc: "b"}, {Loc: "c"}}
+
+	if got := g.paginate(urls, 2); len(got) != 1 || got[0].Loc != "c" {
+		t.Errorf("page 2 = %v", got)
+	}
+	if got := g.paginate(urls, 3); got != nil {
+		t.Errorf("page 3 = %v, want none", got)
+	}
+}
+
+func TestWriteIndex(t *testing.T) {
+	files := []File{
+		{Name: "vehicles-1.xml", LastMod: time.Date(2026, 3, 4, 9, 0, 0, 0, time.FixedZone("EET", 2*3600))},
+		{Name: "brands-1.xml"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIndex(&buf, "https://autoelys.example/", files); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap>
+    <loc>https://autoelys.example/sitemaps/vehicles-1.xml</loc>
+    <lastmod>2026-03-04T07:00:00Z</lastmod>
+  </sitemap>
+  <sitemap>
+    <loc>https://autoelys.example/sitemaps/brands-1.xml</loc>
+  </sitemap>
+</sitemapindex>
+`
+	if buf.String() != want {
+		t.Errorf("index mismatch\n--- got ---\n%s\n--- want ---\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,65 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+)
+
+// ContentType is the HTTP content type of sitemaps and the sitemap index
+const ContentType = "application/xml; charset=utf-8"
+
+type urlSet struct {
+	XMLName xml.Name   `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []xmlEntry `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name   `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []xmlEntry `xml:"sitemap"`
+}
+
+type xmlEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+func newEntry(loc string, lastMod time.Time) xmlEntry {
+	entry := xmlEntry{Loc: loc}
+	if !lastMod.IsZero() {
+		entry.LastMod = lastMod.UTC().Format(time.RFC3339)
+	}
+	return entry
+}
+
+// WriteURLSet writes a sitemap of the URLs
+func WriteURLSet(w io.Writer, urls []URL) error {
+	set := urlSet{URLs: make([]xmlEntry, 0, len(urls))}
+	for _, url := range urls {
+		set.URLs = append(set.URLs, newEntry(url.Loc, url.LastMod))
+	}
+	return encode(w, set)
+}
+
+// WriteIndex writes the sitemap index of the files, served under baseURL/sitemaps
+func WriteIndex(w io.Writer, baseURL string, files []File) error {
+	index := sitemapIndex{Sitemaps: make([]xmlEntry, 0, len(files))}
+	for _, file := range files {
+		index.Sitemaps = append(index.Sitemaps, newEntry(strings.TrimRight(baseURL, "/")+"/"+Dir+"/"+file.Name, file.LastMod))
+	}
+	return encode(w, index)
+}
+
+func encode(w io.Writer, document interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
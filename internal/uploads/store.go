@@ -0,0 +1,142 @@
+// Package uploads stores the images uploaded in chunks through upload sessions, before a vehicle claims them.
+package uploads
+
+import (
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/utils"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrChunkTooLarge is returned by Stage for chunks going past the declared size of the upload
+var ErrChunkTooLarge = errors.New("chunk exceeds the size of the upload")
+
+// ErrNotImage is returned by Finish for files that are not jpeg, png or webp images
+var ErrNotImage = utils.ErrNotImage
+
+// Store keeps the part files of uploads in progress in a directory that is not served, and moves
+// completed images to the served image directory
+type Store struct {
+	tempDir  string
+	imageDir string
+}
+
+// NewStore creates a store writing part files to tempDir and completed images to imageDir
+func NewStore(tempDir, imageDir string) *Store {
+	return &Store{tempDir: tempDir, imageDir: imageDir}
+}
+
+func (s *Store) partPath(uuid string) string {
+	return filepath.Join(s.tempDir, filepath.Base(uuid)+".part")
+}
+
+// Chunk is a chunk of an upload staged in a file of its own, so that concurrent requests never write to the
+// part file before one of them has been recorded as the next chunk
+type Chunk struct {
+	path string
+	// Size is the number of bytes staged
+	Size int64
+}
+
+// Stage writes the chunk read from r, accepting at most limit bytes, to a file of its own. The bytes
+// written before an error are kept in the returned chunk, so an upload interrupted in the middle of a
+// chunk resumes after them. The chunk is nil only when its file could not be created.
+func (s *Store) Stage(uuid string, r io.Reader, limit int64) (*Chunk, error) {
+	if err := os.MkdirAll(s.tempDir, 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(s.tempDir, filepath.Base(uuid)+".*.chunk")
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := &Chunk{path: file.Name()}
+	chunk.Size, err = io.Copy(file, io.LimitReader(r, limit))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return chunk, err
+	}
+
+	var extra [1]byte
+	if n, _ := r.Read(extra[:]); n > 0 {
+		return chunk, ErrChunkTooLarge
+	}
+	return chunk, nil
+}
+
+// Append writes a staged chunk at offset in the part file of an upload. The chunk is left for the caller to remove.
+// Writing at an offset rather than at the end overwrites what a failed earlier attempt left after it.
+func (s *Store) Append(uuid string, offset int64, chunk *Chunk) error {
+	data, err := os.Open(chunk.path)
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+
+	part, err := os.OpenFile(s.partPath(uuid), os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Seek(offset, io.SeekStart); err != nil {
+		part.Close()
+		return err
+	}
+	_, err = io.Copy(part, data)
+	if closeErr := part.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Remove deletes the file of a chunk, if any
+func (c *Chunk) Remove() error {
+	err := os.Remove(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Finish checks that the first size bytes of the part file of an upload are an image and moves them to
+// the image directory. It returns the URL path of the image and removes the part file, unless it is not
+// an image.
+func (s *Store) Finish(uuid string, size int64) (string, error) {
+	part, err := os.Open(s.partPath(uuid))
+	if err != nil {
+		return "", err
+	}
+	defer part.Close()
+
+	imageURL, err := utils.SaveImage(io.LimitReader(part, size), "upload "+uuid, s.imageDir)
+	if err != nil {
+		return "", err
+	}
+	part.Close()
+	_ = s.Remove(uuid)
+	return imageURL, nil
+}
+
+// Remove deletes the part file of an upload, if any
+func (s *Store) Remove(uuid string) error {
+	err := os.Remove(s.partPath(uuid))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Discard deletes the files of an upload that is no longer stored: its part file, or its image once completed
+func (s *Store) Discard(upload *models.Upload) error {
+	if upload.ImageURL != nil {
+		err := utils.DeleteFile(*upload.ImageURL)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return s.Remove(upload.UUID)
+}
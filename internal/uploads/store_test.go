@@ -0,0 +1,146 @@
+package uploads
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testUUID = "0b7ad5a4-3f5e-4d2b-9c1e-6f8a2b4c7d90"
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 16, 16))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestStore(t *testing.T) *Store {
+	dir := t.TempDir()
+	return NewStore(filepath.Join(dir, "parts"), filepath.Join(dir, "images"))
+}
+
+// write stages a chunk and appends what it staged at offset, as the upload handler does once the chunk is recorded
+func write(t *testing.T, store *Store, offset int64, r io.Reader, limit int64) (int64, error) {
+	t.Helper()
+	chunk, err := store.Stage(testUUID, r, limit)
+	if chunk == nil {
+		return 0, err
+	}
+	if appendErr := store.Append(testUUID, offset, chunk); appendErr != nil {
+		t.Fatal(appendErr)
+	}
+	if removeErr := chunk.Remove(); removeErr != nil {
+		t.Fatal(removeErr)
+	}
+	return chunk.Size, err
+}
+
+func TestWriteAndFinishInChunks(t *testing.T) {
+	store := newTestStore(t)
+	data := testPNG(t)
+	size := int64(len(data))
+
+	half := size / 2
+	if written, err := write(t, store, 0, bytes.NewReader(data[:half]), size); err != nil || written != half {
+		t.Fatalf("first chunk: written = %d, err = %v", written, err)
+	}
+	if written, err := write(t, store, half, bytes.NewReader(data[half:]), size-half); err != nil || written != size-half {
+		t.Fatalf("second chunk: written = %d, err = %v", written, err)
+	}
+
+	imageURL, err := store.Finish(testUUID, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(imageURL, ".png") {
+		t.Errorf("imageURL = %q, want a .png image", imageURL)
+	}
+	if _, err := os.Stat(store.partPath(testUUID)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("part file still exists after Finish: %v", err)
+	}
+	images, err := os.ReadDir(store.imageDir)
+	if err != nil || len(images) != 1 {
+		t.Fatalf("image directory has %d files, err = %v", len(images), err)
+	}
+	saved, err := os.ReadFile(filepath.Join(store.imageDir, images[0].Name()))
+	if err != nil || !bytes.Equal(saved, data) {
+		t.Errorf("saved image differs from the uploaded bytes, err = %v", err)
+	}
+}
+
+func TestWriteKeepsPartialChunkAndRejectsExtraData(t *testing.T) {
+	store := newTestStore(t)
+
+	written, err := write(t, store, 0, strings.NewReader("abcdef"), 4)
+	if !errors.Is(err, ErrChunkTooLarge) {
+		t.Fatalf("err = %v, want %v", err, ErrChunkTooLarge)
+	}
+	if written != 4 {
+		t.Errorf("written = %d, want 4", written)
+	}
+
+	// Resuming at the recorded offset overwrites what followed it
+	if _, err := write(t, store, 2, strings.NewReader("XY"), 2); err != nil {
+		t.Fatal(err)
+	}
+	part, err := os.ReadFile(store.partPath(testUUID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(part) != "abXY" {
+		t.Errorf("part = %q, want %q", part, "abXY")
+	}
+}
+
+func TestFinishRejectsNonImages(t *testing.T) {
+	store := newTestStore(t)
+	data := []byte("#!/bin/sh\necho not an image\n")
+	if _, err := write(t, store, 0, bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Finish(testUUID, int64(len(data))); !errors.Is(err, ErrNotImage) {
+		t.Fatalf("err = %v, want %v", err, ErrNotImage)
+	}
+	if err := store.Remove(testUUID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove(testUUID); err != nil {
+		t.Errorf("removing a missing part file: %v", err)
+	}
+}
+
+func TestRemovedChunkLeavesPartFileUntouched(t *testing.T) {
+	store := newTestStore(t)
+	if _, err := write(t, store, 0, strings.NewReader("ab"), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	// A chunk that lost the race for its offset is removed without being appended
+	chunk, err := store.Stage(testUUID, strings.NewReader("XY"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chunk.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(chunk.path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("chunk file still exists after Remove: %v", err)
+	}
+	part, err := os.ReadFile(store.partPath(testUUID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(part) != "ab" {
+		t.Errorf("part = %q, want %q", part, "ab")
+	}
+}
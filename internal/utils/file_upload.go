@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,6 +27,16 @@ var allowedImageExtensions = map[string]bool{
 	".webp": true,
 }
 
+// ErrNotImage is returned by SaveImage for files that are not jpeg, png or webp images
+var ErrNotImage = errors.New("file is not a jpeg, png or webp image")
+
+// sniffedImageExtensions maps the detected content types accepted as images to their file extension
+var sniffedImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
 // UploadVehicleImages handles uploading multiple vehicle images
 func UploadVehicleImages(files []*multipart.FileHeader, uploadDir string) ([]string, error) {
 	if len(files) > MaxImagesPerVehicle {
@@ -54,32 +67,23 @@ func UploadVehicleImages(files []*multipart.FileHeader, uploadDir string) ([]str
 		if err != nil {
 			return uploadedPaths, fmt.Errorf("failed to open file %s: %v", fileHeader.Filename, err)
 		}
-		defer file.Close()
 
-		// Generate unique filename
-		filename := generateUniqueFilename(ext)
-		filepath := filepath.Join(uploadDir, filename)
-
-		// Create destination file
-		dst, err := os.Create(filepath)
+		relativePath, err := SaveImage(file, fileHeader.Filename, uploadDir)
+		file.Close()
 		if err != nil {
-			return uploadedPaths, fmt.Errorf("failed to create file %s: %v", filename, err)
-		}
-		defer dst.Close()
-
-		// Copy file content
-		if _, err := io.Copy(dst, file); err != nil {
-			return uploadedPaths, fmt.Errorf("failed to save file %s: %v", filename, err)
+			return uploadedPaths, err
 		}
-
-		// Store relative path for database
-		relativePath := fmt.Sprintf("/uploads/vehicles/%s", filename)
 		uploadedPaths = append(uploadedPaths, relativePath)
 	}
 
 	return uploadedPaths, nil
 }
 
+// IsImageFilename reports whether name has one of the image extensions accepted for vehicles
+func IsImageFilename(name string) bool {
+	return allowedImageExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
 // generateUniqueFilename creates a unique filename using UUID and timestamp
 func generateUniqueFilename(extension string) string {
 	timestamp := time.Now().Unix()
@@ -93,3 +97,47 @@ func DeleteFile(filePath string) error {
 	absPath := filepath.Join(".", filePath)
 	return os.Remove(absPath)
 }
+
+// SaveImage stores a single image read from r into uploadDir and returns its relative path, derived from
+// uploadDir (e.g. /uploads/vehicles/<file>). The content must be a jpeg, png or webp image, whatever the
+// extension of name, and is saved with the extension of its detected type. Other files fail with
+// ErrNotImage, and images larger than MaxFileSize are rejected.
+func SaveImage(r io.Reader, name string, uploadDir string) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("failed to read file %s: %v", name, err)
+	}
+	ext, ok := sniffedImageExtensions[http.DetectContentType(head[:n])]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", name, ErrNotImage)
+	}
+
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %v", err)
+	}
+
+	filename := generateUniqueFilename(ext)
+	destination := filepath.Join(uploadDir, filename)
+
+	dst, err := os.Create(destination)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file %s: %v", filename, err)
+	}
+
+	content := io.MultiReader(bytes.NewReader(head[:n]), r)
+	written, err := io.Copy(dst, io.LimitReader(content, MaxFileSize+1))
+	closeErr := dst.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil && written > MaxFileSize {
+		err = fmt.Errorf("file %s exceeds maximum size of 10MB", name)
+	}
+	if err != nil {
+		_ = os.Remove(destination)
+		return "", err
+	}
+
+	return "/" + filepath.ToSlash(filepath.Clean(destination)), nil
+}
@@ -61,6 +61,10 @@ func FormatValidationErrorsSimple(errors validator.ValidationErrors) map[string]
 			errorMessages[field] = field + " must be greater than " + err.Param()
 		case "oneof":
 			errorMessages[field] = field + " must be one of: " + err.Param()
+		case "vin":
+			errorMessages[field] = field + " must be a valid 17 character VIN"
+		case "currency":
+			errorMessages[field] = field + " must be one of: lei, euro, usd"
 		default:
 			errorMessages[field] = field + " is invalid"
 		}
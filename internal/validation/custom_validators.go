@@ -4,6 +4,10 @@ import (
 	"regexp"
 	"unicode"
 
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/patch"
+	"autoelys_backend/internal/vin"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -14,6 +18,13 @@ func RegisterCustomValidators(v *validator.Validate) error {
 	if err := v.RegisterValidation("strong_password", validateStrongPassword); err != nil {
 		return err
 	}
+	if err := v.RegisterValidation("vin", validateVIN); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("currency", validateCurrency); err != nil {
+		return err
+	}
+	patch.RegisterValidation(v)
 	return nil
 }
 
@@ -47,3 +58,21 @@ func validateStrongPassword(fl validator.FieldLevel) bool {
 
 	return hasLetter && hasDigit
 }
+
+// validateVIN checks length, allowed characters and the check digit of a vehicle identification number
+func validateVIN(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true
+	}
+	return vin.IsValid(value)
+}
+
+// validateCurrency checks that a currency is on the supported whitelist (aliases such as EUR are accepted)
+func validateCurrency(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true
+	}
+	return models.IsSupportedCurrency(value)
+}
@@ -0,0 +1,155 @@
+package vin
+
+import (
+	"strings"
+	"time"
+)
+
+// Length is the number of characters in a modern (post-1981) VIN
+const Length = 17
+
+// transliteration maps VIN characters to their numeric value for the check digit calculation.
+// The letters I, O and Q are not allowed in a VIN and are therefore missing.
+var transliteration = map[rune]int{
+	'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+// positionWeights are the ISO 3779 / FMVSS 115 weights for each VIN position
+var positionWeights = [Length]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// yearCodes maps the 10th VIN character to the first model year of its 30 year cycle
+var yearCodes = map[byte]int{
+	'A': 1980, 'B': 1981, 'C': 1982, 'D': 1983, 'E': 1984, 'F': 1985, 'G': 1986, 'H': 1987,
+	'J': 1988, 'K': 1989, 'L': 1990, 'M': 1991, 'N': 1992, 'P': 1993, 'R': 1994, 'S': 1995,
+	'T': 1996, 'V': 1997, 'W': 1998, 'X': 1999, 'Y': 2000,
+	'1': 2001, '2': 2002, '3': 2003, '4': 2004, '5': 2005, '6': 2006, '7': 2007, '8': 2008, '9': 2009,
+}
+
+// Decoded holds the information that can be extracted from a VIN without any external service
+type Decoded struct {
+	VIN          string `json:"vin"`
+	WMI          string `json:"wmi"`
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Brand        string `json:"brand,omitempty"`
+	Region       string `json:"region,omitempty"`
+	Country      string `json:"country,omitempty"`
+	ModelYear    int    `json:"model_year,omitempty"`
+	CheckDigit   bool   `json:"check_digit_verified"`
+}
+
+// Normalize upper-cases a VIN and strips surrounding whitespace
+func Normalize(value string) string {
+	return strings.ToUpper(strings.TrimSpace(value))
+}
+
+// HasValidCharacters reports whether the VIN is 17 characters long and uses only allowed characters
+func HasValidCharacters(value string) bool {
+	if len(value) != Length {
+		return false
+	}
+	for _, char := range value {
+		if _, ok := transliteration[char]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckDigit calculates the expected check digit (9th character) of a VIN
+func CheckDigit(value string) byte {
+	sum := 0
+	for i, char := range value {
+		sum += transliteration[char] * positionWeights[i]
+	}
+	remainder := sum % 11
+	if remainder == 10 {
+		return 'X'
+	}
+	return byte('0' + remainder)
+}
+
+// RequiresCheckDigit reports whether the VIN comes from a region where the check digit is mandatory.
+// North America (1-5) and China (L) enforce it; European manufacturers often use the 9th position freely.
+func RequiresCheckDigit(value string) bool {
+	if value == "" {
+		return false
+	}
+	switch value[0] {
+	case '1', '2', '3', '4', '5', 'L':
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid validates length, allowed characters and, where mandatory, the check digit
+func IsValid(value string) bool {
+	value = Normalize(value)
+	if !HasValidCharacters(value) {
+		return false
+	}
+	if RequiresCheckDigit(value) {
+		return value[8] == CheckDigit(value)
+	}
+	return true
+}
+
+// Decode extracts manufacturer, origin and model year from a VIN using the bundled WMI table.
+// The VIN is expected to be valid; unknown WMIs simply leave the manufacturer fields empty.
+func Decode(value string) *Decoded {
+	value = Normalize(value)
+	decoded := &Decoded{
+		VIN: value,
+	}
+	if len(value) != Length {
+		return decoded
+	}
+
+	decoded.WMI = value[:3]
+	decoded.CheckDigit = value[8] == CheckDigit(value)
+	decoded.Region, decoded.Country = regionAndCountry(value[0], value[1])
+
+	if entry, ok := lookupWMI(decoded.WMI); ok {
+		decoded.Manufacturer = entry.Manufacturer
+		decoded.Brand = entry.Brand
+	}
+
+	decoded.ModelYear = modelYear(value)
+
+	return decoded
+}
+
+// modelYear resolves the 30 year ambiguity of the 10th character.
+// For North American VINs a letter in position 7 means 2010 or later; for the rest of the
+// world we pick the most recent cycle that is not more than one year in the future.
+func modelYear(value string) int {
+	base, ok := yearCodes[value[9]]
+	if !ok {
+		return 0
+	}
+
+	if RequiresCheckDigit(value) {
+		if value[6] >= 'A' && value[6] <= 'Z' {
+			return base + 30
+		}
+		return base
+	}
+
+	year := base
+	maxYear := time.Now().Year() + 1
+	for year+30 <= maxYear {
+		year += 30
+	}
+	return year
+}
+
+// Mask hides the serial number section of a VIN, keeping the manufacturer and vehicle descriptor visible
+func Mask(value string) string {
+	if len(value) <= 11 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:11] + strings.Repeat("*", len(value)-11)
+}
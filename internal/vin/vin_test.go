@@ -0,0 +1,89 @@
+package vin
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize("  1hgcm82633a004352\n"); got != "1HGCM82633A004352" {
+		t.Errorf("Normalize = %q, want %q", got, "1HGCM82633A004352")
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		vin  string
+		want byte
+	}{
+		{"1M8GDM9AXKP042788", 'X'},
+		{"1HGCM82633A004352", '3'},
+		{"11111111111111111", '1'},
+		{"5YJSA1E27HF000001", '7'},
+	}
+	for _, tt := range tests {
+		if got := CheckDigit(tt.vin); got != tt.want {
+			t.Errorf("CheckDigit(%s) = %c, want %c", tt.vin, got, tt.want)
+		}
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		vin  string
+		want bool
+	}{
+		{"1M8GDM9AXKP042788", true},
+		{" 1hgcm82633a004352 ", true},
+		{"LVSHCAMB3CE000001", true},
+		// North American and Chinese VINs must carry the right check digit
+		{"1HGCM82643A004352", false},
+		{"LVSHCAMB0CE000001", false},
+		// European manufacturers use the 9th position freely
+		{"WVWZZZ1JZYW000001", true},
+		{"UU1LSDL4H50000001", true},
+		{"1HGCM82633A00435", false},
+		{"1HGCM82633A0043521", false},
+		{"WVWZZZ1JZYW00000I", false},
+		{"WVWZZZ1JZYW00000O", false},
+		{"WVWZZZ1JZYW00000Q", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsValid(tt.vin); got != tt.want {
+			t.Errorf("IsValid(%q) = %v, want %v", tt.vin, got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		vin        string
+		brand      string
+		modelYear  int
+		checkDigit bool
+	}{
+		// North America: a digit in position 7 means the 1980-2009 cycle, a letter the 2010-2039 one
+		{"1HGCM82633A004352", "Honda", 2003, true},
+		{"1M8GDM9AXKP042788", "", 1989, true},
+		{"5YJSA1E27HF000001", "Tesla", 2017, true},
+		// Elsewhere the most recent cycle not more than a year ahead is used
+		{"WBA3A5C50DF000001", "BMW", 2013, false},
+		{"UU1LSDL4H50000001", "Dacia", 2005, false},
+		{"WVWZZZ1JZYW000001", "Volkswagen", 2000, false},
+	}
+	for _, tt := range tests {
+		decoded := Decode(tt.vin)
+		if decoded.Brand != tt.brand || decoded.ModelYear != tt.modelYear || decoded.CheckDigit != tt.checkDigit {
+			t.Errorf("Decode(%s) = brand %q, model year %d, check digit %v; want %q, %d, %v",
+				tt.vin, decoded.Brand, decoded.ModelYear, decoded.CheckDigit, tt.brand, tt.modelYear, tt.checkDigit)
+		}
+	}
+
+	if decoded := Decode("WVWZZZ"); decoded.WMI != "" || decoded.ModelYear != 0 {
+		t.Errorf("Decode of a short VIN = %+v, want only the VIN", decoded)
+	}
+}
+
+func TestMask(t *testing.T) {
+	if got := Mask("WVWZZZ1JZYW000001"); got != "WVWZZZ1JZYW******" {
+		t.Errorf("Mask = %q", got)
+	}
+}
@@ -0,0 +1,220 @@
+package vin
+
+// wmiEntry describes a World Manufacturer Identifier from the bundled table
+type wmiEntry struct {
+	Manufacturer string
+	Brand        string
+}
+
+// wmiTable is an offline subset of the SAE World Manufacturer Identifier registry covering
+// the brands most commonly listed on the marketplace. Keys are the first three VIN characters.
+var wmiTable = map[string]wmiEntry{
+	// Germany
+	"WAU": {"Audi AG", "Audi"},
+	"WA1": {"Audi AG (SUV)", "Audi"},
+	"WUA": {"quattro GmbH", "Audi"},
+	"TRU": {"Audi Hungaria", "Audi"},
+	"WBA": {"BMW AG", "BMW"},
+	"WBS": {"BMW M GmbH", "BMW"},
+	"WBX": {"BMW AG (SUV)", "BMW"},
+	"WBY": {"BMW AG (i models)", "BMW"},
+	"WMW": {"BMW AG", "Mini"},
+	"WDB": {"Mercedes-Benz AG", "Mercedes-Benz"},
+	"WDC": {"Mercedes-Benz AG (SUV)", "Mercedes-Benz"},
+	"WDD": {"Mercedes-Benz AG", "Mercedes-Benz"},
+	"WDF": {"Mercedes-Benz AG (vans)", "Mercedes-Benz"},
+	"W1K": {"Mercedes-Benz AG", "Mercedes-Benz"},
+	"W1N": {"Mercedes-Benz AG (SUV)", "Mercedes-Benz"},
+	"W1V": {"Mercedes-Benz AG (vans)", "Mercedes-Benz"},
+	"WME": {"Mercedes-Benz AG", "Smart"},
+	"WF0": {"Ford-Werke GmbH", "Ford"},
+	"WF1": {"Ford-Werke GmbH", "Ford"},
+	"W0L": {"Opel Automobile GmbH", "Opel"},
+	"W0V": {"Opel Automobile GmbH", "Opel"},
+	"WP0": {"Porsche AG", "Porsche"},
+	"WP1": {"Porsche AG (SUV)", "Porsche"},
+	"WVW": {"Volkswagen AG", "Volkswagen"},
+	"WVG": {"Volkswagen AG (SUV)", "Volkswagen"},
+	"WV1": {"Volkswagen Commercial Vehicles", "Volkswagen"},
+	"WV2": {"Volkswagen Commercial Vehicles", "Volkswagen"},
+	"1VW": {"Volkswagen of America", "Volkswagen"},
+	"3VW": {"Volkswagen de Mexico", "Volkswagen"},
+
+	// Central and Eastern Europe
+	"TMB": {"Skoda Auto", "Skoda"},
+	"TMA": {"Hyundai Motor Manufacturing Czech", "Hyundai"},
+	"UU1": {"Automobile Dacia", "Dacia"},
+	"UU6": {"Automobile Dacia", "Dacia"},
+	"VSS": {"SEAT S.A.", "SEAT"},
+	"VSK": {"Nissan Motor Iberica", "Nissan"},
+	"VSE": {"Suzuki Motor Iberica", "Suzuki"},
+	"VWV": {"Volkswagen Navarra", "Volkswagen"},
+	"SUP": {"FSO Daewoo", "Daewoo"},
+	"XTA": {"AvtoVAZ", "Lada"},
+	"U5Y": {"Kia Slovakia", "Kia"},
+	"TSM": {"Suzuki Hungary", "Suzuki"},
+
+	// France, Italy, Spain
+	"VF1": {"Renault S.A.", "Renault"},
+	"VF3": {"Peugeot S.A.", "Peugeot"},
+	"VF7": {"Citroen", "Citroen"},
+	"VR1": {"DS Automobiles", "DS Automobiles"},
+	"VR3": {"Peugeot S.A.", "Peugeot"},
+	"VR7": {"Citroen", "Citroen"},
+	"VNK": {"Toyota Motor Manufacturing France", "Toyota"},
+	"ZFA": {"Fiat Auto S.p.A.", "Fiat"},
+	"ZFF": {"Ferrari S.p.A.", "Ferrari"},
+	"ZAR": {"Alfa Romeo", "Alfa Romeo"},
+	"ZLA": {"Lancia", "Lancia"},
+	"ZHW": {"Automobili Lamborghini", "Lamborghini"},
+	"ZAM": {"Maserati", "Maserati"},
+	"ZCF": {"Iveco", "Iveco"},
+
+	// United Kingdom and Sweden
+	"SAJ": {"Jaguar Cars", "Jaguar"},
+	"SAL": {"Land Rover", "Land Rover"},
+	"SAR": {"Rover", "Rover"},
+	"SCC": {"Lotus Cars", "Lotus"},
+	"SCB": {"Bentley Motors", "Bentley"},
+	"SCA": {"Rolls-Royce Motor Cars", "Rolls-Royce"},
+	"SCF": {"Aston Martin Lagonda", "Aston Martin"},
+	"SBM": {"McLaren Automotive", "McLaren"},
+	"SJN": {"Nissan Motor Manufacturing UK", "Nissan"},
+	"SHH": {"Honda of the UK", "Honda"},
+	"SHS": {"Honda of the UK (SUV)", "Honda"},
+	"SB1": {"Toyota Motor Manufacturing UK", "Toyota"},
+	"YV1": {"Volvo Cars", "Volvo"},
+	"YV4": {"Volvo Cars (SUV)", "Volvo"},
+	"YS3": {"Saab Automobile", "Saab"},
+	"LVY": {"Volvo Cars China", "Volvo"},
+
+	// Japan
+	"JHM": {"Honda Motor Co.", "Honda"},
+	"JHL": {"Honda Motor Co. (SUV)", "Honda"},
+	"JH4": {"Honda Motor Co.", "Acura"},
+	"JMZ": {"Mazda Motor Corporation", "Mazda"},
+	"JM1": {"Mazda Motor Corporation", "Mazda"},
+	"JN1": {"Nissan Motor Co.", "Nissan"},
+	"JN8": {"Nissan Motor Co. (SUV)", "Nissan"},
+	"JNK": {"Nissan Motor Co.", "Infiniti"},
+	"JF1": {"Subaru Corporation", "Subaru"},
+	"JF2": {"Subaru Corporation (SUV)", "Subaru"},
+	"JS2": {"Suzuki Motor Corporation", "Suzuki"},
+	"JSA": {"Suzuki Motor Corporation", "Suzuki"},
+	"JT2": {"Toyota Motor Corporation", "Toyota"},
+	"JTD": {"Toyota Motor Corporation", "Toyota"},
+	"JTE": {"Toyota Motor Corporation (SUV)", "Toyota"},
+	"JTM": {"Toyota Motor Corporation (SUV)", "Toyota"},
+	"JTN": {"Toyota Motor Corporation", "Toyota"},
+	"JTH": {"Toyota Motor Corporation", "Lexus"},
+	"JTJ": {"Toyota Motor Corporation (SUV)", "Lexus"},
+	"JMB": {"Mitsubishi Motors", "Mitsubishi"},
+	"JA3": {"Mitsubishi Motors", "Mitsubishi"},
+	"JA4": {"Mitsubishi Motors (SUV)", "Mitsubishi"},
+	"JDA": {"Daihatsu Motor", "Daihatsu"},
+
+	// Korea
+	"KMH": {"Hyundai Motor Company", "Hyundai"},
+	"KM8": {"Hyundai Motor Company (SUV)", "Hyundai"},
+	"KMT": {"Genesis Motor", "Genesis"},
+	"KNA": {"Kia Corporation", "Kia"},
+	"KND": {"Kia Corporation (SUV)", "Kia"},
+	"KPT": {"SsangYong Motor", "SsangYong"},
+	"KL1": {"GM Korea", "Chevrolet"},
+
+	// North America
+	"1FA": {"Ford Motor Company", "Ford"},
+	"1FM": {"Ford Motor Company (SUV)", "Ford"},
+	"1FT": {"Ford Motor Company (truck)", "Ford"},
+	"1G1": {"General Motors", "Chevrolet"},
+	"1GC": {"General Motors (truck)", "Chevrolet"},
+	"1GN": {"General Motors (SUV)", "Chevrolet"},
+	"1GT": {"General Motors (truck)", "GMC"},
+	"1G6": {"General Motors", "Cadillac"},
+	"1GY": {"General Motors (SUV)", "Cadillac"},
+	"1C3": {"FCA US", "Chrysler"},
+	"1C4": {"FCA US (SUV)", "Jeep"},
+	"1J4": {"FCA US (SUV)", "Jeep"},
+	"1C6": {"FCA US (truck)", "RAM"},
+	"2C3": {"FCA Canada", "Dodge"},
+	"1HG": {"Honda of America", "Honda"},
+	"1N4": {"Nissan North America", "Nissan"},
+	"4T1": {"Toyota Motor Manufacturing Kentucky", "Toyota"},
+	"5YJ": {"Tesla Inc.", "Tesla"},
+	"7SA": {"Tesla Inc.", "Tesla"},
+	"5UX": {"BMW Manufacturing (SUV)", "BMW"},
+	"4JG": {"Mercedes-Benz U.S. International", "Mercedes-Benz"},
+	"5NP": {"Hyundai Motor Manufacturing Alabama", "Hyundai"},
+	"5XY": {"Kia Georgia", "Kia"},
+	"4S4": {"Subaru of Indiana", "Subaru"},
+	"7FA": {"Rivian Automotive", "Rivian"},
+
+	// China
+	"LRW": {"Tesla Shanghai", "Tesla"},
+	"LFV": {"FAW-Volkswagen", "Volkswagen"},
+	"LSV": {"SAIC Volkswagen", "Volkswagen"},
+	"LBV": {"BMW Brilliance", "BMW"},
+	"LE4": {"Beijing Benz", "Mercedes-Benz"},
+	"LPS": {"Polestar", "Polestar"},
+	"LGX": {"BYD Auto", "BYD"},
+	"LSJ": {"SAIC MG", "MG"},
+	"L6T": {"Geely", "Geely"},
+}
+
+// lookupWMI finds the manufacturer for a WMI
+func lookupWMI(wmi string) (wmiEntry, bool) {
+	entry, ok := wmiTable[wmi]
+	return entry, ok
+}
+
+// countryRange maps a range of the second VIN character to a country for a given first character
+type countryRange struct {
+	from, to byte
+	country  string
+}
+
+var countryRanges = map[byte][]countryRange{
+	'J': {{'A', 'Z', "Japan"}, {'0', '9', "Japan"}},
+	'K': {{'L', 'R', "South Korea"}},
+	'L': {{'A', 'Z', "China"}, {'0', '9', "China"}},
+	'S': {{'A', 'M', "United Kingdom"}, {'N', 'T', "Germany"}, {'U', 'Z', "Poland"}},
+	'T': {{'A', 'H', "Switzerland"}, {'J', 'P', "Czech Republic"}, {'R', 'V', "Hungary"}, {'W', 'Z', "Portugal"}, {'1', '1', "Portugal"}},
+	'U': {{'H', 'M', "Denmark"}, {'N', 'T', "Ireland"}, {'U', 'Z', "Romania"}, {'5', '7', "Slovakia"}},
+	'V': {{'A', 'E', "Austria"}, {'F', 'R', "France"}, {'S', 'W', "Spain"}, {'X', 'Z', "Serbia"}, {'1', '2', "Serbia"}},
+	'W': {{'A', 'Z', "Germany"}, {'0', '9', "Germany"}},
+	'X': {{'L', 'R', "Netherlands"}, {'S', 'W', "Russia"}, {'3', '9', "Russia"}},
+	'Y': {{'A', 'E', "Belgium"}, {'F', 'K', "Finland"}, {'S', 'W', "Sweden"}},
+	'Z': {{'A', 'R', "Italy"}},
+	'1': {{'A', 'Z', "United States"}, {'0', '9', "United States"}},
+	'2': {{'A', 'Z', "Canada"}, {'0', '9', "Canada"}},
+	'3': {{'A', 'W', "Mexico"}},
+	'4': {{'A', 'Z', "United States"}, {'0', '9', "United States"}},
+	'5': {{'A', 'Z', "United States"}, {'0', '9', "United States"}},
+	'7': {{'A', 'Z', "United States"}},
+}
+
+// regionAndCountry derives the continent and country of manufacture from the first two VIN characters
+func regionAndCountry(first, second byte) (string, string) {
+	region := ""
+	switch {
+	case first >= 'A' && first <= 'H':
+		region = "Africa"
+	case first >= 'J' && first <= 'R':
+		region = "Asia"
+	case first >= 'S' && first <= 'Z':
+		region = "Europe"
+	case first >= '1' && first <= '5', first == '7':
+		region = "North America"
+	case first == '6':
+		region = "Oceania"
+	case first == '8' || first == '9':
+		region = "South America"
+	}
+
+	for _, r := range countryRanges[first] {
+		if second >= r.from && second <= r.to {
+			return region, r.country
+		}
+	}
+	return region, ""
+}
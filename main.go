@@ -2,14 +2,34 @@ package main
 
 import (
 	"autoelys_backend/database"
+	"autoelys_backend/internal/cache"
+	"autoelys_backend/internal/catalog"
+	"autoelys_backend/internal/currency"
+	"autoelys_backend/internal/feeds"
 	"autoelys_backend/internal/handlers"
+	"autoelys_backend/internal/jobs"
 	"autoelys_backend/internal/middleware"
+	"autoelys_backend/internal/models"
+	"autoelys_backend/internal/payments"
+	"autoelys_backend/internal/recommend"
 	"autoelys_backend/internal/repository"
 	"autoelys_backend/internal/services"
+	"autoelys_backend/internal/sitemap"
+	"autoelys_backend/internal/uploads"
+	"autoelys_backend/internal/utils"
 	"autoelys_backend/internal/validation"
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -69,23 +89,105 @@ func main() {
 	automobileRepo := repository.NewAutomobileRepository(db)
 	vehicleRepo := repository.NewVehicleRepository(db)
 	serviceRepo := repository.NewServiceRepository(db)
+	exchangeRateRepo := repository.NewExchangeRateRepository(db)
+	comparisonRepo := repository.NewComparisonRepository(db)
+	organizationRepo := repository.NewOrganizationRepository(db)
+	equipmentRepo := repository.NewEquipmentRepository(db)
+	vehicleAuditRepo := repository.NewVehicleAuditRepository(db)
+	serviceOrderRepo := repository.NewServiceOrderRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	viewingRequestRepo := repository.NewViewingRequestRepository(db)
+	uploadRepo := repository.NewUploadRepository(db)
+	transactor := repository.NewTransactor(db)
+	uploadStore := newUploadStore()
+
+	// Hot reference data and the recommended vehicles are cached in process
+	cacheStore := cache.NewStore(cache.NewMemory())
+	brandRepo.SetCache(cacheStore)
+	vehicleRepo.SetCache(cacheStore)
+	serviceOrderRepo.SetCache(cacheStore)
+	paymentRepo.SetCache(cacheStore)
+
 	emailService := services.NewEmailService()
+	location := bookingLocation()
 	authHandler := handlers.NewAuthHandler(userRepo, passwordRepo, emailService, validate)
 	brandHandler := handlers.NewBrandHandler(brandRepo, automobileRepo)
-	vehicleHandler := handlers.NewVehicleHandler(vehicleRepo, validate)
+	vehicleHandler := handlers.NewVehicleHandler(vehicleRepo, brandRepo, automobileRepo, exchangeRateRepo, organizationRepo, equipmentRepo, vehicleAuditRepo, uploadRepo, transactor, feedOptions(), validate)
 	adminHandler := handlers.NewAdminHandler(userRepo)
 	serviceHandler := handlers.NewServiceHandler(serviceRepo)
+	exchangeRateHandler := handlers.NewExchangeRateHandler(exchangeRateRepo)
+	comparisonHandler := handlers.NewComparisonHandler(vehicleRepo, comparisonRepo, exchangeRateRepo)
+	feedHandler := handlers.NewFeedHandler(vehicleRepo, feedOptions())
+	organizationHandler := handlers.NewOrganizationHandler(organizationRepo, userRepo, vehicleRepo, exchangeRateRepo)
+	equipmentHandler := handlers.NewEquipmentHandler(equipmentRepo)
+	serviceOrderHandler := handlers.NewServiceOrderHandler(serviceOrderRepo, serviceRepo, vehicleRepo, organizationRepo)
+	paymentHandler := handlers.NewPaymentHandler(paymentRepo, serviceOrderRepo, paymentProvider())
+	bookingHandler := handlers.NewBookingHandler(bookingRepo, serviceRepo, emailService, location)
+	viewingRequestHandler := handlers.NewViewingRequestHandler(viewingRequestRepo, vehicleRepo, organizationRepo, emailService, location)
+	uploadHandler := handlers.NewUploadHandler(uploadRepo, uploadStore, uploadTTL())
+	sitemapHandler := handlers.NewSitemapHandler(newSitemapGenerator(db), sitemapURL(), sitemapCacheTTL())
 
 	rateLimiter := middleware.NewRateLimiter(10, 5)
 
+	// Listings priced in a currency without a rate have no normalized price and drop out of price filters
+	// until the rates are loaded, through rates:load or PUT /api/admin/exchange-rates
+	if err := checkExchangeRates(exchangeRateRepo); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+
+	// Background jobs
+	refreshInterval := time.Hour
+	if value := os.Getenv("RECOMMENDATION_REFRESH_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			log.Fatalf("Invalid RECOMMENDATION_REFRESH_INTERVAL: %v", err)
+		}
+		refreshInterval = interval
+	}
+	if refreshInterval > 0 {
+		stopRefresh := jobs.Every("recommendations:refresh", refreshInterval, func() error {
+			_, err := recommend.RefreshScores(vehicleRepo, time.Now())
+			return err
+		})
+		defer stopRefresh()
+	}
+
+	if ttl := draftTTL(); ttl > 0 {
+		stopCleanup := jobs.Every("drafts:cleanup", draftCleanupInterval, func() error {
+			_, err := cleanupDrafts(vehicleRepo, ttl)
+			return err
+		})
+		defer stopCleanup()
+	}
+
+	stopViewFlush := jobs.Every("vehicles:flush-views", viewFlushInterval, func() error {
+		_, err := vehicleRepo.FlushViewCounts()
+		return err
+	})
+	defer stopViewFlush()
+
+	stopUploadCleanup := jobs.Every("uploads:cleanup", uploadCleanupInterval, func() error {
+		_, err := cleanupUploads(uploadRepo, uploadStore)
+		return err
+	})
+	defer stopUploadCleanup()
+
 	router := gin.Default()
 
+	// The client IP used by the rate limiter and recorded in audit logs comes from X-Forwarded-For only when
+	// the request came through a trusted proxy. Deployments behind a reverse proxy must list it in TRUSTED_PROXIES.
+	if err := router.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	router.Use(middleware.WarnUntrustedForwarding())
+
 	// CORS configuration
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-None-Match", "If-Match", "Upload-Offset"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Upload-Offset"},
 		AllowCredentials: true,
 	}))
 
@@ -99,6 +201,10 @@ func main() {
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// XML sitemaps of the public pages for search engines
+	router.GET("/"+sitemap.IndexFile, sitemapHandler.GetIndex)
+	router.GET("/"+sitemap.Dir+"/:file", sitemapHandler.GetSitemap)
+
 	api := router.Group("/api")
 	{
 		auth := api.Group("/auth")
@@ -113,15 +219,21 @@ func main() {
 
 		brands := api.Group("/brands")
 		{
-			brands.GET("", brandHandler.GetAllBrands)
+			brands.GET("", middleware.HTTPCache(time.Hour), brandHandler.GetAllBrands)
 			brands.GET("/:id/automobiles", brandHandler.GetAutomobilesByBrand)
 		}
 
 		vehicles := api.Group("/vehicles")
 		{
 			vehicles.GET("", vehicleHandler.GetAllVehicles)
-			vehicles.GET("/recommended", vehicleHandler.GetRecommendedVehicles)
-			vehicles.GET("/:slug", vehicleHandler.GetVehicle)
+			vehicles.GET("/recommended", middleware.HTTPCache(5*time.Minute), vehicleHandler.GetRecommendedVehicles)
+			vehicles.GET("/compare", comparisonHandler.CompareVehicles)
+			vehicles.GET("/feed.rss", middleware.HTTPCache(15*time.Minute), vehicleHandler.GetVehiclesRSS)
+			vehicles.GET("/feed.atom", middleware.HTTPCache(15*time.Minute), vehicleHandler.GetVehiclesAtom)
+			vehicles.GET("/:slug", middleware.HTTPCache(time.Minute), vehicleHandler.GetVehicle)
+			vehicles.GET("/:slug/similar", vehicleHandler.GetSimilarVehicles)
+			vehicles.GET("/:slug/seo", vehicleHandler.GetVehicleSEO)
+			vehicles.POST("/:slug/viewing-requests", middleware.AuthRequired(), viewingRequestHandler.RequestViewing)
 		}
 
 		userVehicles := api.Group("/user/vehicles")
@@ -129,26 +241,144 @@ func main() {
 		{
 			userVehicles.GET("", vehicleHandler.GetUserVehicles)
 			userVehicles.POST("", vehicleHandler.CreateVehicle)
+			userVehicles.POST("/import", vehicleHandler.ImportVehicles)
+			userVehicles.GET("/drafts", vehicleHandler.GetDrafts)
+			userVehicles.POST("/drafts", vehicleHandler.CreateDraft)
+			userVehicles.GET("/drafts/:uuid", vehicleHandler.GetDraft)
+			userVehicles.PUT("/drafts/:uuid", vehicleHandler.UpdateDraft)
+			userVehicles.DELETE("/drafts/:uuid", vehicleHandler.DeleteDraft)
+			userVehicles.POST("/drafts/:uuid/images", vehicleHandler.UploadDraftImages)
+			userVehicles.DELETE("/drafts/:uuid/images/:image_id", vehicleHandler.DeleteDraftImage)
+			userVehicles.POST("/drafts/:uuid/publish", vehicleHandler.PublishDraft)
 			userVehicles.GET("/:uuid", vehicleHandler.GetVehicleByUUID)
 			userVehicles.PUT("/:uuid", vehicleHandler.UpdateVehicle)
+			userVehicles.PATCH("/:uuid", vehicleHandler.PatchVehicle)
+			userVehicles.PUT("/:uuid/status", vehicleHandler.UpdateVehicleStatus)
+			userVehicles.GET("/:uuid/history", vehicleHandler.GetVehicleHistory)
+			userVehicles.POST("/:uuid/promotions", serviceOrderHandler.OrderPromotion)
+		}
+		userUploads := api.Group("/user/uploads")
+		userUploads.Use(middleware.AuthRequired())
+		{
+			userUploads.POST("", uploadHandler.CreateUpload)
+			userUploads.GET("/:uuid", uploadHandler.GetUpload)
+			userUploads.PATCH("/:uuid", uploadHandler.UploadChunk)
+			userUploads.DELETE("/:uuid", uploadHandler.DeleteUpload)
+		}
+		api.GET("/user/service-orders", middleware.AuthRequired(), serviceOrderHandler.GetUserServiceOrders)
+		api.POST("/user/service-orders/:uuid/payments", middleware.AuthRequired(), paymentHandler.ProviderRequired(), paymentHandler.CreatePayment)
+
+		userPayments := api.Group("/user/payments")
+		userPayments.Use(middleware.AuthRequired())
+		{
+			userPayments.GET("/:uuid", paymentHandler.GetPayment)
+			userPayments.POST("/:uuid/confirm", paymentHandler.ProviderRequired(), paymentHandler.ConfirmPayment)
+		}
+
+		userBookings := api.Group("/user/bookings")
+		userBookings.Use(middleware.AuthRequired())
+		{
+			userBookings.GET("", bookingHandler.GetUserBookings)
+			userBookings.POST("", bookingHandler.CreateBooking)
+			userBookings.GET("/:uuid", bookingHandler.GetBooking)
+			userBookings.PUT("/:uuid", bookingHandler.RescheduleBooking)
+			userBookings.DELETE("/:uuid", bookingHandler.CancelBooking)
+		}
+
+		viewingRequests := api.Group("/user/viewing-requests")
+		viewingRequests.Use(middleware.AuthRequired())
+		{
+			viewingRequests.GET("", viewingRequestHandler.GetViewingInbox)
+			viewingRequests.GET("/sent", viewingRequestHandler.GetSentViewingRequests)
+			viewingRequests.GET("/:uuid", viewingRequestHandler.GetViewingRequest)
+			viewingRequests.POST("/:uuid/accept", viewingRequestHandler.AcceptViewingRequest)
+			viewingRequests.POST("/:uuid/counter", viewingRequestHandler.CounterViewingRequest)
+			viewingRequests.POST("/:uuid/decline", viewingRequestHandler.DeclineViewingRequest)
+			viewingRequests.POST("/:uuid/cancel", viewingRequestHandler.CancelViewingRequest)
+			viewingRequests.GET("/:uuid/calendar.ics", viewingRequestHandler.GetViewingCalendar)
+		}
+
+		// Signed payment provider notifications
+		api.POST("/payments/webhooks/:provider", paymentHandler.ProviderRequired(), paymentHandler.HandleWebhook)
+
+		userComparisons := api.Group("/user/comparisons")
+		userComparisons.Use(middleware.AuthRequired())
+		{
+			userComparisons.GET("", comparisonHandler.GetComparison)
+			userComparisons.POST("", comparisonHandler.AddToComparison)
+			userComparisons.DELETE("", comparisonHandler.ClearComparison)
+			userComparisons.DELETE("/:slug", comparisonHandler.RemoveFromComparison)
+		}
+
+		organizations := api.Group("/organizations")
+		organizations.Use(middleware.AuthRequired())
+		{
+			organizations.POST("", organizationHandler.CreateOrganization)
+			organizations.GET("/:uuid", organizationHandler.GetOrganization)
+			organizations.PUT("/:uuid", organizationHandler.UpdateOrganization)
+			organizations.DELETE("/:uuid", organizationHandler.DeleteOrganization)
+			organizations.POST("/:uuid/logo", organizationHandler.UploadOrganizationLogo)
+			organizations.GET("/:uuid/members", organizationHandler.GetOrganizationMembers)
+			organizations.POST("/:uuid/members", organizationHandler.AddOrganizationMember)
+			organizations.PUT("/:uuid/members/:user_uuid", organizationHandler.UpdateOrganizationMember)
+			organizations.DELETE("/:uuid/members/:user_uuid", organizationHandler.RemoveOrganizationMember)
+			organizations.GET("/:uuid/vehicles", organizationHandler.GetOrganizationVehicles)
 		}
+		api.GET("/user/organizations", middleware.AuthRequired(), organizationHandler.GetUserOrganizations)
+
+		// Public dealer storefront
+		api.GET("/dealers/:slug", organizationHandler.GetDealer)
 
 		admin := api.Group("/admin")
 		admin.Use(middleware.AuthRequired(), middleware.AdminRequired())
 		{
 			admin.GET("/users", adminHandler.GetAllUsers)
+			admin.GET("/users/:uuid", adminHandler.GetUser)
 			admin.PUT("/users/:uuid", adminHandler.UpdateUser)
 			admin.DELETE("/users/:uuid", adminHandler.DeleteUser)
 
+			admin.GET("/vehicle-audit-logs", vehicleHandler.GetVehicleAuditLogs)
+
 			admin.GET("/services", serviceHandler.GetAllServices)
 			admin.POST("/services", serviceHandler.CreateService)
 			admin.GET("/services/:uuid", serviceHandler.GetService)
 			admin.PUT("/services/:uuid", serviceHandler.UpdateService)
 			admin.DELETE("/services/:uuid", serviceHandler.DeleteService)
+
+			admin.GET("/service-orders", serviceOrderHandler.GetAllServiceOrders)
+			admin.POST("/service-orders/:uuid/activate", serviceOrderHandler.ActivateServiceOrder)
+			admin.POST("/service-orders/:uuid/cancel", serviceOrderHandler.CancelServiceOrder)
+			admin.POST("/payments/:uuid/refund", paymentHandler.ProviderRequired(), paymentHandler.RefundPayment)
+
+			admin.GET("/bookings", bookingHandler.GetAllBookings)
+			admin.GET("/booking-hours", bookingHandler.GetBookingHours)
+			admin.PUT("/booking-hours", bookingHandler.UpdateBookingHours)
+			admin.GET("/booking-exceptions", bookingHandler.GetBookingExceptions)
+			admin.POST("/booking-exceptions", bookingHandler.CreateBookingException)
+			admin.DELETE("/booking-exceptions/:id", bookingHandler.DeleteBookingException)
+
+			admin.PUT("/exchange-rates", exchangeRateHandler.UpdateExchangeRates)
+
+			admin.POST("/equipment-categories", equipmentHandler.CreateEquipmentCategory)
+			admin.PUT("/equipment-categories/:id", equipmentHandler.UpdateEquipmentCategory)
+			admin.DELETE("/equipment-categories/:id", equipmentHandler.DeleteEquipmentCategory)
+			admin.POST("/equipment", equipmentHandler.CreateEquipment)
+			admin.PUT("/equipment/:id", equipmentHandler.UpdateEquipment)
+			admin.DELETE("/equipment/:id", equipmentHandler.DeleteEquipment)
 		}
 
 		// Public services endpoint
-		api.GET("/services", serviceHandler.GetPublicServices)
+		api.GET("/services", middleware.HTTPCache(10*time.Minute), serviceHandler.GetPublicServices)
+		api.GET("/services/:uuid/slots", bookingHandler.GetServiceSlots)
+
+		// Public exchange rates endpoint
+		api.GET("/exchange-rates", exchangeRateHandler.GetExchangeRates)
+
+		// Public equipment catalog endpoint
+		api.GET("/equipment", equipmentHandler.GetEquipment)
+
+		// Listing feeds for aggregators and ad platforms
+		api.GET("/feeds/:format", middleware.FeedTokenRequired(feedTokens()), feedHandler.GetFeed)
 	}
 
 	port := os.Getenv("PORT")
@@ -158,8 +388,28 @@ func main() {
 
 	log.Printf("Server is running on http://localhost:%s", port)
 	log.Printf("Swagger documentation available at http://localhost:%s/swagger/index.html", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := &http.Server{Addr: ":" + port, Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// On SIGINT or SIGTERM, let the requests in flight finish, then write the page views counted since the
+	// last flush so that a restart does not lose them
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Shutting down server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Server shutdown: %v", err)
+	}
+	stopViewFlush()
+	if _, err := vehicleRepo.FlushViewCounts(); err != nil {
+		log.Printf("Failed to flush view counts: %v", err)
 	}
 }
 
@@ -199,6 +449,67 @@ func handleMigrationCommand() {
 			log.Fatalf("Could not get migration version: %v", err)
 		}
 		fmt.Printf("Current version: %d, Dirty: %t\n", version, dirty)
+	case "catalog:backfill":
+		dryRun := len(os.Args) > 2 && os.Args[2] == "--dry-run"
+		result, err := catalog.Backfill(
+			repository.NewVehicleRepository(db),
+			repository.NewBrandRepository(db),
+			repository.NewAutomobileRepository(db),
+			dryRun,
+		)
+		if err != nil {
+			log.Fatalf("Catalog backfill failed: %v", err)
+		}
+		fmt.Printf("Scanned: %d, Brands linked: %d, Automobiles linked: %d, Unmatched: %d\n",
+			result.Scanned, result.BrandsLinked, result.AutomobileLinked, len(result.Unmatched))
+		for _, name := range result.Unmatched {
+			fmt.Printf("  unmatched: %s\n", name)
+		}
+	case "rates:load":
+		path := "./exchange_rates.json"
+		if len(os.Args) > 2 {
+			path = os.Args[2]
+		}
+		rates, err := currency.LoadFile(path)
+		if err != nil {
+			log.Fatalf("Loading exchange rates failed: %v", err)
+		}
+		if err := repository.NewExchangeRateRepository(db).SaveRates(rates); err != nil {
+			log.Fatalf("Saving exchange rates failed: %v", err)
+		}
+		fmt.Printf("Loaded %d exchange rates from %s\n", len(rates), path)
+	case "recommendations:refresh":
+		count, err := recommend.RefreshScores(repository.NewVehicleRepository(db), time.Now())
+		if err != nil {
+			log.Fatalf("Refreshing recommendation scores failed: %v", err)
+		}
+		fmt.Printf("Refreshed scores of %d vehicles\n", count)
+	case "drafts:cleanup":
+		count, err := cleanupDrafts(repository.NewVehicleRepository(db), draftTTL())
+		if err != nil {
+			log.Fatalf("Cleaning up drafts failed: %v", err)
+		}
+		fmt.Printf("Deleted %d stale drafts\n", count)
+	case "uploads:cleanup":
+		count, err := cleanupUploads(repository.NewUploadRepository(db), newUploadStore())
+		if err != nil {
+			log.Fatalf("Cleaning up uploads failed: %v", err)
+		}
+		fmt.Printf("Deleted %d expired uploads\n", count)
+	case "feeds:generate":
+		if err := generateFeed(repository.NewVehicleRepository(db), os.Args[2:]); err != nil {
+			log.Fatalf("Generating feed failed: %v", err)
+		}
+	case "sitemaps:generate":
+		dir := "./public"
+		if len(os.Args) > 2 {
+			dir = os.Args[2]
+		}
+		count, err := newSitemapGenerator(db).WriteFiles(dir, sitemapURL())
+		if err != nil {
+			log.Fatalf("Generating sitemaps failed: %v", err)
+		}
+		fmt.Printf("Wrote the sitemap index and %d sitemaps to %s\n", count, dir)
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		printMigrationUsage()
@@ -211,5 +522,293 @@ func printMigrationUsage() {
 	fmt.Println("  go run main.go migrate:up      - Run all pending migrations")
 	fmt.Println("  go run main.go migrate:down    - Rollback last migration")
 	fmt.Println("  go run main.go migrate:status  - Show current migration version")
+	fmt.Println("  go run main.go catalog:backfill [--dry-run] - Link vehicles to the brand/automobile catalog")
+	fmt.Println("  go run main.go rates:load [file] - Load exchange rates from a JSON file (default: ./exchange_rates.json)")
+	fmt.Println("  go run main.go recommendations:refresh - Recompute the recommendation score of all active vehicles")
+	fmt.Println("  go run main.go drafts:cleanup  - Delete drafts not edited within DRAFT_TTL")
+	fmt.Println("  go run main.go uploads:cleanup - Delete uploads not claimed by a vehicle within UPLOAD_TTL")
+	fmt.Println("  go run main.go feeds:generate [xml|csv|json] [file] [since] - Write the listing feed to a file (default: stdout)")
+	fmt.Println("  go run main.go sitemaps:generate [dir] - Write the sitemap index and sitemaps to a directory (default: ./public)")
 	fmt.Println("  go run main.go                 - Start the server")
 }
+
+// feedOptions returns the base URLs used in the listing feeds (SITE_URL and MEDIA_URL)
+func feedOptions() feeds.Options {
+	options := feeds.Options{
+		SiteURL:  os.Getenv("SITE_URL"),
+		MediaURL: os.Getenv("MEDIA_URL"),
+	}
+	if options.SiteURL == "" {
+		options.SiteURL = "http://localhost:3000"
+	}
+	if options.MediaURL == "" {
+		options.MediaURL = "http://localhost:8080"
+	}
+	return options
+}
+
+// feedTokens returns the comma separated FEED_TOKENS
+func feedTokens() []string {
+	var tokens []string
+	for _, token := range strings.Split(os.Getenv("FEED_TOKENS"), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// trustedProxies returns the comma separated TRUSTED_PROXIES, IPs or CIDR ranges whose forwarded client IP
+// headers are believed; none by default, so the client IP is the address of the connection
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// newSitemapGenerator creates the sitemap generator linking to pages of SITE_URL
+func newSitemapGenerator(db *sql.DB) *sitemap.Generator {
+	return sitemap.NewGenerator(repository.NewSitemapRepository(db), feedOptions().SiteURL)
+}
+
+// sitemapURL returns the base URL the sitemap files are served from (SITEMAP_URL, default SITE_URL)
+func sitemapURL() string {
+	if value := os.Getenv("SITEMAP_URL"); value != "" {
+		return value
+	}
+	return feedOptions().SiteURL
+}
+
+// sitemapCacheTTL returns how long rendered sitemaps are cached (SITEMAP_CACHE_TTL, default 1h)
+func sitemapCacheTTL() time.Duration {
+	value := os.Getenv("SITEMAP_CACHE_TTL")
+	if value == "" {
+		return time.Hour
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid SITEMAP_CACHE_TTL: %v", err)
+	}
+	return ttl
+}
+
+// generateFeed handles feeds:generate [format] [file] [since]
+func generateFeed(vehicleRepo *repository.VehicleRepository, args []string) error {
+	format := feeds.FormatXML
+	if len(args) > 0 {
+		format = feeds.Format(args[0])
+	}
+	if !feeds.IsSupported(format) {
+		return fmt.Errorf("unsupported feed format %q", format)
+	}
+
+	options := feedOptions()
+	options.GeneratedAt = time.Now()
+	if len(args) > 2 {
+		since, err := time.Parse(time.RFC3339, args[2])
+		if err != nil {
+			return fmt.Errorf("invalid since value, expected an RFC 3339 time: %w", err)
+		}
+		options.Since = since
+	}
+
+	vehicles, err := feeds.Load(vehicleRepo, options.Since)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if len(args) > 1 && args[1] != "-" {
+		file, err := os.Create(args[1])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if err := feeds.Write(out, format, vehicles, options); err != nil {
+		return err
+	}
+	if out != os.Stdout {
+		log.Printf("Wrote %s feed with %d vehicles to %s", format, len(vehicles), args[1])
+	}
+	return nil
+}
+
+// draftCleanupInterval is how often stale drafts are deleted
+const draftCleanupInterval = time.Hour
+
+// uploadCleanupInterval is how often expired uploads are deleted
+const uploadCleanupInterval = 15 * time.Minute
+
+// shutdownTimeout bounds how long the server waits for requests in flight when shutting down
+const shutdownTimeout = 15 * time.Second
+
+// viewFlushInterval is how often the vehicle page views counted in memory are written to the database
+const viewFlushInterval = time.Minute
+
+// paymentProvider returns the payment gateway selected by PAYMENT_PROVIDER, or nil when payments are disabled.
+// The mock gateway settles payments without charging anyone, so it must be selected explicitly, with its
+// secret, and is refused in release mode (GIN_MODE=release). Without a provider the rest of the API runs and
+// the payment endpoints answer 503.
+func paymentProvider() payments.Provider {
+	switch provider := os.Getenv("PAYMENT_PROVIDER"); provider {
+	case "mock":
+		if gin.Mode() == gin.ReleaseMode {
+			log.Printf("Warning: PAYMENT_PROVIDER=mock cannot be used in release mode, payments are disabled")
+			return nil
+		}
+		secret := os.Getenv("MOCK_PAYMENT_SECRET")
+		if secret == "" {
+			log.Printf("Warning: MOCK_PAYMENT_SECRET is not set, payments are disabled")
+			return nil
+		}
+		webhookURL := os.Getenv("MOCK_PAYMENT_WEBHOOK_URL")
+		if webhookURL == "" {
+			port := os.Getenv("PORT")
+			if port == "" {
+				port = "8080"
+			}
+			webhookURL = "http://localhost:" + port + "/api/payments/webhooks/mock"
+		}
+		delay := 5 * time.Second
+		if value := os.Getenv("MOCK_PAYMENT_WEBHOOK_DELAY"); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				log.Fatalf("Invalid MOCK_PAYMENT_WEBHOOK_DELAY: %v", err)
+			}
+			delay = parsed
+		}
+		return payments.NewMockProvider(secret, webhookURL, delay)
+	case "":
+		log.Printf("Warning: PAYMENT_PROVIDER is not set, payments are disabled")
+		return nil
+	default:
+		log.Fatalf("Unsupported PAYMENT_PROVIDER: %s", provider)
+		return nil
+	}
+}
+
+// bookingLocation returns the time zone of the workshop's working hours and of the times in
+// appointment emails, set by BOOKING_TIMEZONE
+func bookingLocation() *time.Location {
+	name := os.Getenv("BOOKING_TIMEZONE")
+	if name == "" {
+		name = "Europe/Bucharest"
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		log.Fatalf("Invalid BOOKING_TIMEZONE: %v", err)
+	}
+	return location
+}
+
+// draftTTL returns how long drafts are kept without changes (DRAFT_TTL, default 30 days, 0 keeps them)
+func draftTTL() time.Duration {
+	value := os.Getenv("DRAFT_TTL")
+	if value == "" {
+		return 30 * 24 * time.Hour
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid DRAFT_TTL: %v", err)
+	}
+	return ttl
+}
+
+// cleanupDrafts deletes the drafts not edited within ttl with their image files and returns how many were deleted
+func cleanupDrafts(vehicleRepo *repository.VehicleRepository, ttl time.Duration) (int, error) {
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-ttl)
+	ids, err := vehicleRepo.GetStaleDraftIDs(cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, id := range ids {
+		// A draft edited or published since it was listed is no longer stale and is left alone
+		imagePaths, ok, err := vehicleRepo.DeleteStaleDraft(id, cutoff)
+		if err != nil {
+			return deleted, err
+		}
+		if !ok {
+			continue
+		}
+		deleted++
+		for _, path := range imagePaths {
+			_ = utils.DeleteFile(path)
+		}
+	}
+	return deleted, nil
+}
+
+// uploadTTL returns how long uploads wait to be claimed by a vehicle before they expire (UPLOAD_TTL, default 24 hours)
+func uploadTTL() time.Duration {
+	value := os.Getenv("UPLOAD_TTL")
+	if value == "" {
+		return 24 * time.Hour
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Fatalf("Invalid UPLOAD_TTL: %s", value)
+	}
+	return ttl
+}
+
+// newUploadStore stores upload parts outside the served ./uploads directory until they are complete images
+func newUploadStore() *uploads.Store {
+	return uploads.NewStore("./storage/uploads", "./uploads/vehicles")
+}
+
+// cleanupUploads deletes the uploads that expired before a vehicle claimed them, with their files
+func cleanupUploads(uploadRepo *repository.UploadRepository, store *uploads.Store) (int, error) {
+	expired, err := uploadRepo.GetExpired()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for i := range expired {
+		// A vehicle may have claimed the upload since it was listed, its image is then in use
+		ok, err := uploadRepo.DeleteExpired(expired[i].ID)
+		if err != nil {
+			return deleted, err
+		}
+		if !ok {
+			continue
+		}
+		deleted++
+		if err := store.Discard(&expired[i]); err != nil {
+			log.Printf("uploads: failed to delete files of %s: %v", expired[i].UUID, err)
+		}
+	}
+	return deleted, nil
+}
+
+// checkExchangeRates reports the supported currencies that have no exchange rate
+func checkExchangeRates(exchangeRateRepo *repository.ExchangeRateRepository) error {
+	rates, err := exchangeRateRepo.GetRates()
+	if err != nil {
+		return fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+
+	var missing []string
+	for _, code := range models.SupportedCurrencies {
+		if rates[code] <= 0 {
+			missing = append(missing, code)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no exchange rate for %s, load them with rates:load or PUT /api/admin/exchange-rates (see exchange_rates.example.json)", strings.Join(missing, ", "))
+	}
+	return nil
+}
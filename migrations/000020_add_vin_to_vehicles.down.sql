@@ -0,0 +1,5 @@
+-- Remove vin column from vehicles table
+
+ALTER TABLE vehicles
+DROP INDEX idx_vin,
+DROP COLUMN vin;
@@ -0,0 +1,5 @@
+-- Add optional vehicle identification number to vehicles table
+
+ALTER TABLE vehicles
+ADD COLUMN vin VARCHAR(17) NULL AFTER model,
+ADD INDEX idx_vin (vin);
@@ -0,0 +1,7 @@
+ALTER TABLE vehicles
+DROP FOREIGN KEY fk_vehicles_brand_id,
+DROP FOREIGN KEY fk_vehicles_automobile_id,
+DROP INDEX idx_brand_id,
+DROP INDEX idx_automobile_id,
+DROP COLUMN brand_id,
+DROP COLUMN automobile_id;
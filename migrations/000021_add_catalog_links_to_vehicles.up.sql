@@ -0,0 +1,10 @@
+-- Link vehicle listings to the brand and automobile catalog
+-- The free-text brand/model columns are kept for display and for rows that cannot be matched
+
+ALTER TABLE vehicles
+ADD COLUMN brand_id BIGINT UNSIGNED NULL AFTER person_type_id,
+ADD COLUMN automobile_id BIGINT UNSIGNED NULL AFTER brand_id,
+ADD INDEX idx_brand_id (brand_id),
+ADD INDEX idx_automobile_id (automobile_id),
+ADD CONSTRAINT fk_vehicles_brand_id FOREIGN KEY (brand_id) REFERENCES brands(id) ON DELETE SET NULL,
+ADD CONSTRAINT fk_vehicles_automobile_id FOREIGN KEY (automobile_id) REFERENCES automobiles(id) ON DELETE SET NULL;
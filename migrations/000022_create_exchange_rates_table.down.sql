@@ -0,0 +1,5 @@
+ALTER TABLE vehicles
+DROP INDEX idx_price_normalized,
+DROP COLUMN price_normalized;
+
+DROP TABLE IF EXISTS exchange_rates;
@@ -0,0 +1,26 @@
+-- Create exchange rates table and a normalized price column for cross-currency filtering
+-- Rates are the value of one unit of a currency in the base currency (lei)
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency VARCHAR(10) NOT NULL PRIMARY KEY,
+    rate DECIMAL(18, 8) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO exchange_rates (currency, rate) VALUES ('lei', 1);
+
+-- Bring existing free-text currencies onto the whitelist (lei, euro, usd)
+UPDATE vehicles SET currency = 'lei' WHERE LOWER(currency) IN ('lei', 'ron');
+UPDATE vehicles SET currency = 'euro' WHERE LOWER(currency) IN ('euro', 'eur', '€');
+UPDATE vehicles SET currency = 'usd' WHERE LOWER(currency) IN ('usd', '$');
+UPDATE services SET currency = 'lei' WHERE LOWER(currency) IN ('lei', 'ron');
+UPDATE services SET currency = 'euro' WHERE LOWER(currency) IN ('euro', 'eur', '€');
+UPDATE services SET currency = 'usd' WHERE LOWER(currency) IN ('usd', '$');
+
+ALTER TABLE vehicles
+ADD COLUMN price_normalized DECIMAL(14, 2) NULL AFTER currency,
+ADD INDEX idx_price_normalized (price_normalized);
+
+UPDATE vehicles v
+INNER JOIN exchange_rates er ON er.currency = v.currency
+SET v.price_normalized = ROUND(v.price * er.rate, 2), v.updated_at = v.updated_at;
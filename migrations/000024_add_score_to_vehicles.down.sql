@@ -0,0 +1,5 @@
+ALTER TABLE vehicles
+DROP INDEX idx_status_score,
+DROP COLUMN score_updated_at,
+DROP COLUMN score,
+DROP COLUMN view_count;
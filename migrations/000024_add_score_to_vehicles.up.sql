@@ -0,0 +1,8 @@
+-- Add listing quality/freshness score and view counter used to rank recommended vehicles
+-- The score is recomputed periodically (see recommendations:refresh); the recommended flag is kept as a boost
+
+ALTER TABLE vehicles
+ADD COLUMN view_count INT UNSIGNED NOT NULL DEFAULT 0 AFTER recommended,
+ADD COLUMN score DECIMAL(6, 2) NOT NULL DEFAULT 0 AFTER view_count,
+ADD COLUMN score_updated_at TIMESTAMP NULL AFTER score,
+ADD INDEX idx_status_score (status, score);
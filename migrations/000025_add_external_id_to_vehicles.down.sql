@@ -0,0 +1,3 @@
+ALTER TABLE vehicles
+DROP INDEX idx_user_external_id,
+DROP COLUMN external_id;
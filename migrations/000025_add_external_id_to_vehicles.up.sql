@@ -0,0 +1,5 @@
+-- Add dealer-supplied external ID so bulk re-imports update listings instead of duplicating them
+
+ALTER TABLE vehicles
+ADD COLUMN external_id VARCHAR(100) NULL AFTER user_id,
+ADD UNIQUE INDEX idx_user_external_id (user_id, external_id);
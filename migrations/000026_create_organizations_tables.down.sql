@@ -0,0 +1,7 @@
+ALTER TABLE vehicles
+DROP FOREIGN KEY fk_vehicles_organization_id,
+DROP INDEX idx_organization_id,
+DROP COLUMN organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
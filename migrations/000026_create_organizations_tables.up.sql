@@ -0,0 +1,38 @@
+-- Dealer organizations: a shared profile and storefront, members with roles (owner, manager, agent)
+-- and vehicles listed on behalf of the organization
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    logo VARCHAR(500) NULL,
+    email VARCHAR(255) NULL,
+    phone VARCHAR(50) NULL,
+    website VARCHAR(255) NULL,
+    address VARCHAR(255) NULL,
+    city VARCHAR(100) NULL,
+    county VARCHAR(100) NULL,
+    postal_code VARCHAR(20) NULL,
+    vat_code VARCHAR(20) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    role ENUM('owner', 'manager', 'agent') NOT NULL DEFAULT 'agent',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (organization_id, user_id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE vehicles
+ADD COLUMN organization_id BIGINT UNSIGNED NULL AFTER user_id,
+ADD INDEX idx_organization_id (organization_id),
+ADD CONSTRAINT fk_vehicles_organization_id FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE SET NULL;
@@ -0,0 +1,64 @@
+-- Managed equipment catalog (grouped by category) and the equipment of each vehicle
+
+CREATE TABLE IF NOT EXISTS equipment_categories (
+    id SMALLINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL,
+    sort_order SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS equipment (
+    id SMALLINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    category_id SMALLINT UNSIGNED NOT NULL,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL,
+    sort_order SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (category_id) REFERENCES equipment_categories(id) ON DELETE RESTRICT,
+    INDEX idx_category_id (category_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS vehicle_equipment (
+    vehicle_id BIGINT UNSIGNED NOT NULL,
+    equipment_id SMALLINT UNSIGNED NOT NULL,
+
+    PRIMARY KEY (vehicle_id, equipment_id),
+    FOREIGN KEY (vehicle_id) REFERENCES vehicles(id) ON DELETE CASCADE,
+    FOREIGN KEY (equipment_id) REFERENCES equipment(id) ON DELETE CASCADE,
+    INDEX idx_equipment_id (equipment_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Seed data
+INSERT INTO equipment_categories (name, display_name, sort_order) VALUES
+('comfort', 'Confort', 1),
+('safety', 'Siguranță', 2),
+('multimedia', 'Multimedia', 3),
+('exterior', 'Exterior', 4);
+
+INSERT INTO equipment (category_id, name, display_name, sort_order)
+SELECT c.id, e.name, e.display_name, e.sort_order
+FROM equipment_categories c
+INNER JOIN (
+    SELECT 'comfort' AS category, 'ac' AS name, 'Aer condiționat' AS display_name, 1 AS sort_order
+    UNION ALL SELECT 'comfort', 'climate_control', 'Climatronic', 2
+    UNION ALL SELECT 'comfort', 'leather_seats', 'Scaune din piele', 3
+    UNION ALL SELECT 'comfort', 'heated_seats', 'Scaune încălzite', 4
+    UNION ALL SELECT 'comfort', 'cruise_control', 'Cruise control', 5
+    UNION ALL SELECT 'comfort', 'keyless_entry', 'Keyless entry', 6
+    UNION ALL SELECT 'safety', 'abs', 'ABS', 1
+    UNION ALL SELECT 'safety', 'esp', 'ESP', 2
+    UNION ALL SELECT 'safety', 'parking_sensors_front', 'Senzori parcare față', 3
+    UNION ALL SELECT 'safety', 'parking_sensors_rear', 'Senzori parcare spate', 4
+    UNION ALL SELECT 'safety', 'rear_camera', 'Cameră marșarier', 5
+    UNION ALL SELECT 'safety', 'lane_assist', 'Asistent menținere bandă', 6
+    UNION ALL SELECT 'multimedia', 'navigation', 'Navigație', 1
+    UNION ALL SELECT 'multimedia', 'bluetooth', 'Bluetooth', 2
+    UNION ALL SELECT 'multimedia', 'apple_carplay', 'Apple CarPlay', 3
+    UNION ALL SELECT 'multimedia', 'android_auto', 'Android Auto', 4
+    UNION ALL SELECT 'exterior', 'alloy_wheels', 'Jante aliaj', 1
+    UNION ALL SELECT 'exterior', 'led_headlights', 'Faruri LED', 2
+    UNION ALL SELECT 'exterior', 'sunroof', 'Trapă', 3
+    UNION ALL SELECT 'exterior', 'tow_hitch', 'Cârlig remorcare', 4
+) e ON e.category = c.name;
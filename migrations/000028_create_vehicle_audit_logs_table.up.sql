@@ -0,0 +1,19 @@
+-- Field-level history of vehicle listings: who created, edited or changed the status of a listing,
+-- from which IP, and the old and new value of every changed field. Entries are kept when the vehicle
+-- or the acting user is deleted, so vehicle_id has no foreign key.
+
+CREATE TABLE IF NOT EXISTS vehicle_audit_logs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    vehicle_id BIGINT UNSIGNED NOT NULL,
+    actor_id BIGINT UNSIGNED NULL,
+    actor_role ENUM('owner', 'member', 'admin') NOT NULL,
+    action ENUM('create', 'update', 'status') NOT NULL,
+    ip_address VARCHAR(45) NULL,
+    changes JSON NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_vehicle_id_created_at (vehicle_id, created_at),
+    INDEX idx_actor_id (actor_id),
+    INDEX idx_ip_address (ip_address)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
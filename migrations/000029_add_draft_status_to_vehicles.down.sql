@@ -0,0 +1,11 @@
+DELETE FROM vehicles WHERE status = 4;
+
+ALTER TABLE vehicles
+DROP INDEX idx_status_updated_at,
+MODIFY COLUMN status TINYINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '1=active, 2=inactive, 3=banned',
+MODIFY COLUMN person_type_id TINYINT UNSIGNED NOT NULL,
+MODIFY COLUMN fuel_type_id TINYINT UNSIGNED NOT NULL,
+MODIFY COLUMN body_type_id TINYINT UNSIGNED NOT NULL,
+MODIFY COLUMN condition_id TINYINT UNSIGNED NOT NULL,
+MODIFY COLUMN transmission_id TINYINT UNSIGNED NOT NULL,
+MODIFY COLUMN steering_id TINYINT UNSIGNED NOT NULL;
@@ -0,0 +1,12 @@
+-- Draft listings (status 4) are saved incomplete, so the lookup columns become nullable.
+-- Published listings always have them; the application validates them on publish.
+
+ALTER TABLE vehicles
+MODIFY COLUMN status TINYINT UNSIGNED NOT NULL DEFAULT 1 COMMENT '1=active, 2=inactive, 3=banned, 4=draft',
+MODIFY COLUMN person_type_id TINYINT UNSIGNED NULL,
+MODIFY COLUMN fuel_type_id TINYINT UNSIGNED NULL,
+MODIFY COLUMN body_type_id TINYINT UNSIGNED NULL,
+MODIFY COLUMN condition_id TINYINT UNSIGNED NULL,
+MODIFY COLUMN transmission_id TINYINT UNSIGNED NULL,
+MODIFY COLUMN steering_id TINYINT UNSIGNED NULL,
+ADD INDEX idx_status_updated_at (status, updated_at);
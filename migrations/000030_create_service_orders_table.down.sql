@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS service_orders;
+
+ALTER TABLE vehicles
+DROP INDEX idx_highlighted_until,
+DROP INDEX idx_promoted_until,
+DROP COLUMN highlighted_until,
+DROP COLUMN promoted_until;
+
+ALTER TABLE services
+DROP COLUMN promotion;
@@ -0,0 +1,35 @@
+-- Listing promotions: services granting a promotion (top listing or homepage highlight) can be
+-- ordered for a vehicle. An activated order extends the promotion window stored on the vehicle.
+
+ALTER TABLE services
+ADD COLUMN promotion ENUM('top', 'highlight') NULL AFTER duration_minutes;
+
+ALTER TABLE vehicles
+ADD COLUMN promoted_until TIMESTAMP NULL AFTER score,
+ADD COLUMN highlighted_until TIMESTAMP NULL AFTER promoted_until,
+ADD INDEX idx_promoted_until (promoted_until),
+ADD INDEX idx_highlighted_until (highlighted_until);
+
+CREATE TABLE IF NOT EXISTS service_orders (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    user_id BIGINT UNSIGNED NOT NULL,
+    vehicle_id BIGINT UNSIGNED NOT NULL,
+    service_id BIGINT UNSIGNED NOT NULL,
+    promotion ENUM('top', 'highlight') NOT NULL,
+    price DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    duration_minutes INT UNSIGNED NOT NULL,
+    status ENUM('pending', 'active', 'cancelled') NOT NULL DEFAULT 'pending',
+    starts_at TIMESTAMP NULL,
+    ends_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (vehicle_id) REFERENCES vehicles(id) ON DELETE CASCADE,
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE RESTRICT,
+    INDEX idx_user_id (user_id),
+    INDEX idx_vehicle_id (vehicle_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,39 @@
+-- Payments for service orders. Every status change is recorded in payment_events; webhook
+-- events are stored with their provider event ID so redelivered webhooks are applied once.
+
+CREATE TABLE IF NOT EXISTS payments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    user_id BIGINT UNSIGNED NOT NULL,
+    service_order_id BIGINT UNSIGNED NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    provider_intent_id VARCHAR(255) NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    status ENUM('pending', 'processing', 'succeeded', 'failed', 'refunded') NOT NULL DEFAULT 'pending',
+    failure_reason VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (service_order_id) REFERENCES service_orders(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_user_idempotency_key (user_id, idempotency_key),
+    UNIQUE KEY uk_provider_intent (provider, provider_intent_id),
+    INDEX idx_service_order_id (service_order_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS payment_events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    payment_id BIGINT UNSIGNED NOT NULL,
+    from_status ENUM('pending', 'processing', 'succeeded', 'failed', 'refunded') NULL,
+    to_status ENUM('pending', 'processing', 'succeeded', 'failed', 'refunded') NOT NULL,
+    source ENUM('user', 'admin', 'webhook', 'system') NOT NULL,
+    provider_event_id VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_payment_provider_event (payment_id, provider_event_id),
+    INDEX idx_payment_id (payment_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bookings;
+DROP TABLE IF EXISTS booking_days;
+DROP TABLE IF EXISTS booking_exceptions;
+DROP TABLE IF EXISTS booking_hours;
@@ -0,0 +1,48 @@
+-- Appointment booking for services: weekly working hours and dated exceptions define when
+-- appointments can take place and how many can run at the same time (capacity).
+
+CREATE TABLE IF NOT EXISTS booking_hours (
+    id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    weekday TINYINT UNSIGNED NOT NULL COMMENT '0=Sunday ... 6=Saturday',
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL,
+    capacity INT UNSIGNED NOT NULL DEFAULT 1,
+
+    INDEX idx_weekday (weekday)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Exceptions replace the working hours of their date; a row without hours closes the day
+CREATE TABLE IF NOT EXISTS booking_exceptions (
+    id INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    date DATE NOT NULL,
+    opens_at TIME NULL,
+    closes_at TIME NULL,
+    capacity INT UNSIGNED NULL,
+    note VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX idx_date (date)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- One row per booked date, locked while booking so concurrent requests cannot overbook the day
+CREATE TABLE IF NOT EXISTS booking_days (
+    day DATE PRIMARY KEY
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS bookings (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    user_id BIGINT UNSIGNED NOT NULL,
+    service_id BIGINT UNSIGNED NOT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    status ENUM('confirmed', 'cancelled') NOT NULL DEFAULT 'confirmed',
+    notes TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (service_id) REFERENCES services(id) ON DELETE RESTRICT,
+    INDEX idx_user_id (user_id),
+    INDEX idx_status_starts_at (status, starts_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS viewing_request_slots;
+DROP TABLE IF EXISTS viewing_requests;
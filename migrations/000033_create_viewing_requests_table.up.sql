@@ -0,0 +1,34 @@
+-- Viewing and test drive requests: buyers propose time slots for a listing, sellers accept one,
+-- counter-propose other slots or decline. Each round of proposals is kept in viewing_request_slots.
+
+CREATE TABLE IF NOT EXISTS viewing_requests (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    vehicle_id BIGINT UNSIGNED NOT NULL,
+    buyer_id BIGINT UNSIGNED NOT NULL,
+    kind ENUM('viewing', 'test_drive') NOT NULL DEFAULT 'viewing',
+    message TEXT NULL,
+    status ENUM('pending', 'countered', 'accepted', 'declined', 'cancelled') NOT NULL DEFAULT 'pending' COMMENT 'pending: awaiting the seller, countered: awaiting the buyer',
+    scheduled_at DATETIME NULL,
+    duration_minutes INT UNSIGNED NOT NULL,
+    response_message TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (vehicle_id) REFERENCES vehicles(id) ON DELETE CASCADE,
+    FOREIGN KEY (buyer_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_vehicle_id (vehicle_id),
+    INDEX idx_buyer_id (buyer_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS viewing_request_slots (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    viewing_request_id BIGINT UNSIGNED NOT NULL,
+    round INT UNSIGNED NOT NULL,
+    proposed_by ENUM('buyer', 'seller') NOT NULL,
+    starts_at DATETIME NOT NULL,
+
+    FOREIGN KEY (viewing_request_id) REFERENCES viewing_requests(id) ON DELETE CASCADE,
+    INDEX idx_viewing_request_round (viewing_request_id, round)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
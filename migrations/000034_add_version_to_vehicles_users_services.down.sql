@@ -0,0 +1,8 @@
+ALTER TABLE services
+DROP COLUMN version;
+
+ALTER TABLE users
+DROP COLUMN version;
+
+ALTER TABLE vehicles
+DROP COLUMN version;
@@ -0,0 +1,11 @@
+-- Optimistic concurrency control: every update of a row increments its version, and updates
+-- only apply to the version the client read (sent back as the If-Match ETag).
+
+ALTER TABLE vehicles
+ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER phone;
+
+ALTER TABLE users
+ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER active;
+
+ALTER TABLE services
+ADD COLUMN version INT UNSIGNED NOT NULL DEFAULT 1 AFTER active;
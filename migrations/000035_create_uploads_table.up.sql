@@ -0,0 +1,21 @@
+-- Upload sessions: images are uploaded in chunks before the vehicle they belong to is created
+-- or updated, which then claims them by UUID. Uploads not claimed before expires_at are deleted
+-- with their files.
+
+CREATE TABLE IF NOT EXISTS uploads (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    uuid CHAR(36) NOT NULL UNIQUE,
+    user_id BIGINT UNSIGNED NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    size INT UNSIGNED NOT NULL,
+    received INT UNSIGNED NOT NULL DEFAULT 0,
+    status ENUM('pending', 'completed') NOT NULL DEFAULT 'pending' COMMENT 'pending: receiving chunks, completed: waiting to be claimed',
+    image_url VARCHAR(500) NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_user_status (user_id, status),
+    INDEX idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
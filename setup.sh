@@ -23,12 +23,20 @@ if [ ! -f .env ]; then
     echo "Please update .env with your database credentials"
 fi
 
+# Create exchange_rates.json if it doesn't exist
+if [ ! -f exchange_rates.json ]; then
+    echo "Creating exchange_rates.json file..."
+    cp exchange_rates.example.json exchange_rates.json
+    echo "Please update exchange_rates.json with current exchange rates"
+fi
+
 echo ""
 echo "=== Setup Complete ==="
 echo ""
 echo "Next steps:"
 echo "1. Update .env with your database credentials"
 echo "2. Run migrations: go run main.go migrate:up"
-echo "3. Start server: go run main.go"
+echo "3. Load exchange rates: go run main.go rates:load"
+echo "4. Start server: go run main.go"
 echo ""
 echo "Swagger UI will be available at: http://localhost:8080/swagger/index.html"